@@ -0,0 +1,158 @@
+// cmd/matchsim/arrivals.go
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPartySize matches the game server's party limit
+const maxPartySize = 4
+
+// arrival is one player entering the queue; players with the same party label
+// and offset queue together
+type arrival struct {
+	Offset   time.Duration
+	Username string
+	Skill    int
+	Party    string
+	Platform string
+	Patience time.Duration // How long the player waits before leaving, 0 = the server's wait limit
+}
+
+// syntheticLoad describes generated queue traffic
+type syntheticLoad struct {
+	duration    time.Duration
+	perMinute   float64
+	skillMean   float64
+	skillStdDev float64
+	partyRate   float64
+	patience    time.Duration
+	maxParty    int
+}
+
+// generateArrivals draws Poisson arrivals with normally distributed ratings.
+// Party members are rated close to each other.
+func generateArrivals(rng *rand.Rand, load syntheticLoad) []arrival {
+	if load.perMinute <= 0 {
+		return nil
+	}
+
+	maxParty := load.maxParty
+	if maxParty > maxPartySize {
+		maxParty = maxPartySize
+	}
+
+	var arrivals []arrival
+	players, parties := 0, 0
+	offset := time.Duration(0)
+	for {
+		offset += time.Duration(rng.ExpFloat64() / load.perMinute * float64(time.Minute))
+		if offset > load.duration {
+			return arrivals
+		}
+
+		size, party := 1, ""
+		if maxParty >= 2 && rng.Float64() < load.partyRate {
+			size = 2 + rng.Intn(maxParty-1)
+			parties++
+			party = fmt.Sprintf("party-%d", parties)
+		}
+
+		skill := rng.NormFloat64()*load.skillStdDev + load.skillMean
+		var patience time.Duration
+		if load.patience > 0 {
+			patience = time.Duration(rng.ExpFloat64() * float64(load.patience))
+		}
+		for i := 0; i < size; i++ {
+			players++
+			memberSkill := skill
+			if size > 1 {
+				memberSkill += rng.NormFloat64() * 100
+			}
+			arrivals = append(arrivals, arrival{
+				Offset:   offset,
+				Username: fmt.Sprintf("player-%d", players),
+				Skill:    clampSkill(memberSkill),
+				Party:    party,
+				Patience: patience,
+			})
+		}
+	}
+}
+
+// clampSkill keeps generated ratings in a sensible range
+func clampSkill(skill float64) int {
+	return int(math.Round(math.Max(0, math.Min(3000, skill))))
+}
+
+// loadArrivals reads recorded arrivals from a CSV file with the columns
+// offset,username,skill[,party,platform,patience]. Offsets and patience are Go
+// durations ("90s") or seconds. A header row is skipped.
+func loadArrivals(path string) ([]arrival, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var arrivals []arrival
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return arrivals, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("line %d: expected at least offset,username,skill", line)
+		}
+
+		offset, err := parseDuration(record[0])
+		if err != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: invalid offset %q", line, record[0])
+		}
+		skill, err := strconv.Atoi(strings.TrimSpace(record[2]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid skill %q", line, record[2])
+		}
+
+		next := arrival{Offset: offset, Username: strings.TrimSpace(record[1]), Skill: skill}
+		if len(record) > 3 {
+			next.Party = strings.TrimSpace(record[3])
+		}
+		if len(record) > 4 {
+			next.Platform = strings.TrimSpace(record[4])
+		}
+		if len(record) > 5 && strings.TrimSpace(record[5]) != "" {
+			if next.Patience, err = parseDuration(record[5]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid patience %q", line, record[5])
+			}
+		}
+		arrivals = append(arrivals, next)
+	}
+}
+
+// parseDuration accepts a Go duration or a number of seconds
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return time.ParseDuration(value)
+}
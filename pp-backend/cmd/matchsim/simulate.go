@@ -0,0 +1,228 @@
+// cmd/matchsim/simulate.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+)
+
+// Summary keys of a distribution
+var percentiles = []struct {
+	name string
+	rank float64
+}{{"p50", 0.50}, {"p90", 0.90}, {"p95", 0.95}, {"p99", 0.99}, {"max", 1}}
+
+// poolSample is the queue length at a point of virtual time
+type poolSample struct {
+	At   float64 `json:"at"` // Seconds since the first arrival
+	Size int     `json:"size"`
+}
+
+// report is the outcome of one simulation run
+type report struct {
+	GameType        string                        `json:"gameType"`
+	Rules           *gameserver.GameTypeRules     `json:"rules"`
+	Config          *gameserver.MatchmakingConfig `json:"config"`
+	Arrivals        int                           `json:"arrivals"`
+	Matched         int                           `json:"matched"`
+	Abandoned       int                           `json:"abandoned"`
+	AbandonmentRate float64                       `json:"abandonmentRate"`
+	Matches         int                           `json:"matches"`
+	MatchSizes      map[int]int                   `json:"matchSizes"`
+	WaitSeconds     map[string]float64            `json:"waitSeconds"` // Wait of matched players
+	SkillSpread     map[string]float64            `json:"skillSpread"` // Highest minus lowest rating per match
+	PoolSize        []poolSample                  `json:"poolSize"`
+	SimulatedTime   float64                       `json:"simulatedTime"` // Seconds of virtual time
+}
+
+// simulate replays the arrivals through the matchmaking pass, one tick of the
+// virtual clock at a time. Players leave once they waited longer than their
+// patience or the server's wait limit, as the live cleanup does.
+func simulate(arrivals []arrival, settings *gameserver.MatchmakingConfig, rules *gameserver.GameTypeRules, sampleEvery time.Duration) *report {
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	requests := buildRequests(arrivals, settings, rules, start)
+
+	result := &report{
+		GameType:   string(rules.GameType),
+		Rules:      rules,
+		Config:     settings,
+		Arrivals:   len(requests),
+		MatchSizes: make(map[int]int),
+	}
+
+	var pool []*gameserver.MatchmakingRequest
+	var waits, spreads []float64
+	next := 0
+	nextSample := start
+	lastArrival := requests[len(requests)-1].CreatedAt
+
+	now := start
+	for ; next < len(requests) || len(pool) > 0; now = now.Add(settings.TickInterval) {
+		for next < len(requests) && !requests[next].CreatedAt.After(now) {
+			pool = append(pool, requests[next])
+			next++
+		}
+
+		matches, remaining := gameserver.MatchPool(pool, settings, rules, now)
+		for _, match := range matches {
+			low, high := math.MaxInt, math.MinInt
+			for _, player := range match {
+				waits = append(waits, now.Sub(player.CreatedAt).Seconds())
+				low = min(low, player.SkillLevel)
+				high = max(high, player.SkillLevel)
+			}
+			spreads = append(spreads, float64(high-low))
+			result.Matches++
+			result.Matched += len(match)
+			result.MatchSizes[len(match)]++
+		}
+
+		pool = pool[:0]
+		for _, request := range remaining {
+			if now.Sub(request.CreatedAt) > request.MaxWaitTime {
+				result.Abandoned++
+				continue
+			}
+			pool = append(pool, request)
+		}
+
+		for !nextSample.After(now) {
+			result.PoolSize = append(result.PoolSize, poolSample{At: nextSample.Sub(start).Seconds(), Size: len(pool)})
+			nextSample = nextSample.Add(sampleEvery)
+		}
+
+		// Everyone left has either been matched or given up by now
+		if now.After(lastArrival.Add(settings.MaxWaitTime + settings.TickInterval)) {
+			result.Abandoned += len(pool)
+			break
+		}
+	}
+
+	result.SimulatedTime = now.Sub(start).Seconds()
+	result.AbandonmentRate = float64(result.Abandoned) / float64(result.Arrivals)
+	result.WaitSeconds = summarize(waits)
+	result.SkillSpread = summarize(spreads)
+	return result
+}
+
+// buildRequests turns arrivals into matchmaking requests on the virtual clock,
+// sharing a party ID between members that arrived together
+func buildRequests(arrivals []arrival, settings *gameserver.MatchmakingConfig, rules *gameserver.GameTypeRules, start time.Time) []*gameserver.MatchmakingRequest {
+	sort.SliceStable(arrivals, func(i, j int) bool { return arrivals[i].Offset < arrivals[j].Offset })
+
+	parties := make(map[string]*uuid.UUID)
+	requests := make([]*gameserver.MatchmakingRequest, 0, len(arrivals))
+	for _, arrival := range arrivals {
+		maxWait := settings.DefaultWaitTime
+		if arrival.Patience > 0 {
+			maxWait = min(arrival.Patience, settings.MaxWaitTime)
+		}
+		platform := arrival.Platform
+		if platform == "" {
+			platform = gameserver.PlatformWeb
+		}
+
+		request := &gameserver.MatchmakingRequest{
+			ID:          uuid.New(),
+			Username:    arrival.Username,
+			GameType:    rules.GameType,
+			Platform:    platform,
+			SkillLevel:  arrival.Skill,
+			MaxWaitTime: maxWait,
+			CreatedAt:   start.Add(arrival.Offset),
+		}
+		if arrival.Party != "" {
+			key := fmt.Sprintf("%s@%d", arrival.Party, arrival.Offset)
+			if _, exists := parties[key]; !exists {
+				partyID := uuid.New()
+				parties[key] = &partyID
+			}
+			request.PartyID = parties[key]
+		}
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+// summarize returns the percentiles and mean of a distribution
+func summarize(values []float64) map[string]float64 {
+	summary := make(map[string]float64)
+	if len(values) == 0 {
+		return summary
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	total := 0.0
+	for _, value := range sorted {
+		total += value
+	}
+	summary["mean"] = total / float64(len(sorted))
+	for _, p := range percentiles {
+		rank := int(math.Ceil(p.rank*float64(len(sorted)))) - 1
+		summary[p.name] = sorted[max(rank, 0)]
+	}
+	return summary
+}
+
+// writeJSON prints the report for other tools
+func (r *report) writeJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// print writes a human readable report
+func (r *report) print(w io.Writer) {
+	fmt.Fprintf(w, "🎯 %s: %d-%d players (optimal %d), match timeout %s, skill range %d\n",
+		r.GameType, r.Rules.MinPlayers, r.Rules.MaxPlayers, r.Rules.OptimalPlayers, r.Rules.MatchTimeout, r.Rules.SkillRange)
+	fmt.Fprintf(w, "   tick %s, skill expansion x%.2f per 30s up to %d, wait limit %s\n\n",
+		r.Config.TickInterval, r.Config.SkillExpansionRate, r.Config.MaxSkillDifference, r.Config.DefaultWaitTime)
+
+	fmt.Fprintf(w, "Arrivals:    %d over %s\n", r.Arrivals, time.Duration(r.SimulatedTime*float64(time.Second)).Round(time.Second))
+	fmt.Fprintf(w, "Matched:     %d players in %d matches\n", r.Matched, r.Matches)
+	fmt.Fprintf(w, "Abandoned:   %d (%.1f%%)\n", r.Abandoned, r.AbandonmentRate*100)
+
+	sizes := make([]int, 0, len(r.MatchSizes))
+	for size := range r.MatchSizes {
+		sizes = append(sizes, size)
+	}
+	sort.Ints(sizes)
+	parts := make([]string, 0, len(sizes))
+	for _, size := range sizes {
+		parts = append(parts, fmt.Sprintf("%d players x%d", size, r.MatchSizes[size]))
+	}
+	fmt.Fprintf(w, "Match sizes: %s\n\n", strings.Join(parts, ", "))
+
+	fmt.Fprintf(w, "%-14s %8s %8s %8s %8s %8s %8s\n", "", "mean", "p50", "p90", "p95", "p99", "max")
+	r.printSummary(w, "Wait (s)", r.WaitSeconds)
+	r.printSummary(w, "Skill spread", r.SkillSpread)
+
+	fmt.Fprintf(w, "\nPool size\n")
+	largest := 1
+	for _, sample := range r.PoolSize {
+		largest = max(largest, sample.Size)
+	}
+	for _, sample := range r.PoolSize {
+		at := time.Duration(sample.At * float64(time.Second))
+		bar := strings.Repeat("█", sample.Size*40/largest)
+		fmt.Fprintf(w, "  %8s %4d %s\n", at, sample.Size, bar)
+	}
+}
+
+// printSummary prints one row of the distribution table
+func (r *report) printSummary(w io.Writer, name string, summary map[string]float64) {
+	fmt.Fprintf(w, "%-14s", name)
+	for _, key := range []string{"mean", "p50", "p90", "p95", "p99", "max"} {
+		fmt.Fprintf(w, " %8.1f", summary[key])
+	}
+	fmt.Fprintln(w)
+}
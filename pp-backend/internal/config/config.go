@@ -40,6 +40,10 @@ type Config struct {
 	// Game Server settings
 	WSPort            int     `mapstructure:"WS_PORT"`
 	GameServerEnabled bool    `mapstructure:"GAME_SERVER_ENABLED"`
+	GameServerBackplane  string `mapstructure:"GAME_SERVER_BACKPLANE"`   // "", "memory" or "postgres"
+	GameServerInstanceID string `mapstructure:"GAME_SERVER_INSTANCE_ID"` // Defaults to hostname + random suffix
+	GameServerMaxConnections int   `mapstructure:"GAME_SERVER_MAX_CONNECTIONS"`
+	GameServerMaxMessageSize int64 `mapstructure:"GAME_SERVER_MAX_MESSAGE_SIZE"` // Largest client message in bytes
 
 	// Security settings
 	RequireHTTLS      bool    `mapstructure:"REQUIRE_HTTPS"`
@@ -49,6 +53,11 @@ type Config struct {
 	MaxRequestSizeMB  int     `mapstructure:"MAX_REQUEST_SIZE_MB"`
 	CORSOrigins       string  `mapstructure:"CORS_ORIGINS"`
 	GoEnv             string  `mapstructure:"GO_ENV"`
+
+	// Matchmaking rules, reloaded by WatchMatchmaking when the config file changes
+	Matchmaking MatchmakingRules `mapstructure:"MATCHMAKING"`
+
+	v *viper.Viper
 }
 
 // LoadConfig reads configuration from file or environment variables.
@@ -75,11 +84,16 @@ func LoadConfig() (*Config, error) {
 	v.SetDefault("GO_ENV", "development")
 	v.SetDefault("WS_PORT", 8082)
 	v.SetDefault("GAME_SERVER_ENABLED", true)
+	v.SetDefault("GAME_SERVER_BACKPLANE", "")
+	v.SetDefault("GAME_SERVER_INSTANCE_ID", "")
+	v.SetDefault("GAME_SERVER_MAX_CONNECTIONS", 1000)
+	v.SetDefault("GAME_SERVER_MAX_MESSAGE_SIZE", 8192)
 
 	// Load from config file
 	v.SetConfigName("config")
 	v.SetConfigType("yaml")
 	v.AddConfigPath(".")
+	v.AddConfigPath("./config")
 	v.AddConfigPath("/etc/app/")
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -95,6 +109,7 @@ func LoadConfig() (*Config, error) {
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
+	cfg.v = v
 
 	// HOTFIX: Viper가 환경변수를 읽지 못하는 문제 해결
 	// 환경변수를 직접 읽어서 설정
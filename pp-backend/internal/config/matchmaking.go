@@ -0,0 +1,70 @@
+// backend/internal/config/matchmaking.go
+
+package config
+
+import (
+	"log"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// MatchmakingRules holds the matchmaking tuning read from the "matchmaking"
+// section of the config file. Zero values keep the game server's built-in defaults.
+type MatchmakingRules struct {
+	TickInterval       time.Duration                       `mapstructure:"tick_interval"`
+	MaxSkillDifference int                                 `mapstructure:"max_skill_difference"`
+	MinPlayersPerMatch int                                 `mapstructure:"min_players_per_match"`
+	MaxPlayersPerMatch int                                 `mapstructure:"max_players_per_match"`
+	DefaultWaitTime    time.Duration                       `mapstructure:"default_wait_time"`
+	MaxWaitTime        time.Duration                       `mapstructure:"max_wait_time"`
+	SkillExpansionRate float64                             `mapstructure:"skill_expansion_rate"`
+	MatchCooldown      time.Duration                       `mapstructure:"match_cooldown"`
+	GameTypes          map[string]GameTypeMatchmakingRules `mapstructure:"game_types"`
+}
+
+// GameTypeMatchmakingRules overrides the matchmaking rules of a single game type.
+type GameTypeMatchmakingRules struct {
+	MinPlayers     int           `mapstructure:"min_players"`
+	MaxPlayers     int           `mapstructure:"max_players"`
+	OptimalPlayers int           `mapstructure:"optimal_players"`
+	MatchTimeout   time.Duration `mapstructure:"match_timeout"`
+	SkillRange     int           `mapstructure:"skill_range"`
+	CrossPlatform  *bool         `mapstructure:"cross_platform"`
+	LateJoin       *bool         `mapstructure:"late_join"`
+	Platforms      []string      `mapstructure:"platforms"`
+}
+
+// WatchMatchmaking calls onChange with the new matchmaking rules every time the
+// config file changes. It does nothing when no config file was loaded.
+func (c *Config) WatchMatchmaking(onChange func(MatchmakingRules)) {
+	if c.v == nil || c.v.ConfigFileUsed() == "" {
+		return
+	}
+
+	c.v.OnConfigChange(func(event fsnotify.Event) {
+		var rules MatchmakingRules
+		if err := c.v.UnmarshalKey("matchmaking", &rules); err != nil {
+			log.Printf("Failed to reload matchmaking rules from %s: %v", event.Name, err)
+			return
+		}
+		onChange(rules)
+	})
+	c.v.WatchConfig()
+}
+
+// LoadMatchmakingRules reads only the matchmaking section of a config file.
+func LoadMatchmakingRules(path string) (MatchmakingRules, error) {
+	var rules MatchmakingRules
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return rules, err
+	}
+	if err := v.UnmarshalKey("matchmaking", &rules); err != nil {
+		return rules, err
+	}
+	return rules, nil
+}
@@ -40,6 +40,9 @@ type Container struct {
 	ChatRoomRepo           repository.ChatRoomRepository
 	PasswordResetTokenRepo repository.PasswordResetTokenRepository
 	MaintenanceRepo        repository.MaintenanceRepository
+	MatchReplayRepo        repository.MatchReplayRepository
+	PlayerRatingRepo       repository.PlayerRatingRepository
+	MatchRepo              repository.MatchRepository
 
 	// Services
 	UserService                service.UserService
@@ -53,6 +56,8 @@ type Container struct {
 	KakaoAuthService           service.KakaoAuthService
 	AuthService                service.AuthService
 	MaintenanceService         service.MaintenanceService
+	RatingService              service.RatingService
+	MatchService               service.MatchService
 
 	// Email
 	EmailSender email.Sender
@@ -69,6 +74,8 @@ type Container struct {
 	ChatRoomHandler    *handler.ChatRoomHandler
 	MiniGameHandler    *handler.MiniGameHandler
 	MaintenanceHandler *handler.MaintenanceHandler
+	RatingHandler      *handler.RatingHandler
+	MatchHandler       *handler.MatchHandler
 
 	// Mini Game Engine
 	MiniGameEngine *minigame.MiniGameEngine
@@ -108,6 +115,9 @@ func New(cfg *config.Config) (*Container, error) {
 	passwordResetTokenRepo := repository.NewPostgresPasswordResetTokenRepository(dbConn)
 	maintenanceRepo := repository.NewPostgresMaintenanceRepository(dbConn)
 	miniGameScoreRepo := repository.NewMiniGameScoreRepository(dbConn)
+	matchReplayRepo := repository.NewMatchReplayRepository(dbConn)
+	playerRatingRepo := repository.NewPlayerRatingRepository(dbConn)
+	matchRepo := repository.NewMatchRepository(dbConn)
 
 	// 4) 이메일 발송기
 	emailSender := email.NewSMTPSender(cfg)
@@ -134,6 +144,8 @@ func New(cfg *config.Config) (*Container, error) {
 	authService := service.NewAuthService(userRepo, tokenRepo, passwordResetTokenRepo, tokenSvc, emailSender, cfg)
 	maintenanceService := service.NewMaintenanceService(maintenanceRepo, hub)
 	miniGameLeaderboardService := service.NewMiniGameLeaderboardService(miniGameScoreRepo)
+	ratingService := service.NewRatingService(playerRatingRepo, userRepo)
+	matchService := service.NewMatchService(matchRepo, userRepo)
 
 	// 5-1) 미니게임 엔진
 	miniGameEngine := minigame.NewMiniGameEngine(gameService, paymentService)
@@ -143,19 +155,60 @@ func New(cfg *config.Config) (*Container, error) {
 	if cfg.GameServerEnabled {
 		gameServerConfig := &gameserver.GameServerConfig{
 			Port:                  cfg.WSPort,
-			MaxConnections:        1000,
+			MaxConnections:        cfg.GameServerMaxConnections,
+			MaxMessageSize:        cfg.GameServerMaxMessageSize,
 			MaxRooms:              100,
 			MaxPlayersPerRoom:     8,
 			ConnectionTimeout:     5 * time.Minute,
 			RoomInactivityTimeout: 30 * time.Minute,
 			MatchmakingTimeout:    5 * time.Minute,
+			ReconnectGracePeriod:  30 * time.Second,
+			PausesPerPlayer:       2,
+			MaxPauseDuration:      time.Minute,
+			BotFillTimeout:        30 * time.Second,
+			InstanceID:            cfg.GameServerInstanceID,
 			EnableCORS:            true,
 			AllowedOrigins:        []string{cfg.AllowedOrigins},
 			EnableMetrics:         true,
 			EnableHealthCheck:     true,
 			LogLevel:              "info",
 		}
-		gameServer = gameserver.NewGameServer(gameServerConfig, miniGameEngine)
+		gameServerOpts := []gameserver.Option{
+			gameserver.WithAuthenticator(gameserver.NewAuthenticator(tokenSvc, userService)),
+			gameserver.WithSettlement(gameserver.NewSettlement(gameService, miniGameLeaderboardService, paymentService)),
+			gameserver.WithReplayStore(gameserver.NewReplayStore(matchReplayRepo)),
+			gameserver.WithRatings(gameserver.NewRatingStore(ratingService)),
+			gameserver.WithMatchStore(gameserver.NewMatchStore(matchRepo)),
+			gameserver.WithFriends(friendService),
+			gameserver.WithBlockList(friendService),
+			gameserver.WithInviteChat(chatService),
+		}
+
+		// 여러 게임서버 인스턴스가 같은 룸을 서비스하도록 백플레인 연결
+		switch cfg.GameServerBackplane {
+		case "postgres":
+			backplane, err := gameserver.NewPostgresBackplane(dbConn, cfg.DSN)
+			if err != nil {
+				return nil, fmt.Errorf("failed to start game server backplane: %w", err)
+			}
+			gameServerOpts = append(gameServerOpts, gameserver.WithBackplane(backplane))
+		case "memory":
+			gameServerOpts = append(gameServerOpts, gameserver.WithBackplane(gameserver.NewMemoryBackplane()))
+		}
+
+		gameServer = gameserver.NewGameServer(gameServerConfig, miniGameEngine, gameServerOpts...)
+
+		// 게임 타입별 매치메이킹 규칙 (설정 파일이 바뀌면 재시작 없이 반영)
+		if err := gameServer.ApplyMatchmakingRules(cfg.Matchmaking); err != nil {
+			return nil, fmt.Errorf("invalid matchmaking rules: %w", err)
+		}
+		cfg.WatchMatchmaking(func(rules config.MatchmakingRules) {
+			if err := gameServer.ApplyMatchmakingRules(rules); err != nil {
+				fmt.Printf("⚠️ Keeping previous matchmaking rules: %v\n", err)
+				return
+			}
+			fmt.Println("🔄 Matchmaking rules reloaded")
+		})
 
 		// 개발 환경에서 테스트용 기본 게임룸 생성
 		if cfg.GoEnv == "development" {
@@ -183,6 +236,8 @@ func New(cfg *config.Config) (*Container, error) {
 	chatRoomHandler := handler.NewChatRoomHandler(chatRoomService)
 	miniGameHandler := handler.NewMiniGameHandler(miniGameEngine, miniGameLeaderboardService)
 	maintenanceHandler := handler.NewMaintenanceHandler(maintenanceService)
+	ratingHandler := handler.NewRatingHandler(ratingService)
+	matchHandler := handler.NewMatchHandler(matchService)
 
 	maintenanceService.Start()
 
@@ -204,6 +259,9 @@ func New(cfg *config.Config) (*Container, error) {
 		ChatRoomRepo:               chatRoomRepo,
 		PasswordResetTokenRepo:     passwordResetTokenRepo,
 		MaintenanceRepo:            maintenanceRepo,
+		MatchReplayRepo:            matchReplayRepo,
+		PlayerRatingRepo:           playerRatingRepo,
+		MatchRepo:                  matchRepo,
 		UserService:                userService,
 		FriendService:              friendService,
 		ChatService:                chatService,
@@ -215,6 +273,8 @@ func New(cfg *config.Config) (*Container, error) {
 		KakaoAuthService:           kakaoAuthSvc,
 		AuthService:                authService,
 		MaintenanceService:         maintenanceService,
+		RatingService:              ratingService,
+		MatchService:               matchService,
 		EmailSender:                emailSender,
 		AuthHandler:                authHandler,
 		UserHandler:                userHandler,
@@ -227,6 +287,8 @@ func New(cfg *config.Config) (*Container, error) {
 		ChatRoomHandler:            chatRoomHandler,
 		MiniGameHandler:            miniGameHandler,
 		MaintenanceHandler:         maintenanceHandler,
+		RatingHandler:              ratingHandler,
+		MatchHandler:               matchHandler,
 
 		// Mini Game Engine
 		MiniGameEngine: miniGameEngine,
@@ -0,0 +1,203 @@
+// internal/gameserver/auth.go
+package gameserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+)
+
+// WebSocket subprotocol used to carry an access token during the handshake.
+// Browsers cannot set headers on WebSocket requests, so clients send
+// Sec-WebSocket-Protocol: bearer, <token> and the server echoes "bearer".
+const wsBearerProtocol = "bearer"
+
+var (
+	ErrMissingCredentials = errors.New("missing credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidTicket      = errors.New("invalid or expired ticket")
+	ErrAccountBanned      = errors.New("account is banned")
+	ErrAccountInactive    = errors.New("account is deactivated")
+)
+
+// TokenValidator validates access tokens issued by the REST API
+type TokenValidator interface {
+	ValidateToken(tokenStr string, isRefresh bool) (jwt.MapClaims, error)
+}
+
+// UserLookup loads user accounts for status checks
+type UserLookup interface {
+	Find(username string) (*repository.User, error)
+}
+
+// wsTicket is a short-lived, single-use credential for the WebSocket handshake
+type wsTicket struct {
+	username  string
+	expiresAt time.Time
+}
+
+// Authenticator resolves the identity of game server clients
+type Authenticator struct {
+	tokens    TokenValidator
+	users     UserLookup
+	tickets   map[string]*wsTicket
+	ticketTTL time.Duration
+	mu        sync.Mutex
+}
+
+// NewAuthenticator creates a new authenticator backed by the API token service
+func NewAuthenticator(tokens TokenValidator, users UserLookup) *Authenticator {
+	return &Authenticator{
+		tokens:    tokens,
+		users:     users,
+		tickets:   make(map[string]*wsTicket),
+		ticketTTL: 30 * time.Second,
+	}
+}
+
+// AuthenticateToken validates an access token and returns the username from its sub claim
+func (a *Authenticator) AuthenticateToken(token string) (string, error) {
+	claims, err := a.tokens.ValidateToken(token, false)
+	if err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	username, _ := claims["sub"].(string)
+	if username == "" {
+		return "", ErrInvalidCredentials
+	}
+
+	if err := a.checkAccount(username); err != nil {
+		return "", err
+	}
+
+	return username, nil
+}
+
+// AuthenticateRequest resolves the user of an HTTP request from the
+// Authorization header, the bearer subprotocol or a one-time ticket
+func (a *Authenticator) AuthenticateRequest(r *http.Request) (string, error) {
+	if ticket := r.URL.Query().Get("ticket"); ticket != "" {
+		return a.redeemTicket(ticket)
+	}
+
+	token := bearerToken(r)
+	if token == "" {
+		return "", ErrMissingCredentials
+	}
+
+	return a.AuthenticateToken(token)
+}
+
+// IssueTicket creates a single-use WebSocket ticket for an authenticated user
+func (a *Authenticator) IssueTicket(username string) (string, time.Time, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, err
+	}
+	ticket := hex.EncodeToString(buf)
+	expiresAt := time.Now().Add(a.ticketTTL)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pruneTickets()
+	a.tickets[ticket] = &wsTicket{username: username, expiresAt: expiresAt}
+
+	return ticket, expiresAt, nil
+}
+
+// redeemTicket consumes a ticket and re-checks the account status
+func (a *Authenticator) redeemTicket(ticket string) (string, error) {
+	a.mu.Lock()
+	t, exists := a.tickets[ticket]
+	delete(a.tickets, ticket)
+	a.mu.Unlock()
+
+	if !exists || time.Now().After(t.expiresAt) {
+		return "", ErrInvalidTicket
+	}
+
+	if err := a.checkAccount(t.username); err != nil {
+		return "", err
+	}
+
+	return t.username, nil
+}
+
+// checkAccount rejects banned, deactivated and unknown users
+func (a *Authenticator) checkAccount(username string) error {
+	if a.users == nil {
+		return nil
+	}
+
+	user, err := a.users.Find(username)
+	if err != nil {
+		return ErrInvalidCredentials
+	}
+	if user.BannedAt != nil {
+		return ErrAccountBanned
+	}
+	if user.DeletedAt != nil || (user.IsActive != nil && !*user.IsActive) {
+		return ErrAccountInactive
+	}
+
+	return nil
+}
+
+// pruneTickets removes expired tickets (assumes lock is held)
+func (a *Authenticator) pruneTickets() {
+	now := time.Now()
+	for key, t := range a.tickets {
+		if now.After(t.expiresAt) {
+			delete(a.tickets, key)
+		}
+	}
+}
+
+// bearerToken extracts an access token from the Authorization header or the
+// Sec-WebSocket-Protocol header
+func bearerToken(r *http.Request) string {
+	const bearer = "Bearer "
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, bearer) {
+		return strings.TrimPrefix(h, bearer)
+	}
+
+	protocols := websocketSubprotocols(r)
+	for i, p := range protocols {
+		if p == wsBearerProtocol && i+1 < len(protocols) {
+			return protocols[i+1]
+		}
+	}
+
+	return ""
+}
+
+func websocketSubprotocols(r *http.Request) []string {
+	var protocols []string
+	for _, h := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, p := range strings.Split(h, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				protocols = append(protocols, p)
+			}
+		}
+	}
+	return protocols
+}
+
+// authStatus maps authentication errors to HTTP status codes
+func authStatus(err error) int {
+	switch err {
+	case ErrAccountBanned, ErrAccountInactive:
+		return http.StatusForbidden
+	default:
+		return http.StatusUnauthorized
+	}
+}
@@ -0,0 +1,99 @@
+// internal/gameserver/auth_test.go
+package gameserver_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubUsers map[string]*repository.User
+
+func (s stubUsers) Find(username string) (*repository.User, error) {
+	user, ok := s[username]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func newTestAuthenticator(t *testing.T) (*gameserver.Authenticator, *service.TokenService) {
+	t.Helper()
+	tokenSvc := service.NewTokenService("access-secret", "refresh-secret", 15, 7)
+	inactive := false
+	bannedAt := time.Now()
+	users := stubUsers{
+		"alice":   {Username: "alice"},
+		"banned":  {Username: "banned", BannedAt: &bannedAt},
+		"retired": {Username: "retired", IsActive: &inactive},
+	}
+	return gameserver.NewAuthenticator(tokenSvc, users), tokenSvc
+}
+
+func TestAuthenticator_BearerHeader(t *testing.T) {
+	auth, tokenSvc := newTestAuthenticator(t)
+	token, err := tokenSvc.CreateAccessToken("alice", "user")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/ws/alice", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	username, err := auth.AuthenticateRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", username)
+}
+
+func TestAuthenticator_Subprotocol(t *testing.T) {
+	auth, tokenSvc := newTestAuthenticator(t)
+	token, err := tokenSvc.CreateAccessToken("alice", "user")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/ws/alice", nil)
+	req.Header.Set("Sec-WebSocket-Protocol", "bearer, "+token)
+
+	username, err := auth.AuthenticateRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", username)
+}
+
+func TestAuthenticator_TicketIsSingleUse(t *testing.T) {
+	auth, _ := newTestAuthenticator(t)
+	ticket, _, err := auth.IssueTicket("alice")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/ws/alice?ticket="+ticket, nil)
+	username, err := auth.AuthenticateRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", username)
+
+	_, err = auth.AuthenticateRequest(req)
+	assert.ErrorIs(t, err, gameserver.ErrInvalidTicket)
+}
+
+func TestAuthenticator_RejectsAccounts(t *testing.T) {
+	auth, tokenSvc := newTestAuthenticator(t)
+
+	_, err := auth.AuthenticateRequest(httptest.NewRequest("GET", "/ws/alice", nil))
+	assert.ErrorIs(t, err, gameserver.ErrMissingCredentials)
+
+	_, err = auth.AuthenticateToken("not-a-jwt")
+	assert.ErrorIs(t, err, gameserver.ErrInvalidCredentials)
+
+	token, _ := tokenSvc.CreateAccessToken("banned", "user")
+	_, err = auth.AuthenticateToken(token)
+	assert.ErrorIs(t, err, gameserver.ErrAccountBanned)
+
+	token, _ = tokenSvc.CreateAccessToken("retired", "user")
+	_, err = auth.AuthenticateToken(token)
+	assert.ErrorIs(t, err, gameserver.ErrAccountInactive)
+
+	token, _ = tokenSvc.CreateAccessToken("ghost", "user")
+	_, err = auth.AuthenticateToken(token)
+	assert.ErrorIs(t, err, gameserver.ErrInvalidCredentials)
+}
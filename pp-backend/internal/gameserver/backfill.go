@@ -0,0 +1,242 @@
+// internal/gameserver/backfill.go
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+)
+
+// ErrNoOpenSlots is returned when a room has no seat matchmaking could fill
+var ErrNoOpenSlots = errors.New("room has no open slots to backfill")
+
+// BackfillRequest asks matchmaking to fill the open seats of an existing room
+type BackfillRequest struct {
+	RoomID     uuid.UUID         `json:"roomId"`
+	GameType   minigame.GameType `json:"gameType"`
+	SkillLevel int               `json:"skillLevel"` // Average rating of the players in the room
+	CreatedAt  time.Time         `json:"createdAt"`
+}
+
+// backfillMatch is a set of queued players picked for a room's open seats
+type backfillMatch struct {
+	roomID  uuid.UUID
+	players []*MatchmakingRequest
+}
+
+// RequestBackfill lets matchmaking fill the open seats of a public room with
+// queued players of the same game type
+func (ms *MatchmakingService) RequestBackfill(roomID uuid.UUID) error {
+	room, exists := ms.roomManager.GetRoom(roomID)
+	if !exists {
+		return ErrRoomNotFound
+	}
+	if slots, ok := ms.roomManager.OpenSlots(roomID); !ok || slots <= 0 {
+		return fmt.Errorf("%w: %s", ErrNoOpenSlots, roomID)
+	}
+
+	room.mu.RLock()
+	gameType := room.GameType
+	players := make([]string, 0, len(room.Players))
+	for username, player := range room.Players {
+		if !player.IsBot {
+			players = append(players, username)
+		}
+	}
+	room.mu.RUnlock()
+
+	skillLevel := DefaultSkillRating
+	if len(players) > 0 {
+		total := 0
+		for _, username := range players {
+			total += ms.skillRating(username, gameType)
+		}
+		skillLevel = total / len(players)
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if backfill, exists := ms.backfills[roomID]; exists {
+		backfill.SkillLevel = skillLevel
+		return nil
+	}
+	ms.backfills[roomID] = &BackfillRequest{
+		RoomID:     roomID,
+		GameType:   gameType,
+		SkillLevel: skillLevel,
+		CreatedAt:  time.Now(),
+	}
+	return nil
+}
+
+// CancelBackfill stops filling a room's open seats
+func (ms *MatchmakingService) CancelBackfill(roomID uuid.UUID) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	delete(ms.backfills, roomID)
+}
+
+// pendingBackfills returns the open backfill requests of a game type, oldest
+// first, and how many seats of each room are held by pending ready-checks
+func (ms *MatchmakingService) pendingBackfills(gameType minigame.GameType) ([]*BackfillRequest, map[uuid.UUID]int) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var backfills []*BackfillRequest
+	for _, backfill := range ms.backfills {
+		if backfill.GameType == gameType {
+			backfillCopy := *backfill
+			backfills = append(backfills, &backfillCopy)
+		}
+	}
+	sort.Slice(backfills, func(i, j int) bool {
+		return backfills[i].CreatedAt.Before(backfills[j].CreatedAt)
+	})
+
+	reserved := make(map[uuid.UUID]int)
+	for _, proposal := range ms.proposals {
+		if proposal.RoomID != nil {
+			reserved[*proposal.RoomID] += len(proposal.Players)
+		}
+	}
+	return backfills, reserved
+}
+
+// matchBackfills picks queued players and parties for rooms waiting for
+// backfill before new rooms are formed. It returns the picks, the units left
+// for regular matching and the rooms that no longer need backfill.
+// (assumes pool lock is held)
+func (ms *MatchmakingService) matchBackfills(backfills []*BackfillRequest, reserved map[uuid.UUID]int, units [][]*MatchmakingRequest, config *MatchmakingConfig, rules *GameTypeRules, now time.Time) ([]backfillMatch, [][]*MatchmakingRequest, []uuid.UUID) {
+	var matches []backfillMatch
+	var done []uuid.UUID
+
+	for _, backfill := range backfills {
+		openSlots, ok := ms.roomManager.OpenSlots(backfill.RoomID)
+		if !ok || openSlots <= 0 {
+			done = append(done, backfill.RoomID)
+			continue
+		}
+
+		slots := openSlots - reserved[backfill.RoomID]
+		var picked []*MatchmakingRequest
+		for _, unit := range units {
+			if len(picked)+len(unit) > slots {
+				continue
+			}
+			maxSkillDiff := skillRange(config, rules, now.Sub(unit[0].CreatedAt))
+			if int(math.Abs(float64(backfill.SkillLevel-unitSkill(unit)))) > maxSkillDiff {
+				continue
+			}
+			picked = append(picked, unit...)
+		}
+
+		if len(picked) > 0 {
+			matches = append(matches, backfillMatch{roomID: backfill.RoomID, players: picked})
+			units = removeUnits(units, picked)
+		}
+	}
+
+	return matches, units, done
+}
+
+// joinBackfillRoom seats players who accepted a backfill match. Parties are
+// seated whole; players and parties whose seats are gone by now go back to
+// the front of the queue.
+func (ms *MatchmakingService) joinBackfillRoom(roomID uuid.UUID, gameType minigame.GameType, players []*MatchmakingRequest) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	players = ms.stillQueued(players)
+
+	var joined, requeued []*MatchmakingRequest
+	var room *GameRoom
+	for _, unit := range partyUnits(players) {
+		usernames := make([]string, len(unit))
+		for i, player := range unit {
+			usernames[i] = player.Username
+		}
+		joinedRoom, err := ms.roomManager.joinRoomTogether(roomID, usernames)
+		if err != nil {
+			requeued = append(requeued, unit...)
+			continue
+		}
+		room = joinedRoom
+		joined = append(joined, unit...)
+	}
+	ms.requeueFront(gameType, requeued)
+
+	if room == nil {
+		return
+	}
+
+	ms.recordWaits(gameType, joined)
+	for _, player := range joined {
+		// A game in progress has no ready state to set
+		if err := ms.roomManager.SetPlayerReady(room.ID, player.Username, true); err != nil && !errors.Is(err, ErrInvalidRoomState) {
+			log.Printf("Failed to mark %s ready in backfilled room %s: %v", player.Username, room.ID, err)
+		}
+
+		delete(ms.activeRequests, player.ID)
+		delete(ms.userRequests, player.Username)
+		ms.addToMatchHistory(player.Username)
+
+		ms.wsManager.SendToUser(player.Username, &WebSocketMessage{
+			Type: MessageTypeMatchFound,
+			Data: map[string]interface{}{
+				"roomId":   room.ID.String(),
+				"gameType": gameType,
+				"backfill": true,
+				"room":     ms.matchRoomStats(room, player.Username),
+			},
+			Timestamp: time.Now(),
+			RoomID:    &room.ID,
+		})
+	}
+
+	if slots, ok := ms.roomManager.OpenSlots(roomID); !ok || slots <= 0 {
+		delete(ms.backfills, roomID)
+	}
+}
+
+// joinRoomTogether seats a group of players in a public room only if there is
+// a seat for each of them. Joins need the manager lock, so holding it keeps
+// the free seats from being taken between the check and the seating.
+func (rm *RoomManager) joinRoomTogether(roomID uuid.UUID, usernames []string) (*GameRoom, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	room, exists := rm.rooms[roomID]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+
+	room.mu.RLock()
+	free := room.openSeats()
+	room.mu.RUnlock()
+	if free < len(usernames) {
+		return nil, ErrRoomFull
+	}
+
+	joined := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		if _, err := rm.joinRoom(roomID, username, false); err != nil {
+			// Take back the seats of the members who already joined
+			room.mu.Lock()
+			for _, member := range joined {
+				rm.removePlayer(room, member)
+			}
+			room.mu.Unlock()
+			return nil, err
+		}
+		joined = append(joined, username)
+	}
+	return room, nil
+}
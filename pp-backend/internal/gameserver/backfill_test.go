@@ -0,0 +1,135 @@
+// internal/gameserver/backfill_test.go
+package gameserver_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomManager_LateJoinFollowsGameTypePolicy(t *testing.T) {
+	rm := newTestGameServer(t).GetRoomManager()
+
+	paint, err := rm.CreateRoom("host", minigame.GameTypePaintBattle, map[string]interface{}{
+		"maxPlayers": float64(3),
+	})
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(paint.ID, "guest", "")
+	require.NoError(t, err)
+	require.NoError(t, rm.SetPlayerReady(paint.ID, "host", true))
+	require.NoError(t, rm.SetPlayerReady(paint.ID, "guest", true))
+	require.NoError(t, rm.StartGame(paint.ID, "host"))
+
+	_, err = rm.JoinRoom(paint.ID, "carol", "")
+	require.NoError(t, err)
+	stats := paint.GetRoomStats()
+	assert.Equal(t, gameserver.RoomStateInProgress, stats["state"])
+	assert.Contains(t, stats["players"], "carol")
+
+	room, err := rm.CreateRoom("dave", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "erin", "")
+	require.NoError(t, err)
+	require.NoError(t, rm.SetPlayerReady(room.ID, "dave", true))
+	require.NoError(t, rm.SetPlayerReady(room.ID, "erin", true))
+	require.NoError(t, rm.StartGame(room.ID, "dave"))
+
+	_, err = rm.JoinRoom(room.ID, "frank", "")
+	assert.ErrorIs(t, err, gameserver.ErrInvalidRoomState)
+	_, ok := rm.OpenSlots(room.ID)
+	assert.False(t, ok)
+}
+
+func TestMatchmaking_BackfillsSeatLeftInPublicRoom(t *testing.T) {
+	gs, tokenSvc, srv := newReadyCheckTestServer(t, 5*time.Second)
+	ms := gs.GetMatchmakingService()
+	rm := gs.GetRoomManager()
+
+	carol := dialGameSocket(t, srv, tokenSvc, "carol", "/ws/carol")
+	defer carol.Close()
+
+	room, err := rm.CreateRoom("alice", minigame.GameTypeMemoryMatch, map[string]interface{}{
+		"maxPlayers": float64(2),
+	})
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "bob", "")
+	require.NoError(t, err)
+	assert.ErrorIs(t, ms.RequestBackfill(room.ID), gameserver.ErrNoOpenSlots)
+
+	require.NoError(t, rm.LeaveRoom(room.ID, "bob"))
+	require.Eventually(t, func() bool {
+		status, err := ms.GetQueueStatus(minigame.GameTypeMemoryMatch)
+		return err == nil && status["backfillRooms"] == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = ms.JoinMatchmaking("carol", minigame.GameTypeMemoryMatch, nil)
+	require.NoError(t, err)
+
+	check := readUntil(t, carol, gameserver.MessageTypeMatchReadyCheck)
+	assert.Equal(t, true, check.Data["backfill"])
+	assert.Equal(t, room.ID.String(), check.Data["roomId"])
+	require.NoError(t, ms.AcceptMatch("carol", uuid.MustParse(check.Data["matchId"].(string))))
+
+	found := readUntil(t, carol, gameserver.MessageTypeMatchFound)
+	assert.Equal(t, true, found.Data["backfill"])
+	assert.Equal(t, room.ID.String(), found.Data["roomId"])
+
+	carolRoom, inRoom := rm.GetUserRoom("carol")
+	require.True(t, inRoom)
+	assert.Equal(t, room.ID, carolRoom.ID)
+
+	status, err := ms.GetQueueStatus(minigame.GameTypeMemoryMatch)
+	require.NoError(t, err)
+	assert.Equal(t, 0, status["backfillRooms"])
+}
+
+func TestMatchmaking_BackfillKeepsPartiesTogether(t *testing.T) {
+	gs, tokenSvc, srv := newPartyTestServer(t)
+	ms := gs.GetMatchmakingService()
+	rm := gs.GetRoomManager()
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeMemoryMatch, map[string]interface{}{
+		"maxPlayers": float64(4),
+	})
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "guest", "")
+	require.NoError(t, err)
+	require.NoError(t, ms.RequestBackfill(room.ID))
+
+	party, err := ms.InviteToParty("alice", "bob")
+	require.NoError(t, err)
+	_, err = ms.AcceptPartyInvite("bob", party.ID)
+	require.NoError(t, err)
+	_, err = ms.JoinMatchmaking("alice", minigame.GameTypeMemoryMatch, nil)
+	require.NoError(t, err)
+
+	check := readUntil(t, alice, gameserver.MessageTypeMatchReadyCheck)
+	assert.Equal(t, true, check.Data["backfill"])
+	matchID := uuid.MustParse(check.Data["matchId"].(string))
+	readUntil(t, bob, gameserver.MessageTypeMatchReadyCheck)
+
+	// One of the two seats is taken before the party accepts
+	_, err = rm.JoinRoom(room.ID, "dave", "")
+	require.NoError(t, err)
+	require.NoError(t, ms.AcceptMatch("alice", matchID))
+	require.NoError(t, ms.AcceptMatch("bob", matchID))
+
+	for _, username := range []string{"alice", "bob"} {
+		_, inRoom := rm.GetUserRoom(username)
+		assert.False(t, inRoom, username)
+		_, err := ms.GetMatchmakingStatus(username)
+		assert.NoError(t, err, username)
+	}
+	slots, _ := rm.OpenSlots(room.ID)
+	assert.Equal(t, 1, slots)
+}
@@ -0,0 +1,476 @@
+// internal/gameserver/backplane.go
+package gameserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ErrRoomOwnedElsewhere is returned when another instance already hosts a room
+var ErrRoomOwnedElsewhere = errors.New("room is owned by another instance")
+
+// Kinds of backplane messages
+const (
+	BackplaneRoom    = "room"    // Room broadcast for every instance with players in the room
+	BackplaneUser    = "user"    // Message for a single user, delivered by the instance holding their socket
+	BackplaneCommand = "command" // Client message forwarded to the instance hosting the room
+)
+
+// backplaneDisconnect is the command sent to a room's owner when a remote player's socket drops
+const backplaneDisconnect = "backplane_disconnect"
+
+// BackplaneMessage is a message exchanged between game server instances
+type BackplaneMessage struct {
+	Kind     string            `json:"kind"`
+	Origin   string            `json:"origin"`           // Instance that published the message
+	Target   string            `json:"target,omitempty"` // Instance a command is addressed to
+	RoomID   *uuid.UUID        `json:"roomId,omitempty"`
+	Username string            `json:"username,omitempty"`
+	Message  *WebSocketMessage `json:"message"`
+}
+
+// BackplaneHandler receives messages published by any instance
+type BackplaneHandler func(message *BackplaneMessage)
+
+// Backplane connects game server instances so players of one room can be
+// served by different instances. Rooms live on the instance that created them;
+// the backplane relays their broadcasts and tracks which instance owns each room.
+type Backplane interface {
+	Publish(message *BackplaneMessage) error
+	Subscribe(handler BackplaneHandler) error
+	ClaimRoom(roomID uuid.UUID, instanceID string) error
+	ReleaseRoom(roomID uuid.UUID, instanceID string) error
+	RoomOwner(roomID uuid.UUID) (string, error)
+	Close() error
+}
+
+// newInstanceID names this game server instance on the backplane
+func newInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "gameserver"
+	}
+	return fmt.Sprintf("%s-%s", host, uuid.NewString()[:8])
+}
+
+// MemoryBackplane is an in-process backplane connecting game servers that run
+// in the same process, for tests and single-host setups
+type MemoryBackplane struct {
+	owners      map[uuid.UUID]string
+	subscribers []chan *BackplaneMessage
+	done        chan struct{}
+	closeOnce   sync.Once
+	mu          sync.RWMutex
+}
+
+// NewMemoryBackplane creates an in-process backplane
+func NewMemoryBackplane() *MemoryBackplane {
+	return &MemoryBackplane{
+		owners: make(map[uuid.UUID]string),
+		done:   make(chan struct{}),
+	}
+}
+
+// Publish delivers a message to every subscriber in publish order. Messages
+// are copied through JSON so subscribers see the same data a remote instance would.
+func (b *MemoryBackplane) Publish(message *BackplaneMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode backplane message: %w", err)
+	}
+
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		var copied BackplaneMessage
+		if err := json.Unmarshal(data, &copied); err != nil {
+			return fmt.Errorf("failed to decode backplane message: %w", err)
+		}
+		select {
+		case subscriber <- &copied:
+		case <-b.done:
+			return errors.New("backplane is closed")
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a handler for all published messages
+func (b *MemoryBackplane) Subscribe(handler BackplaneHandler) error {
+	messages := make(chan *BackplaneMessage, 1024)
+
+	b.mu.Lock()
+	b.subscribers = append(b.subscribers[:len(b.subscribers):len(b.subscribers)], messages)
+	b.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case <-b.done:
+				return
+			case message := <-messages:
+				handler(message)
+			}
+		}
+	}()
+	return nil
+}
+
+// ClaimRoom records an instance as the owner of a room
+func (b *MemoryBackplane) ClaimRoom(roomID uuid.UUID, instanceID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if owner, exists := b.owners[roomID]; exists && owner != instanceID {
+		return ErrRoomOwnedElsewhere
+	}
+	b.owners[roomID] = instanceID
+	return nil
+}
+
+// ReleaseRoom forgets a room's owner
+func (b *MemoryBackplane) ReleaseRoom(roomID uuid.UUID, instanceID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.owners[roomID] == instanceID {
+		delete(b.owners, roomID)
+	}
+	return nil
+}
+
+// RoomOwner returns the instance hosting a room
+func (b *MemoryBackplane) RoomOwner(roomID uuid.UUID) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	owner, exists := b.owners[roomID]
+	if !exists {
+		return "", ErrRoomNotFound
+	}
+	return owner, nil
+}
+
+// Close stops delivering messages
+func (b *MemoryBackplane) Close() error {
+	b.closeOnce.Do(func() { close(b.done) })
+	return nil
+}
+
+// UseBackplane relays room broadcasts and user messages through a backplane
+// shared with other instances
+func (m *WebSocketManager) UseBackplane(backplane Backplane, instanceID string) error {
+	m.mu.Lock()
+	m.backplane = backplane
+	m.instanceID = instanceID
+	m.mu.Unlock()
+
+	return backplane.Subscribe(m.deliverRemote)
+}
+
+// publish sends a message to the other instances, if a backplane is configured
+func (m *WebSocketManager) publish(message *BackplaneMessage) {
+	if m.backplane == nil {
+		return
+	}
+	message.Origin = m.instanceID
+	if err := m.backplane.Publish(message); err != nil {
+		log.Printf("Failed to publish %s message to backplane: %v", message.Kind, err)
+	}
+}
+
+// forwardCommand hands a client message to the instance hosting the sender's room
+func (m *WebSocketManager) forwardCommand(owner, username string, message *WebSocketMessage) error {
+	if m.backplane == nil {
+		return ErrRoomNotFound
+	}
+	m.publish(&BackplaneMessage{
+		Kind:     BackplaneCommand,
+		Target:   owner,
+		Username: username,
+		Message:  message,
+	})
+	return nil
+}
+
+// deliverRemote handles a message published by another instance
+func (m *WebSocketManager) deliverRemote(message *BackplaneMessage) {
+	if message.Origin == m.instanceID || message.Message == nil {
+		return
+	}
+
+	switch message.Kind {
+	case BackplaneRoom:
+		if message.RoomID == nil {
+			return
+		}
+		message.Message.RoomID = message.RoomID
+		m.broadcastToRoom(message.Message)
+		m.syncRemoteRoom(*message.RoomID, message.Message)
+
+	case BackplaneUser:
+		if conn, exists := m.GetConnection(message.Username); exists {
+			m.trackRemoteRoom(conn, message.Message)
+			m.sendToConnection(conn, message.Message)
+		}
+
+	case BackplaneCommand:
+		if message.Target == m.instanceID {
+			m.runRemoteCommand(message)
+		}
+	}
+}
+
+// runRemoteCommand processes a client message forwarded by the instance
+// holding the sender's socket. Replies travel back over the backplane.
+func (m *WebSocketManager) runRemoteCommand(message *BackplaneMessage) {
+	// A drop reported by another instance is stale once the user reconnected here
+	if _, local := m.GetConnection(message.Username); local && message.Message.Type == backplaneDisconnect {
+		return
+	}
+
+	conn := &WebSocketConnection{
+		ID:       uuid.New(),
+		Username: message.Username,
+		Context:  m.ctx,
+		IsAlive:  true,
+		remote:   true,
+	}
+
+	if message.Message.Type == backplaneDisconnect {
+		if message.Message.RoomID != nil {
+			m.dropRemoteMember(*message.Message.RoomID, message.Username)
+		}
+		m.mu.RLock()
+		handlers := m.onDisconnect
+		m.mu.RUnlock()
+		for _, handler := range handlers {
+			handler(conn)
+		}
+		return
+	}
+
+	m.processMessage(conn, message.Message)
+}
+
+// trackRemoteRoom subscribes a local socket to a remote room's broadcasts once
+// the owning instance confirms the user took a seat, and unsubscribes it on leave
+func (m *WebSocketManager) trackRemoteRoom(conn *WebSocketConnection, message *WebSocketMessage) {
+	if message.RoomID == nil {
+		return
+	}
+	roomID := *message.RoomID
+
+	switch message.Type {
+	case MessageTypeRoomJoined, MessageTypeResumed:
+		m.mu.Lock()
+		m.remoteRooms[conn.Username] = roomID
+		m.mu.Unlock()
+
+		// Spectators are served by the delayed feed, not by room broadcasts
+		if role, _ := message.Data["role"].(string); role != "spectator" {
+			m.AddToRoom(conn, roomID)
+		}
+
+	case MessageTypeRoomLeft:
+		m.forgetRemoteRoom(conn.Username, roomID)
+	}
+}
+
+// syncRemoteRoom drops local subscriptions for players that left a remote room
+func (m *WebSocketManager) syncRemoteRoom(roomID uuid.UUID, message *WebSocketMessage) {
+	switch message.Type {
+	case RoomEventPlayerLeft, RoomEventPlayerForfeited:
+		m.forgetRemoteRoom(message.From, roomID)
+
+	case RoomEventRoomClosed:
+		for _, conn := range m.GetRoomConnections(roomID) {
+			m.forgetRemoteRoom(conn.Username, roomID)
+		}
+	}
+}
+
+// publishToUser relays a message to a user connected to another instance
+func (m *WebSocketManager) publishToUser(username string, message *WebSocketMessage) {
+	m.trackRemoteMember(username, message)
+	m.publish(&BackplaneMessage{Kind: BackplaneUser, Username: username, Message: message})
+}
+
+// trackRemoteMember records which hosted rooms seat players connected to other
+// instances, from the join and resume confirmations sent to them
+func (m *WebSocketManager) trackRemoteMember(username string, message *WebSocketMessage) {
+	if message.RoomID == nil {
+		return
+	}
+	roomID := *message.RoomID
+
+	switch message.Type {
+	case MessageTypeRoomJoined, MessageTypeResumed:
+		// Spectators are served by the delayed feed, not by room broadcasts
+		if role, _ := message.Data["role"].(string); role == "spectator" {
+			return
+		}
+		m.mu.Lock()
+		members, exists := m.remoteMembers[roomID]
+		if !exists {
+			members = make(map[string]bool)
+			m.remoteMembers[roomID] = members
+		}
+		members[username] = true
+		m.mu.Unlock()
+
+	case MessageTypeRoomLeft:
+		m.dropRemoteMember(roomID, username)
+	}
+}
+
+// syncRemoteMembers forgets remote players once the room broadcast that they
+// left has gone out
+func (m *WebSocketManager) syncRemoteMembers(roomID uuid.UUID, message *WebSocketMessage) {
+	switch message.Type {
+	case RoomEventPlayerLeft, RoomEventPlayerForfeited:
+		m.dropRemoteMember(roomID, message.From)
+
+	case RoomEventRoomClosed:
+		m.mu.Lock()
+		delete(m.remoteMembers, roomID)
+		m.mu.Unlock()
+	}
+}
+
+// dropRemoteMember stops publishing a hosted room's broadcasts for a remote player
+func (m *WebSocketManager) dropRemoteMember(roomID uuid.UUID, username string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	members, exists := m.remoteMembers[roomID]
+	if !exists {
+		return
+	}
+	delete(members, username)
+	if len(members) == 0 {
+		delete(m.remoteMembers, roomID)
+	}
+}
+
+// hasRemoteMembers reports whether a hosted room seats players connected to other instances
+func (m *WebSocketManager) hasRemoteMembers(roomID uuid.UUID) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.remoteMembers[roomID]) > 0
+}
+
+// forgetRemoteRoom stops tracking a user's seat in a remote room
+func (m *WebSocketManager) forgetRemoteRoom(username string, roomID uuid.UUID) {
+	m.mu.Lock()
+	current, exists := m.remoteRooms[username]
+	if !exists || current != roomID {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.remoteRooms, username)
+	m.mu.Unlock()
+
+	if conn, exists := m.GetConnection(username); exists {
+		m.RemoveFromRoom(conn, roomID)
+	}
+}
+
+// remoteRoom returns the room a local user plays or watches on another instance
+func (m *WebSocketManager) remoteRoom(username string) (uuid.UUID, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	roomID, exists := m.remoteRooms[username]
+	return roomID, exists
+}
+
+// routeToOwner wraps a message handler so messages about rooms hosted by
+// another instance are forwarded there instead of handled locally
+func (gs *GameServer) routeToOwner(handler MessageHandler) MessageHandler {
+	return func(conn *WebSocketConnection, message *WebSocketMessage) error {
+		if owner, ok := gs.remoteOwner(conn, message); ok {
+			return gs.wsManager.forwardCommand(owner, conn.Username, message)
+		}
+		return handler(conn, message)
+	}
+}
+
+// remoteOwner resolves the instance hosting the room a message refers to,
+// when that is not this instance
+func (gs *GameServer) remoteOwner(conn *WebSocketConnection, message *WebSocketMessage) (string, bool) {
+	if gs.backplane == nil || conn.remote {
+		return "", false
+	}
+
+	roomID, ok := gs.wsManager.remoteRoom(conn.Username)
+	if roomIDStr, _ := message.Data["roomId"].(string); roomIDStr != "" {
+		parsed, err := uuid.Parse(roomIDStr)
+		if err != nil {
+			return "", false
+		}
+		roomID, ok = parsed, true
+	}
+	if !ok {
+		return "", false
+	}
+	if _, local := gs.roomManager.GetRoom(roomID); local {
+		return "", false
+	}
+
+	owner, err := gs.backplane.RoomOwner(roomID)
+	if err != nil || owner == gs.config.InstanceID {
+		return "", false
+	}
+	return owner, true
+}
+
+// forwardDisconnect tells a remote room's owner that a local player's socket dropped
+func (gs *GameServer) forwardDisconnect(conn *WebSocketConnection) bool {
+	if gs.backplane == nil || conn.remote {
+		return false
+	}
+	roomID, ok := gs.wsManager.remoteRoom(conn.Username)
+	if !ok {
+		return false
+	}
+	gs.wsManager.forgetRemoteRoom(conn.Username, roomID)
+
+	owner, err := gs.backplane.RoomOwner(roomID)
+	if err != nil || owner == gs.config.InstanceID {
+		return false
+	}
+	gs.wsManager.forwardCommand(owner, conn.Username, &WebSocketMessage{
+		Type:   backplaneDisconnect,
+		Data:   map[string]interface{}{},
+		RoomID: &roomID,
+	})
+	return true
+}
+
+// claimRoom registers this instance as the host of a new room
+func (rm *RoomManager) claimRoom(roomID uuid.UUID) error {
+	if rm.backplane == nil {
+		return nil
+	}
+	if err := rm.backplane.ClaimRoom(roomID, rm.instanceID); err != nil {
+		return fmt.Errorf("failed to claim room %s on backplane: %w", roomID, err)
+	}
+	return nil
+}
+
+// releaseRoom gives up ownership of a closed room
+func (rm *RoomManager) releaseRoom(roomID uuid.UUID) {
+	if err := rm.backplane.ReleaseRoom(roomID, rm.instanceID); err != nil {
+		log.Printf("Failed to release room %s on backplane: %v", roomID, err)
+	}
+}
@@ -0,0 +1,239 @@
+// internal/gameserver/backplane_postgres.go
+package gameserver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	// backplaneChannel is the LISTEN/NOTIFY channel shared by all instances
+	backplaneChannel = "game_backplane"
+
+	// maxNotifyPayload keeps NOTIFY payloads under Postgres' 8000 byte limit
+	maxNotifyPayload = 7900
+
+	// backplaneMessageTTL is how long parked payloads are kept for listeners to fetch
+	backplaneMessageTTL = time.Minute
+)
+
+// notification is the NOTIFY payload: either the message itself or a
+// reference to a payload parked in game_backplane_messages
+type notification struct {
+	Ref     int64             `json:"ref,omitempty"`
+	Message *BackplaneMessage `json:"message,omitempty"`
+}
+
+// PostgresBackplane relays messages between instances with LISTEN/NOTIFY and
+// keeps room ownership in the game_room_owners table
+type PostgresBackplane struct {
+	db        *sql.DB
+	listener  *pq.Listener
+	handlers  []BackplaneHandler
+	instances map[string]bool // Instance IDs that claimed rooms through this backplane
+	mu        sync.RWMutex
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+// NewPostgresBackplane connects a listener to the database at dsn and starts
+// delivering notifications. db is used for publishing and room ownership.
+func NewPostgresBackplane(db *sql.DB, dsn string) (*PostgresBackplane, error) {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Game backplane listener event %d: %v", event, err)
+		}
+	})
+	if err := listener.Listen(backplaneChannel); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to listen on %s: %w", backplaneChannel, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &PostgresBackplane{
+		db:        db,
+		listener:  listener,
+		instances: make(map[string]bool),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	go b.listen()
+
+	return b, nil
+}
+
+// Publish notifies all instances. Payloads over the NOTIFY limit are parked
+// in a table and only their id is sent.
+func (b *PostgresBackplane) Publish(message *BackplaneMessage) error {
+	payload, err := json.Marshal(notification{Message: message})
+	if err != nil {
+		return fmt.Errorf("failed to encode backplane message: %w", err)
+	}
+
+	if len(payload) > maxNotifyPayload {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("failed to encode backplane message: %w", err)
+		}
+
+		var ref int64
+		if err := b.db.QueryRow(`INSERT INTO game_backplane_messages (payload) VALUES ($1) RETURNING id`, data).Scan(&ref); err != nil {
+			return fmt.Errorf("failed to park backplane message: %w", err)
+		}
+		if payload, err = json.Marshal(notification{Ref: ref}); err != nil {
+			return fmt.Errorf("failed to encode backplane reference: %w", err)
+		}
+	}
+
+	if _, err := b.db.Exec(`SELECT pg_notify($1, $2)`, backplaneChannel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify backplane: %w", err)
+	}
+	return nil
+}
+
+// Subscribe registers a handler for all notifications
+func (b *PostgresBackplane) Subscribe(handler BackplaneHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+	return nil
+}
+
+// ClaimRoom records an instance as the owner of a room
+func (b *PostgresBackplane) ClaimRoom(roomID uuid.UUID, instanceID string) error {
+	query := `
+		INSERT INTO game_room_owners (room_id, instance_id)
+		VALUES ($1, $2)
+		ON CONFLICT (room_id) DO UPDATE SET claimed_at = NOW()
+		WHERE game_room_owners.instance_id = EXCLUDED.instance_id
+	`
+	result, err := b.db.Exec(query, roomID, instanceID)
+	if err != nil {
+		return fmt.Errorf("failed to claim room: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return ErrRoomOwnedElsewhere
+	}
+
+	b.mu.Lock()
+	b.instances[instanceID] = true
+	b.mu.Unlock()
+	return nil
+}
+
+// ReleaseRoom removes an instance's ownership of a room
+func (b *PostgresBackplane) ReleaseRoom(roomID uuid.UUID, instanceID string) error {
+	if _, err := b.db.Exec(`DELETE FROM game_room_owners WHERE room_id = $1 AND instance_id = $2`, roomID, instanceID); err != nil {
+		return fmt.Errorf("failed to release room: %w", err)
+	}
+	return nil
+}
+
+// RoomOwner returns the instance hosting a room
+func (b *PostgresBackplane) RoomOwner(roomID uuid.UUID) (string, error) {
+	var owner string
+	err := b.db.QueryRow(`SELECT instance_id FROM game_room_owners WHERE room_id = $1`, roomID).Scan(&owner)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrRoomNotFound
+		}
+		return "", fmt.Errorf("failed to look up room owner: %w", err)
+	}
+	return owner, nil
+}
+
+// Close stops listening and releases the rooms claimed through this backplane
+// so a replacement instance does not inherit stale ownership
+func (b *PostgresBackplane) Close() error {
+	b.cancel()
+
+	b.mu.RLock()
+	instances := make([]string, 0, len(b.instances))
+	for instanceID := range b.instances {
+		instances = append(instances, instanceID)
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	if len(instances) > 0 {
+		if _, err := b.db.Exec(`DELETE FROM game_room_owners WHERE instance_id = ANY($1)`, pq.Array(instances)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to release rooms: %w", err))
+		}
+	}
+	if err := b.listener.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// listen dispatches notifications to the subscribed handlers
+func (b *PostgresBackplane) listen() {
+	cleanup := time.NewTicker(backplaneMessageTTL)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+
+		case n := <-b.listener.Notify:
+			// A nil notification means the connection was re-established
+			if n == nil {
+				continue
+			}
+			message, err := b.decode(n.Extra)
+			if err != nil {
+				log.Printf("Failed to read backplane notification: %v", err)
+				continue
+			}
+
+			b.mu.RLock()
+			handlers := b.handlers
+			b.mu.RUnlock()
+			for _, handler := range handlers {
+				handler(message)
+			}
+
+		case <-cleanup.C:
+			if _, err := b.db.Exec(`DELETE FROM game_backplane_messages WHERE created_at < $1`, time.Now().Add(-backplaneMessageTTL)); err != nil {
+				log.Printf("Failed to clean up backplane messages: %v", err)
+			}
+			go b.listener.Ping()
+		}
+	}
+}
+
+// decode reads a notification payload, fetching parked messages by reference
+func (b *PostgresBackplane) decode(payload string) (*BackplaneMessage, error) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return nil, err
+	}
+	if n.Ref == 0 {
+		if n.Message == nil {
+			return nil, errors.New("empty backplane notification")
+		}
+		return n.Message, nil
+	}
+
+	var data []byte
+	if err := b.db.QueryRow(`SELECT payload FROM game_backplane_messages WHERE id = $1`, n.Ref).Scan(&data); err != nil {
+		return nil, fmt.Errorf("failed to fetch parked message %d: %w", n.Ref, err)
+	}
+
+	var message BackplaneMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
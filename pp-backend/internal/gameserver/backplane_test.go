@@ -0,0 +1,167 @@
+// internal/gameserver/backplane_test.go
+package gameserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBackplaneTestServer serves one game server instance on a shared backplane.
+// Test token services share their secrets, so tokens work on every instance.
+func newBackplaneTestServer(t *testing.T, instanceID string, backplane gameserver.Backplane) (*gameserver.GameServer, *service.TokenService, *httptest.Server) {
+	t.Helper()
+	config := gameserver.GetDefaultConfig()
+	config.InstanceID = instanceID
+	return newSocketTestServer(t, config, gameserver.WithBackplane(backplane))
+}
+
+// countingBackplane counts the room broadcasts published to a backplane
+type countingBackplane struct {
+	*gameserver.MemoryBackplane
+	roomMessages atomic.Int64
+}
+
+func (b *countingBackplane) Publish(message *gameserver.BackplaneMessage) error {
+	if message.Kind == gameserver.BackplaneRoom {
+		b.roomMessages.Add(1)
+	}
+	return b.MemoryBackplane.Publish(message)
+}
+
+// unclaimableBackplane fails every room claim
+type unclaimableBackplane struct {
+	*gameserver.MemoryBackplane
+}
+
+func (b *unclaimableBackplane) ClaimRoom(roomID uuid.UUID, instanceID string) error {
+	return errors.New("backplane unavailable")
+}
+
+// readUntil reads socket frames until a message of the wanted type arrives
+func readUntil(t *testing.T, conn *websocket.Conn, messageType string) gameserver.WebSocketMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		_, frame, err := conn.ReadMessage()
+		require.NoError(t, err)
+		for _, line := range bytes.Split(frame, []byte{'\n'}) {
+			var message gameserver.WebSocketMessage
+			require.NoError(t, json.Unmarshal(line, &message))
+			if message.Type == messageType {
+				return message
+			}
+		}
+	}
+}
+
+func TestBackplane_PlayersOnDifferentInstancesShareARoom(t *testing.T) {
+	backplane := gameserver.NewMemoryBackplane()
+	defer backplane.Close()
+
+	owner, tokenSvc, _ := newBackplaneTestServer(t, "instance-a", backplane)
+	other, _, srv := newBackplaneTestServer(t, "instance-b", backplane)
+
+	room, err := owner.GetRoomManager().CreateRoom("host", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+	instance, err := backplane.RoomOwner(room.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "instance-a", instance)
+
+	accessToken, err := tokenSvc.CreateAccessToken("guest", "user")
+	require.NoError(t, err)
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+accessToken)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws/guest", header)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The join is forwarded to the owning instance and acknowledged over the backplane
+	require.NoError(t, conn.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeJoinRoom,
+		"data": map[string]interface{}{"roomId": room.ID.String()},
+	}))
+	joined := readUntil(t, conn, gameserver.MessageTypeRoomJoined)
+	assert.Equal(t, "player", joined.Data["role"])
+
+	hostedRoom, inRoom := owner.GetRoomManager().GetUserRoom("guest")
+	require.True(t, inRoom)
+	assert.Equal(t, room.ID, hostedRoom.ID)
+	_, localRoom := other.GetRoomManager().GetUserRoom("guest")
+	assert.False(t, localRoom)
+
+	// Room broadcasts from the owner reach the guest's socket on the other instance
+	require.Eventually(t, func() bool {
+		return other.GetWebSocketManager().GetRoomConnectionsCount(room.ID) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, owner.GetRoomManager().SetPlayerReady(room.ID, "host", true))
+	ready := readUntil(t, conn, gameserver.RoomEventPlayerReady)
+	assert.Equal(t, "host", ready.From)
+
+	require.NoError(t, conn.WriteJSON(map[string]interface{}{"type": gameserver.MessageTypeLeaveRoom}))
+	readUntil(t, conn, gameserver.MessageTypeRoomLeft)
+	_, inRoom = owner.GetRoomManager().GetUserRoom("guest")
+	assert.False(t, inRoom)
+	assert.Zero(t, other.GetWebSocketManager().GetRoomConnectionsCount(room.ID))
+}
+
+func TestBackplane_PublishesRoomBroadcastsOnlyWithRemotePlayers(t *testing.T) {
+	backplane := &countingBackplane{MemoryBackplane: gameserver.NewMemoryBackplane()}
+	defer backplane.Close()
+
+	owner, tokenSvc, ownerSrv := newBackplaneTestServer(t, "instance-a", backplane)
+	other, _, otherSrv := newBackplaneTestServer(t, "instance-b", backplane)
+
+	host := dialGameSocket(t, ownerSrv, tokenSvc, "host", "/ws/host")
+	defer host.Close()
+	room, err := owner.GetRoomManager().CreateRoom("host", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+
+	// Every player is local, so the broadcast stays on this instance
+	require.NoError(t, owner.GetRoomManager().SetPlayerReady(room.ID, "host", true))
+	readUntil(t, host, gameserver.RoomEventPlayerReady)
+	assert.Never(t, func() bool { return backplane.roomMessages.Load() > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	guest := dialGameSocket(t, otherSrv, tokenSvc, "guest", "/ws/guest")
+	defer guest.Close()
+	require.NoError(t, guest.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeJoinRoom,
+		"data": map[string]interface{}{"roomId": room.ID.String()},
+	}))
+	readUntil(t, guest, gameserver.MessageTypeRoomJoined)
+	require.Eventually(t, func() bool {
+		return other.GetWebSocketManager().GetRoomConnectionsCount(room.ID) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, owner.GetRoomManager().SetPlayerReady(room.ID, "host", false))
+	assert.Equal(t, "host", readUntil(t, guest, gameserver.RoomEventPlayerNotReady).From)
+	assert.Positive(t, backplane.roomMessages.Load())
+}
+
+func TestBackplane_CreateRoomFailsWhenTheClaimFails(t *testing.T) {
+	backplane := &unclaimableBackplane{MemoryBackplane: gameserver.NewMemoryBackplane()}
+	defer backplane.Close()
+
+	gs, _, _ := newBackplaneTestServer(t, "instance-a", backplane)
+	rm := gs.GetRoomManager()
+
+	_, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, nil)
+	require.Error(t, err)
+	_, inRoom := rm.GetUserRoom("host")
+	assert.False(t, inRoom)
+	assert.Empty(t, rm.ListPublicRooms())
+}
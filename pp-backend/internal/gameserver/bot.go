@@ -0,0 +1,345 @@
+// internal/gameserver/bot.go
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+)
+
+// BotDifficulty sets how fast and how well server-side bots play
+type BotDifficulty string
+
+const (
+	BotDifficultyEasy   BotDifficulty = "easy"
+	BotDifficultyNormal BotDifficulty = "normal"
+	BotDifficultyHard   BotDifficulty = "hard"
+)
+
+// defaultBotFillTimeout is how long a short-handed room waits for people before bots take the empty seats
+const defaultBotFillTimeout = 30 * time.Second
+
+// botNamePrefix starts the username of every bot
+const botNamePrefix = "bot-"
+
+// botProfile is the play style of a difficulty level
+type botProfile struct {
+	interval time.Duration // Average time between actions
+	accuracy float64       // Chance that a move succeeds
+	minCells int           // Paint battle stroke size
+	maxCells int
+}
+
+var botProfiles = map[BotDifficulty]botProfile{
+	BotDifficultyEasy:   {interval: 900 * time.Millisecond, accuracy: 0.3, minCells: 1, maxCells: 2},
+	BotDifficultyNormal: {interval: 500 * time.Millisecond, accuracy: 0.6, minCells: 2, maxCells: 4},
+	BotDifficultyHard:   {interval: 250 * time.Millisecond, accuracy: 0.9, minCells: 4, maxCells: 5},
+}
+
+// parseBotSettings reads whether bots may fill the room and how strong they
+// are. Public rooms allow bots unless told otherwise.
+func parseBotSettings(settings map[string]interface{}, isPrivate bool) (allow bool, difficulty BotDifficulty) {
+	allow, difficulty = !isPrivate, BotDifficultyNormal
+	if settings == nil {
+		return allow, difficulty
+	}
+	if v, ok := settings["allowBots"].(bool); ok {
+		allow = v
+	}
+	if v, ok := settings["botDifficulty"].(string); ok {
+		if _, known := botProfiles[BotDifficulty(v)]; known {
+			difficulty = BotDifficulty(v)
+		}
+	}
+	return allow, difficulty
+}
+
+// humanCount returns how many seats are taken by people (assumes room lock is held)
+func (room *GameRoom) humanCount() int {
+	humans := 0
+	for _, player := range room.Players {
+		if !player.IsBot {
+			humans++
+		}
+	}
+	return humans
+}
+
+// botNames returns the usernames of the room's bots (assumes room lock is held)
+func (room *GameRoom) botNames() []string {
+	var names []string
+	for username, player := range room.Players {
+		if player.IsBot {
+			names = append(names, username)
+		}
+	}
+	return names
+}
+
+// hasBotSeat reports whether a bot would give up its seat to a joining player (assumes room lock is held)
+func (room *GameRoom) hasBotSeat() bool {
+	if room.State != RoomStateWaiting && room.State != RoomStateReady {
+		return false
+	}
+	return len(room.botNames()) > 0
+}
+
+// openSeats returns how many players could still join; seats held by bots
+// count as open until the game starts (assumes room lock is held)
+func (room *GameRoom) openSeats() int {
+	if room.hasBotSeat() {
+		return room.MaxPlayers - room.humanCount()
+	}
+	return room.MaxPlayers - len(room.Players)
+}
+
+// allPlayersReady reports whether every seated player is ready (assumes room lock is held)
+func (room *GameRoom) allPlayersReady() bool {
+	for _, player := range room.Players {
+		player.mu.RLock()
+		ready := player.IsReady
+		player.mu.RUnlock()
+		if !ready {
+			return false
+		}
+	}
+	return true
+}
+
+// runBotFill seats bots in a room that has been short-handed for the fill
+// timeout, until the game starts or the room closes
+func (rm *RoomManager) runBotFill(room *GameRoom) {
+	timer := time.NewTimer(rm.botFillTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-room.ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		wait, done := rm.fillWithBots(room.ID, time.Now())
+		if done {
+			return
+		}
+		timer.Reset(wait)
+	}
+}
+
+// fillWithBots seats bots up to the room's minimum player count once nobody
+// has joined for the fill timeout. It returns when to check again, or done
+// once the room no longer takes players.
+func (rm *RoomManager) fillWithBots(roomID uuid.UUID, now time.Time) (wait time.Duration, done bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	room, exists := rm.rooms[roomID]
+	if !exists {
+		return 0, true
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	switch room.State {
+	case RoomStateWaiting, RoomStateReady:
+	default:
+		return 0, true
+	}
+	if !room.AllowBots || len(room.Players) >= room.MinPlayers {
+		return rm.botFillTimeout, false
+	}
+	if idle := now.Sub(room.LastActivity); idle < rm.botFillTimeout {
+		return rm.botFillTimeout - idle, false
+	}
+
+	for len(room.Players) < room.MinPlayers {
+		rm.addBot(room, now)
+	}
+	if room.State == RoomStateWaiting && room.allPlayersReady() {
+		room.State = RoomStateReady
+	}
+
+	return rm.botFillTimeout, false
+}
+
+// addBot seats a ready bot of the room's difficulty (assumes manager and room locks are held)
+func (rm *RoomManager) addBot(room *GameRoom, now time.Time) *Player {
+	username := botNamePrefix + strings.ReplaceAll(uuid.NewString(), "-", "")[:8]
+	bot := &Player{
+		Username:      username,
+		IsReady:       true,
+		Connected:     true,
+		IsBot:         true,
+		BotDifficulty: room.BotDifficulty,
+		GameData:      make(map[string]interface{}),
+	}
+
+	room.Players[username] = bot
+	room.participants = append(room.participants, username)
+	rm.userRooms[username] = room.ID
+	room.LastActivity = now
+
+	room.emitEvent(&GameRoomEvent{
+		Type:     RoomEventPlayerJoined,
+		RoomID:   room.ID,
+		Username: username,
+		Data: map[string]interface{}{"player": map[string]interface{}{
+			"username":      username,
+			"isReady":       true,
+			"isHost":        false,
+			"connected":     true,
+			"score":         0,
+			"isBot":         true,
+			"botDifficulty": room.BotDifficulty,
+		}},
+		Timestamp: now,
+	})
+
+	return bot
+}
+
+// removeBot frees a bot's seat for a joining player (assumes manager and room locks are held)
+func (rm *RoomManager) removeBot(room *GameRoom) error {
+	names := room.botNames()
+	if len(names) == 0 {
+		return ErrRoomFull
+	}
+	return rm.removePlayer(room, names[0])
+}
+
+// removeBots takes every bot out of the room (assumes manager and room locks are held)
+func (rm *RoomManager) removeBots(room *GameRoom) {
+	for _, username := range room.botNames() {
+		rm.removePlayer(room, username)
+	}
+}
+
+// startBots lets the room's bots play the new session (assumes room lock is held)
+func (rm *RoomManager) startBots(room *GameRoom) {
+	for _, username := range room.botNames() {
+		player := room.Players[username]
+		bot := &roomBot{
+			username: username,
+			gameType: room.GameType,
+			profile:  botProfiles[player.BotDifficulty],
+			rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+			guessed:  make(map[int]bool),
+		}
+		go rm.runBot(room, room.GameSession.SessionID, bot)
+	}
+}
+
+// roomBot plays one seat of a room through the same action path as people
+type roomBot struct {
+	username string
+	gameType minigame.GameType
+	profile  botProfile
+	rng      *rand.Rand
+	guessed  map[int]bool // Numbers already tried in number guess
+}
+
+// runBot sends the bot's actions until its game is over
+func (rm *RoomManager) runBot(room *GameRoom, sessionID uuid.UUID, bot *roomBot) {
+	timer := time.NewTimer(bot.nextDelay())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-room.ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		room.mu.RLock()
+		player, seated := room.Players[bot.username]
+		running := seated && room.State == RoomStateInProgress && room.GameSession != nil && room.GameSession.SessionID == sessionID
+		var action map[string]interface{}
+		if running {
+			player.mu.RLock()
+			action = bot.nextAction(player.GameData)
+			player.mu.RUnlock()
+		}
+		room.mu.RUnlock()
+
+		if !running {
+			return
+		}
+		if action != nil {
+			err := rm.ProcessGameAction(room.ID, bot.username, action)
+			switch {
+			case errors.Is(err, ErrInvalidRoomState), errors.Is(err, ErrPlayerNotInRoom), errors.Is(err, ErrRoomNotFound):
+				return
+			}
+		}
+
+		timer.Reset(bot.nextDelay())
+	}
+}
+
+// nextDelay spreads the bot's actions around its profile's interval
+func (b *roomBot) nextDelay() time.Duration {
+	jitter := time.Duration(b.rng.Int63n(int64(b.profile.interval) / 2))
+	return b.profile.interval*3/4 + jitter
+}
+
+// nextAction picks the bot's next move from what it can see of its own game
+// state, or nil for game types it cannot play
+func (b *roomBot) nextAction(gameData map[string]interface{}) map[string]interface{} {
+	switch b.gameType {
+	case minigame.GameTypeClickSpeed:
+		return map[string]interface{}{"type": "click"}
+
+	case minigame.GameTypeMemoryMatch:
+		return map[string]interface{}{
+			"type": "match_attempt",
+			"data": map[string]interface{}{"isMatch": b.rng.Float64() < b.profile.accuracy},
+		}
+
+	case minigame.GameTypeNumberGuess:
+		if len(b.guessed) >= 100 {
+			return nil
+		}
+		guess := 1 + b.rng.Intn(100)
+		for b.guessed[guess] {
+			guess = guess%100 + 1
+		}
+		b.guessed[guess] = true
+		return map[string]interface{}{
+			"type": "guess",
+			"data": map[string]interface{}{"number": float64(guess)},
+		}
+
+	case minigame.GameTypePaintBattle:
+		cells := b.profile.minCells + b.rng.Intn(b.profile.maxCells-b.profile.minCells+1)
+		return map[string]interface{}{
+			"type": "paint",
+			"data": map[string]interface{}{"cells": float64(cells)},
+		}
+
+	case minigame.GameTypePhysicsJump:
+		if b.rng.Float64() >= b.profile.accuracy {
+			return map[string]interface{}{"type": "fall"}
+		}
+		platform, _ := gameData["platform"].(int)
+		return map[string]interface{}{
+			"type": "land",
+			"data": map[string]interface{}{"platform": float64(platform + 1)},
+		}
+	}
+	return nil
+}
+
+// validateBotDifficulty checks a difficulty sent by a client
+func validateBotDifficulty(difficulty string) (BotDifficulty, error) {
+	if _, known := botProfiles[BotDifficulty(difficulty)]; !known {
+		return "", fmt.Errorf("%w: botDifficulty must be easy, normal or hard", ErrInvalidRoomSettings)
+	}
+	return BotDifficulty(difficulty), nil
+}
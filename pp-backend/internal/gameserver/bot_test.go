@@ -0,0 +1,163 @@
+// internal/gameserver/bot_test.go
+package gameserver_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBotTestRoomManager(t *testing.T) *gameserver.RoomManager {
+	t.Helper()
+	config := gameserver.GetDefaultConfig()
+	config.BotFillTimeout = 50 * time.Millisecond
+	return gameserver.NewGameServer(config, minigame.NewMiniGameEngine(nil, nil)).GetRoomManager()
+}
+
+// roomBots returns the usernames of the bots seated in a room
+func roomBots(room *gameserver.GameRoom) []string {
+	var bots []string
+	for username, stats := range room.GetRoomStats()["players"].(map[string]interface{}) {
+		if stats.(map[string]interface{})["isBot"] == true {
+			bots = append(bots, username)
+		}
+	}
+	return bots
+}
+
+func TestBots_FillShortHandedRoomAndPlay(t *testing.T) {
+	rm := newBotTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, map[string]interface{}{
+		"botDifficulty": "hard",
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return room.GetRoomStats()["botCount"] == 1
+	}, time.Second, 10*time.Millisecond)
+	bots := roomBots(room)
+	require.Len(t, bots, 1)
+	bot := bots[0]
+	assert.Equal(t, gameserver.BotDifficultyHard, room.GetRoomStats()["botDifficulty"])
+
+	assert.ErrorIs(t, rm.TransferHost(room.ID, "host", bot), gameserver.ErrInvalidRoomState)
+
+	require.NoError(t, rm.SetPlayerReady(room.ID, "host", true))
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+	require.NoError(t, rm.ProcessGameAction(room.ID, "host", map[string]interface{}{"type": "click"}))
+
+	// The bot plays through the same action path as people
+	require.Eventually(t, func() bool {
+		players := room.GetRoomStats()["players"].(map[string]interface{})
+		return players[bot].(map[string]interface{})["score"].(int) >= 2
+	}, 2*time.Second, 20*time.Millisecond)
+
+	require.NoError(t, rm.EndGame(room.ID))
+	require.NotNil(t, room.Result)
+	for _, player := range room.Result.Players {
+		if player.Username == bot {
+			assert.True(t, player.IsBot)
+			assert.False(t, player.IsValid)
+			assert.Zero(t, player.PointsEarned)
+		} else {
+			assert.False(t, player.IsBot)
+		}
+	}
+}
+
+func TestBots_OnlyFillRoomsThatAllowThem(t *testing.T) {
+	rm := newBotTestRoomManager(t)
+
+	private, err := rm.CreateRoom("alice", minigame.GameTypeClickSpeed, map[string]interface{}{"isPrivate": true})
+	require.NoError(t, err)
+	noBots, err := rm.CreateRoom("bob", minigame.GameTypeClickSpeed, map[string]interface{}{"allowBots": false})
+	require.NoError(t, err)
+	invited, err := rm.CreateRoom("carol", minigame.GameTypeClickSpeed, map[string]interface{}{
+		"isPrivate": true,
+		"allowBots": true,
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return invited.GetRoomStats()["botCount"] == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, private.GetRoomStats()["botCount"])
+	assert.Equal(t, 0, noBots.GetRoomStats()["botCount"])
+
+	// Turning bots off sends the seated ones away
+	allowBots := false
+	_, err = rm.UpdateRoomSettings(invited.ID, "carol", &gameserver.RoomSettingsUpdate{AllowBots: &allowBots})
+	require.NoError(t, err)
+	assert.Equal(t, 0, invited.GetRoomStats()["botCount"])
+}
+
+func TestBots_GiveUpSeatsAndLeaveWithPeople(t *testing.T) {
+	rm := newBotTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return room.GetRoomStats()["botCount"] == 1
+	}, time.Second, 10*time.Millisecond)
+	bot := roomBots(room)[0]
+
+	// A person takes the bot's seat in a full room
+	_, err = rm.JoinRoom(room.ID, "guest", "")
+	require.NoError(t, err)
+	assert.Equal(t, 0, room.GetRoomStats()["botCount"])
+	_, seated := rm.GetUserRoom(bot)
+	assert.False(t, seated)
+
+	// A room with only bots left closes
+	require.NoError(t, rm.LeaveRoom(room.ID, "guest"))
+	require.Eventually(t, func() bool {
+		return room.GetRoomStats()["botCount"] == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, rm.LeaveRoom(room.ID, "host"))
+	_, exists := rm.GetRoom(room.ID)
+	assert.False(t, exists)
+}
+
+func TestBots_SeatsStayOpenForBackfill(t *testing.T) {
+	rm := newBotTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return room.GetRoomStats()["botCount"] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// The room is full of bots, but queued people may still take the bot's seat
+	slots, ok := rm.OpenSlots(room.ID)
+	require.True(t, ok)
+	assert.Equal(t, 1, slots)
+
+	require.NoError(t, rm.SetPlayerReady(room.ID, "host", true))
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+	_, ok = rm.OpenSlots(room.ID)
+	assert.False(t, ok)
+}
+
+func TestSettlement_SkipsBots(t *testing.T) {
+	stores := newRecordingStores()
+	settlement := gameserver.NewSettlement(stores, stores, stores)
+
+	err := settlement.Settle(&gameserver.RoomResult{
+		GameType: minigame.GameTypeClickSpeed,
+		Players: []*gameserver.PlayerResult{
+			{Username: "bot-1", Placement: 1, Score: 20, IsBot: true},
+			{Username: "host", Placement: 2, Score: 10, PointsEarned: 10, IsValid: true},
+		},
+	})
+	require.NoError(t, err)
+
+	sessions, entries, payouts := stores.counts()
+	assert.Equal(t, 1, sessions)
+	assert.Equal(t, 1, entries)
+	assert.Equal(t, 1, payouts)
+}
@@ -0,0 +1,284 @@
+// internal/gameserver/chat.go
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"golang.org/x/time/rate"
+)
+
+// Chat errors reported to the sender
+var (
+	ErrInvalidChatMessage = errors.New("invalid chat message")
+	ErrChatRateLimited    = errors.New("sending messages too fast")
+)
+
+// Message types for room chat. Clients send them with text or an emote and
+// receive them back from everyone in the room.
+const (
+	MessageTypeRoomChat  = "room_chat"
+	MessageTypeRoomEmote = "room_emote"
+)
+
+const (
+	// maxChatLength is the longest chat message in characters
+	maxChatLength = 200
+
+	// chatBurst messages may be sent at once, then one per chatInterval
+	chatBurst    = 5
+	chatInterval = time.Second
+
+	// blockListTTL is how long a player's blocked users are cached
+	blockListTTL = 30 * time.Second
+)
+
+// roomEmotes are the quick emotes players can send
+var roomEmotes = map[string]bool{
+	"gg":        true,
+	"wave":      true,
+	"laugh":     true,
+	"wow":       true,
+	"thumbs_up": true,
+	"sad":       true,
+	"angry":     true,
+	"heart":     true,
+}
+
+// defaultProfanity is masked in room chat unless another filter is configured
+var defaultProfanity = []string{
+	"fuck", "shit", "bitch", "asshole", "bastard",
+	"씨발", "시발", "병신", "개새끼", "좆",
+}
+
+// ChatFilter cleans up chat text before it is delivered
+type ChatFilter interface {
+	// Filter returns the text to deliver and whether anything was masked
+	Filter(text string) (string, bool)
+}
+
+// BlockLister lists the users a player has blocked
+type BlockLister interface {
+	ListBlockedUsers(username string) ([]*repository.BlockedUser, error)
+}
+
+// wordFilter masks listed words regardless of case
+type wordFilter struct {
+	words [][]rune
+}
+
+// NewWordFilter creates a chat filter that masks the given words with asterisks
+func NewWordFilter(words []string) ChatFilter {
+	filter := &wordFilter{}
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			filter.words = append(filter.words, []rune(strings.ToLower(word)))
+		}
+	}
+	return filter
+}
+
+func (f *wordFilter) Filter(text string) (string, bool) {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	masked := false
+	for _, word := range f.words {
+		for i := 0; i+len(word) <= len(lower); i++ {
+			if !hasRunesAt(lower, word, i) {
+				continue
+			}
+			for j := i; j < i+len(word); j++ {
+				runes[j] = '*'
+			}
+			masked = true
+		}
+	}
+	return string(runes), masked
+}
+
+// hasRunesAt reports whether word appears in text at position i
+func hasRunesAt(text, word []rune, i int) bool {
+	for j, r := range word {
+		if text[i+j] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// roomChat delivers chat and emotes to a room, hiding messages from players
+// the recipient has blocked
+type roomChat struct {
+	filter    ChatFilter
+	blocks    BlockLister
+	cache     map[string]*blockList
+	nextSweep time.Time // When expired block lists are evicted next
+	mu        sync.Mutex
+}
+
+// blockList is a cached set of users a player has blocked
+type blockList struct {
+	blocked map[string]bool
+	expires time.Time
+}
+
+func newRoomChat() *roomChat {
+	return &roomChat{
+		filter: NewWordFilter(defaultProfanity),
+		cache:  make(map[string]*blockList),
+	}
+}
+
+// hasBlocked reports whether recipient has blocked sender. Lookups that fail
+// let the message through.
+func (c *roomChat) hasBlocked(recipient, sender string) bool {
+	if c.blocks == nil {
+		return false
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	list, cached := c.cache[recipient]
+	c.mu.Unlock()
+	if cached && now.Before(list.expires) {
+		return list.blocked[sender]
+	}
+
+	blocked, err := c.blocks.ListBlockedUsers(recipient)
+	if err != nil {
+		log.Printf("Failed to load blocked users of %s: %v", recipient, err)
+		return false
+	}
+	list = &blockList{blocked: make(map[string]bool, len(blocked)), expires: now.Add(blockListTTL)}
+	for _, user := range blocked {
+		list.blocked[user.BlockedUsername] = true
+	}
+
+	c.mu.Lock()
+	c.evictExpired(now)
+	c.cache[recipient] = list
+	c.mu.Unlock()
+	return list.blocked[sender]
+}
+
+// evictExpired drops block lists past their TTL, at most once per TTL (assumes lock is held)
+func (c *roomChat) evictExpired(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+	for username, list := range c.cache {
+		if !now.Before(list.expires) {
+			delete(c.cache, username)
+		}
+	}
+	c.nextSweep = now.Add(blockListTTL)
+}
+
+// forget drops the block list of a user whose socket closed
+func (c *roomChat) forget(conn *WebSocketConnection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.cache, conn.Username)
+}
+
+// allowChat takes a token from the connection's chat rate limit
+func (conn *WebSocketConnection) allowChat() bool {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.chatLimiter == nil {
+		conn.chatLimiter = rate.NewLimiter(rate.Every(chatInterval), chatBurst)
+	}
+	return conn.chatLimiter.Allow()
+}
+
+// limitChat rate limits chat on the instance that holds the socket, before
+// the message is routed to the room's owner
+func (gs *GameServer) limitChat(handler MessageHandler) MessageHandler {
+	return func(conn *WebSocketConnection, message *WebSocketMessage) error {
+		if !conn.allowChat() {
+			return ErrChatRateLimited
+		}
+		return handler(conn, message)
+	}
+}
+
+// handleRoomChatMessage filters a player's chat text and sends it to the room
+func (gs *GameServer) handleRoomChatMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	text, _ := message.Data["text"].(string)
+	text = strings.TrimSpace(text)
+	if text == "" || len([]rune(text)) > maxChatLength {
+		return fmt.Errorf("%w: text must be 1 to %d characters", ErrInvalidChatMessage, maxChatLength)
+	}
+
+	filtered := false
+	if gs.chat.filter != nil {
+		text, filtered = gs.chat.filter.Filter(text)
+	}
+
+	return gs.sendRoomChat(conn.Username, MessageTypeRoomChat, map[string]interface{}{
+		"username": conn.Username,
+		"text":     text,
+		"filtered": filtered,
+	})
+}
+
+// handleRoomEmoteMessage sends one of the predefined emotes to the room
+func (gs *GameServer) handleRoomEmoteMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	emote, _ := message.Data["emote"].(string)
+	if !roomEmotes[emote] {
+		return fmt.Errorf("%w: unknown emote %q", ErrInvalidChatMessage, emote)
+	}
+
+	return gs.sendRoomChat(conn.Username, MessageTypeRoomEmote, map[string]interface{}{
+		"username": conn.Username,
+		"emote":    emote,
+	})
+}
+
+// sendRoomChat delivers a chat message to the sender's room, skipping bots
+// and everyone who has blocked the sender
+func (gs *GameServer) sendRoomChat(sender, messageType string, data map[string]interface{}) error {
+	room, exists := gs.roomManager.GetUserRoom(sender)
+	if !exists {
+		if _, spectating := gs.roomManager.GetSpectatingRoom(sender); spectating {
+			return ErrSpectatorCannotAct
+		}
+		return ErrPlayerNotInRoom
+	}
+
+	room.mu.RLock()
+	recipients := room.spectatorNames()
+	for username, player := range room.Players {
+		if !player.IsBot {
+			recipients = append(recipients, username)
+		}
+	}
+	room.mu.RUnlock()
+
+	message := &WebSocketMessage{
+		Type:      messageType,
+		Data:      data,
+		Timestamp: time.Now(),
+		From:      sender,
+		RoomID:    &room.ID,
+	}
+	for _, recipient := range recipients {
+		if recipient != sender && gs.chat.hasBlocked(recipient, sender) {
+			continue
+		}
+		gs.wsManager.SendToUser(recipient, message)
+	}
+	return nil
+}
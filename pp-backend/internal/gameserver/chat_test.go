@@ -0,0 +1,102 @@
+// internal/gameserver/chat_test.go
+package gameserver_test
+
+import (
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticBlocks maps each user to the users they have blocked
+type staticBlocks map[string][]string
+
+func (b staticBlocks) ListBlockedUsers(username string) ([]*repository.BlockedUser, error) {
+	var blocked []*repository.BlockedUser
+	for _, name := range b[username] {
+		blocked = append(blocked, &repository.BlockedUser{BlockerUsername: username, BlockedUsername: name})
+	}
+	return blocked, nil
+}
+
+// newChatTestRoom seats alice, bob and carol in one room over game sockets.
+// Carol has blocked alice.
+func newChatTestRoom(t *testing.T) (alice, bob, carol *websocket.Conn) {
+	t.Helper()
+	gs, tokenSvc, srv := newSocketTestServer(t, gameserver.GetDefaultConfig(), gameserver.WithBlockList(staticBlocks{"carol": {"alice"}}))
+
+	alice = dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	bob = dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	carol = dialGameSocket(t, srv, tokenSvc, "carol", "/ws/carol")
+	for _, conn := range []*websocket.Conn{alice, bob, carol} {
+		t.Cleanup(func() { conn.Close() })
+	}
+
+	rm := gs.GetRoomManager()
+	room, err := rm.CreateRoom("alice", minigame.GameTypeClickSpeed, map[string]interface{}{"maxPlayers": float64(4)})
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "bob", "")
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "carol", "")
+	require.NoError(t, err)
+	return alice, bob, carol
+}
+
+func sendChat(t *testing.T, conn *websocket.Conn, messageType string, data map[string]interface{}) {
+	t.Helper()
+	require.NoError(t, conn.WriteJSON(map[string]interface{}{"type": messageType, "data": data}))
+}
+
+func TestRoomChat_FiltersAndHidesBlockedSenders(t *testing.T) {
+	alice, bob, carol := newChatTestRoom(t)
+
+	sendChat(t, alice, gameserver.MessageTypeRoomChat, map[string]interface{}{"text": "good game SHIT"})
+	chat := readUntil(t, bob, gameserver.MessageTypeRoomChat)
+	assert.Equal(t, "alice", chat.Data["username"])
+	assert.Equal(t, "good game ****", chat.Data["text"])
+	assert.Equal(t, true, chat.Data["filtered"])
+	assert.Equal(t, "good game ****", readUntil(t, alice, gameserver.MessageTypeRoomChat).Data["text"])
+
+	// Carol blocked alice, so the first chat she sees is bob's
+	sendChat(t, bob, gameserver.MessageTypeRoomChat, map[string]interface{}{"text": "gg"})
+	chat = readUntil(t, carol, gameserver.MessageTypeRoomChat)
+	assert.Equal(t, "bob", chat.Data["username"])
+	assert.Equal(t, false, chat.Data["filtered"])
+
+	sendChat(t, carol, gameserver.MessageTypeRoomEmote, map[string]interface{}{"emote": "wave"})
+	emote := readUntil(t, alice, gameserver.MessageTypeRoomEmote)
+	assert.Equal(t, "carol", emote.Data["username"])
+	assert.Equal(t, "wave", emote.Data["emote"])
+
+	sendChat(t, carol, gameserver.MessageTypeRoomEmote, map[string]interface{}{"emote": "dance"})
+	assert.Equal(t, "INVALID_CHAT_MESSAGE", readUntil(t, carol, gameserver.MessageTypeError).Data["code"])
+}
+
+func TestRoomChat_RateLimitsEachConnection(t *testing.T) {
+	alice, bob, _ := newChatTestRoom(t)
+
+	for i := 0; i < 10; i++ {
+		sendChat(t, alice, gameserver.MessageTypeRoomChat, map[string]interface{}{"text": "spam"})
+	}
+	assert.Equal(t, "CHAT_RATE_LIMITED", readUntil(t, alice, gameserver.MessageTypeError).Data["code"])
+
+	// Other players still have their own allowance
+	sendChat(t, bob, gameserver.MessageTypeRoomEmote, map[string]interface{}{"emote": "gg"})
+	assert.Equal(t, "bob", readUntil(t, alice, gameserver.MessageTypeRoomEmote).Data["username"])
+}
+
+func TestWordFilter_MasksCaseInsensitively(t *testing.T) {
+	filter := gameserver.NewWordFilter([]string{"darn", "병신"})
+
+	text, masked := filter.Filter("Darn it, 병신아")
+	assert.True(t, masked)
+	assert.Equal(t, "**** it, **아", text)
+
+	text, masked = filter.Filter("well played")
+	assert.False(t, masked)
+	assert.Equal(t, "well played", text)
+}
@@ -1,58 +1,71 @@
+// internal/gameserver/connection_pool.go
 package gameserver
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/gorilla/websocket"
 	"github.com/google/uuid"
-	"github.com/pitturu-ppaturu/backend/internal/pool"
+	"github.com/gorilla/websocket"
 )
 
-// ConnectionPool manages WebSocket connections with optimized performance
+// ErrConnectionLimit is returned when the pool holds as many connections as it may
+var ErrConnectionLimit = errors.New("connection limit reached")
+
+// ConnectionPool owns the WebSocket connections of one game server instance. It
+// indexes them by ID, user and room, runs their read and write pumps, and fans
+// out broadcasts without taking a lock on the send path
 type ConnectionPool struct {
-	connections     sync.Map // uuid.UUID -> *OptimizedConnection
-	userConnections sync.Map // string -> *OptimizedConnection
-	roomConnections sync.Map // uuid.UUID -> *sync.Map (connectionID -> *OptimizedConnection)
+	connections     sync.Map   // uuid.UUID -> *WebSocketConnection
+	userConnections sync.Map   // string -> *WebSocketConnection
+	roomConnections sync.Map   // uuid.UUID -> *sync.Map (connectionID -> *WebSocketConnection)
+	roomsMu         sync.Mutex // Serializes room membership changes; broadcasts only read
 
 	// Channels for connection management
-	register   chan *OptimizedConnection
-	unregister chan *OptimizedConnection
+	register   chan *WebSocketConnection
+	unregister chan *WebSocketConnection
 
 	// Broadcast channels with buffering
-	globalBroadcast chan *PooledMessage
-	roomBroadcast   chan *RoomMessage
+	globalBroadcast chan []byte
+	roomBroadcast   chan RoomMessage
 
 	// Configuration
 	config *PoolConfig
 
+	// Callbacks of the owning WebSocket manager
+	onRegister   func(conn *WebSocketConnection)
+	onUnregister func(conn *WebSocketConnection)
+	onMessage    func(conn *WebSocketConnection, data []byte)
+
 	// Metrics
 	activeConnections int64
-	totalMessages     int64
-	failedMessages    int64
-
-	// Cleanup
-	cleanupTicker *time.Ticker
-	done          chan struct{}
+	totalMessages     int64 // Broadcasts fanned out
+	receivedMessages  int64
+	sentMessages      int64
+	failedMessages    int64 // Sends dropped on a full buffer or failed writes
 
 	upgrader websocket.Upgrader
+	done     <-chan struct{} // Closed once the pool stops
 }
 
 // PoolConfig holds configuration for the connection pool
 type PoolConfig struct {
 	MaxConnections     int
 	WriteTimeout       time.Duration
-	ReadTimeout        time.Duration
+	ReadTimeout        time.Duration // Connections without any activity for this long are dropped
 	PingPeriod         time.Duration
 	PongTimeout        time.Duration
-	MaxMessageSize     int64
+	MaxMessageSize     int64 // Largest client message; bigger ones close the connection
 	WriteBufferSize    int
 	ReadBufferSize     int
 	CleanupInterval    time.Duration
-	MessageChannelSize int
+	MessageChannelSize int // Broadcasts queued for fan-out
+	SendBufferSize     int // Messages queued per connection before sends are dropped
 }
 
 // DefaultPoolConfig returns default configuration
@@ -60,134 +73,59 @@ func DefaultPoolConfig() *PoolConfig {
 	return &PoolConfig{
 		MaxConnections:     10000,
 		WriteTimeout:       10 * time.Second,
-		ReadTimeout:        60 * time.Second,
+		ReadTimeout:        5 * time.Minute,
 		PingPeriod:         54 * time.Second,
 		PongTimeout:        60 * time.Second,
-		MaxMessageSize:     512,
+		MaxMessageSize:     8192,
 		WriteBufferSize:    1024,
 		ReadBufferSize:     1024,
 		CleanupInterval:    30 * time.Second,
-		MessageChannelSize: 1000,
+		MessageChannelSize: 1024,
+		SendBufferSize:     256,
 	}
 }
 
-// OptimizedConnection represents an optimized WebSocket connection
-type OptimizedConnection struct {
-	ID           uuid.UUID
-	Username     string
-	Conn         *websocket.Conn
-	RoomID       *uuid.UUID
-	LastActivity int64 // Unix timestamp for atomic operations
-	IsAlive      int32 // Atomic boolean (0 = false, 1 = true)
-
-	// Channels
-	send chan *PooledMessage
-	done chan struct{}
-
-	// Context for cancellation
-	ctx    context.Context
-	cancel context.CancelFunc
-
-	// Message processing
-	messageHandler func(*PooledMessage)
-
-	// Performance metrics
-	messagesSent     int64
-	messagesReceived int64
-	bytesTransferred int64
-}
-
-// PooledMessage represents a message that can be reused
-type PooledMessage struct {
-	Type      string                 `json:"type"`
-	Data      map[string]interface{} `json:"data"`
-	Timestamp int64                  `json:"timestamp"`
-	From      string                 `json:"from,omitempty"`
-	To        string                 `json:"to,omitempty"`
-	RoomID    *uuid.UUID             `json:"roomId,omitempty"`
-
-	// For pooling
-	buf   []byte
-	inUse bool
-}
-
-// RoomMessage represents a message targeted to a specific room
+// RoomMessage represents an encoded message targeted to a specific room
 type RoomMessage struct {
 	RoomID  uuid.UUID
-	Message *PooledMessage
+	Data    []byte
 	Exclude []uuid.UUID // Connection IDs to exclude
 }
 
-// MessagePool for reusing message objects
-var messagePool = sync.Pool{
-	New: func() interface{} {
-		return &PooledMessage{
-			Data: make(map[string]interface{}),
-			buf:  make([]byte, 0, 512),
-		}
-	},
-}
-
-// GetMessage returns a pooled message
-func GetMessage() *PooledMessage {
-	msg := messagePool.Get().(*PooledMessage)
-	msg.inUse = true
-	msg.Timestamp = time.Now().Unix()
-	// Clear previous data
-	for k := range msg.Data {
-		delete(msg.Data, k)
-	}
-	msg.buf = msg.buf[:0]
-	return msg
-}
-
-// ReleaseMessage returns a message to the pool
-func ReleaseMessage(msg *PooledMessage) {
-	if !msg.inUse {
-		return
-	}
-	msg.inUse = false
-	msg.Type = ""
-	msg.From = ""
-	msg.To = ""
-	msg.RoomID = nil
-	messagePool.Put(msg)
-}
-
 // NewConnectionPool creates a new optimized connection pool
 func NewConnectionPool(config *PoolConfig) *ConnectionPool {
 	if config == nil {
 		config = DefaultPoolConfig()
 	}
 
-	pool := &ConnectionPool{
-		register:        make(chan *OptimizedConnection, 100),
-		unregister:      make(chan *OptimizedConnection, 100),
-		globalBroadcast: make(chan *PooledMessage, config.MessageChannelSize),
-		roomBroadcast:   make(chan *RoomMessage, config.MessageChannelSize),
+	return &ConnectionPool{
+		register:        make(chan *WebSocketConnection, 256),
+		unregister:      make(chan *WebSocketConnection, 256),
+		globalBroadcast: make(chan []byte, config.MessageChannelSize),
+		roomBroadcast:   make(chan RoomMessage, config.MessageChannelSize),
 		config:          config,
-		done:            make(chan struct{}),
-		cleanupTicker:   time.NewTicker(config.CleanupInterval),
 		upgrader: websocket.Upgrader{
 			ReadBufferSize:  config.ReadBufferSize,
 			WriteBufferSize: config.WriteBufferSize,
+			Subprotocols:    []string{wsBearerProtocol},
 			CheckOrigin: func(r *http.Request) bool {
-				return true // Allow all origins for development
+				// TODO: Implement proper origin checking for production
+				return true
 			},
 		},
 	}
-
-	return pool
 }
 
 // Start begins the connection pool management
 func (p *ConnectionPool) Start(ctx context.Context) {
+	p.done = ctx.Done()
 	go p.run(ctx)
 }
 
 // run is the main event loop for the connection pool
 func (p *ConnectionPool) run(ctx context.Context) {
-	defer p.cleanupTicker.Stop()
+	cleanupTicker := time.NewTicker(p.config.CleanupInterval)
+	defer cleanupTicker.Stop()
 
 	for {
 		select {
@@ -197,85 +135,205 @@ func (p *ConnectionPool) run(ctx context.Context) {
 		case conn := <-p.unregister:
 			p.handleUnregister(conn)
 
-		case msg := <-p.globalBroadcast:
-			p.handleGlobalBroadcast(msg)
+		case data := <-p.globalBroadcast:
+			p.handleGlobalBroadcast(data)
 
 		case roomMsg := <-p.roomBroadcast:
 			p.handleRoomBroadcast(roomMsg)
 
-		case <-p.cleanupTicker.C:
+		case <-cleanupTicker.C:
 			p.cleanup()
 
 		case <-ctx.Done():
 			p.shutdown()
 			return
+		}
+	}
+}
 
-		case <-p.done:
-			p.shutdown()
-			return
+// Upgrade turns an HTTP request into a pooled connection of the user and starts serving it
+func (p *ConnectionPool) Upgrade(w http.ResponseWriter, r *http.Request, ctx context.Context, username string) (*WebSocketConnection, error) {
+	if p.Full() {
+		if _, connected := p.userConnections.Load(username); !connected {
+			return nil, ErrConnectionLimit
 		}
 	}
+
+	wsConn, err := p.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	connCtx, cancel := context.WithCancel(ctx)
+	conn := &WebSocketConnection{
+		ID:           uuid.New(),
+		Username:     username,
+		Conn:         wsConn,
+		Platform:     r.URL.Query().Get("platform"),
+		Send:         make(chan []byte, p.config.SendBufferSize),
+		LastActivity: time.Now(),
+		IsAlive:      true,
+		Context:      connCtx,
+		Cancel:       cancel,
+	}
+	select {
+	case p.register <- conn:
+	case <-p.done:
+		cancel()
+		wsConn.Close()
+	}
+	return conn, nil
+}
+
+// Full reports whether the pool reached its connection limit
+func (p *ConnectionPool) Full() bool {
+	return atomic.LoadInt64(&p.activeConnections) >= int64(p.config.MaxConnections)
 }
 
-// handleRegister adds a new connection to the pool
-func (p *ConnectionPool) handleRegister(conn *OptimizedConnection) {
-	// Check connection limit
-	if atomic.LoadInt64(&p.activeConnections) >= int64(p.config.MaxConnections) {
-		conn.Close("Connection limit exceeded")
+// handleRegister adds a new connection to the pool. A user's previous
+// connection is replaced without counting as a disconnect.
+func (p *ConnectionPool) handleRegister(conn *WebSocketConnection) {
+	existing, replacing := p.userConnections.Load(conn.Username)
+	if !replacing && p.Full() {
+		conn.Conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "connection limit exceeded"),
+			time.Now().Add(p.config.WriteTimeout))
+		conn.close()
+		conn.Conn.Close()
 		return
 	}
 
-	// Store connection
+	if replacing {
+		old := existing.(*WebSocketConnection)
+		p.leaveCurrentRoom(old)
+		p.connections.Delete(old.ID)
+		old.close()
+	} else {
+		atomic.AddInt64(&p.activeConnections, 1)
+	}
 	p.connections.Store(conn.ID, conn)
 	p.userConnections.Store(conn.Username, conn)
 
-	if conn.RoomID != nil {
-		p.addToRoom(conn)
+	if p.onRegister != nil {
+		p.onRegister(conn)
 	}
 
-	atomic.AddInt64(&p.activeConnections, 1)
-
-	// Start connection handlers
-	go conn.writePump(p)
-	go conn.readPump(p)
+	go p.writePump(conn)
+	go p.readPump(conn)
 }
 
 // handleUnregister removes a connection from the pool
-func (p *ConnectionPool) handleUnregister(conn *OptimizedConnection) {
-	// Remove from maps
-	p.connections.Delete(conn.ID)
-	p.userConnections.Delete(conn.Username)
+func (p *ConnectionPool) handleUnregister(conn *WebSocketConnection) {
+	if _, exists := p.connections.LoadAndDelete(conn.ID); !exists {
+		return
+	}
+
+	p.leaveCurrentRoom(conn)
+	p.userConnections.CompareAndDelete(conn.Username, conn)
+	atomic.AddInt64(&p.activeConnections, -1)
+	conn.close()
+
+	// Disconnect handlers reach into rooms and the backplane, so they must not
+	// hold up the loop that registers connections and fans out broadcasts
+	if p.onUnregister != nil {
+		go p.reportUnregister(conn)
+	}
+}
+
+// reportUnregister tells the manager about a dropped connection. A user who
+// reconnected in the meantime counts as replaced and is not reported.
+func (p *ConnectionPool) reportUnregister(conn *WebSocketConnection) {
+	if current, connected := p.GetConnection(conn.Username); connected && current != conn {
+		return
+	}
+	p.onUnregister(conn)
+}
+
+// AddToRoom moves a connection into a room, leaving its previous room
+func (p *ConnectionPool) AddToRoom(conn *WebSocketConnection, roomID uuid.UUID) {
+	p.roomsMu.Lock()
+	defer p.roomsMu.Unlock()
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
 
+	if !conn.IsAlive {
+		return
+	}
 	if conn.RoomID != nil {
-		p.removeFromRoom(conn)
+		p.removeFromRoom(conn, *conn.RoomID)
 	}
 
-	atomic.AddInt64(&p.activeConnections, -1)
-	conn.Close("")
+	roomConnections, _ := p.roomConnections.LoadOrStore(roomID, &sync.Map{})
+	roomConnections.(*sync.Map).Store(conn.ID, conn)
+	conn.RoomID = &roomID
+}
+
+// RemoveFromRoom takes a connection out of a room it is in
+func (p *ConnectionPool) RemoveFromRoom(conn *WebSocketConnection, roomID uuid.UUID) {
+	p.roomsMu.Lock()
+	defer p.roomsMu.Unlock()
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.RoomID != nil && *conn.RoomID == roomID {
+		p.removeFromRoom(conn, roomID)
+	}
 }
 
-// addToRoom adds a connection to a room
-func (p *ConnectionPool) addToRoom(conn *OptimizedConnection) {
-	roomConnections, _ := p.roomConnections.LoadOrStore(*conn.RoomID, &sync.Map{})
-	roomMap := roomConnections.(*sync.Map)
-	roomMap.Store(conn.ID, conn)
+// leaveCurrentRoom takes a connection out of whatever room it is in
+func (p *ConnectionPool) leaveCurrentRoom(conn *WebSocketConnection) {
+	p.roomsMu.Lock()
+	defer p.roomsMu.Unlock()
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.RoomID != nil {
+		p.removeFromRoom(conn, *conn.RoomID)
+	}
 }
 
-// removeFromRoom removes a connection from a room
-func (p *ConnectionPool) removeFromRoom(conn *OptimizedConnection) {
-	if roomConnections, ok := p.roomConnections.Load(*conn.RoomID); ok {
+// removeFromRoom drops a connection from a room and forgets empty rooms
+// (assumes roomsMu and the connection lock are held)
+func (p *ConnectionPool) removeFromRoom(conn *WebSocketConnection, roomID uuid.UUID) {
+	if roomConnections, ok := p.roomConnections.Load(roomID); ok {
 		roomMap := roomConnections.(*sync.Map)
 		roomMap.Delete(conn.ID)
+
+		empty := true
+		roomMap.Range(func(key, value interface{}) bool {
+			empty = false
+			return false
+		})
+		if empty {
+			p.roomConnections.Delete(roomID)
+		}
 	}
+	conn.RoomID = nil
 }
 
-// handleGlobalBroadcast sends a message to all connections
-func (p *ConnectionPool) handleGlobalBroadcast(msg *PooledMessage) {
-	defer ReleaseMessage(msg)
+// Broadcast queues an encoded message for every connection
+func (p *ConnectionPool) Broadcast(ctx context.Context, data []byte) {
+	select {
+	case p.globalBroadcast <- data:
+	case <-ctx.Done():
+	}
+}
 
+// BroadcastToRoom queues an encoded message for every connection in a room
+func (p *ConnectionPool) BroadcastToRoom(ctx context.Context, roomMsg RoomMessage) {
+	select {
+	case p.roomBroadcast <- roomMsg:
+	case <-ctx.Done():
+	}
+}
+
+// handleGlobalBroadcast sends a message to all connections
+func (p *ConnectionPool) handleGlobalBroadcast(data []byte) {
 	p.connections.Range(func(key, value interface{}) bool {
-		conn := value.(*OptimizedConnection)
-		conn.SendMessage(msg)
+		p.Send(value.(*WebSocketConnection), data)
 		return true
 	})
 
@@ -283,24 +341,16 @@ func (p *ConnectionPool) handleGlobalBroadcast(msg *PooledMessage) {
 }
 
 // handleRoomBroadcast sends a message to all connections in a room
-func (p *ConnectionPool) handleRoomBroadcast(roomMsg *RoomMessage) {
-	defer ReleaseMessage(roomMsg.Message)
-
+func (p *ConnectionPool) handleRoomBroadcast(roomMsg RoomMessage) {
 	if roomConnections, ok := p.roomConnections.Load(roomMsg.RoomID); ok {
-		roomMap := roomConnections.(*sync.Map)
-
-		// Create exclude map for faster lookup
-		excludeMap := make(map[uuid.UUID]bool)
-		for _, id := range roomMsg.Exclude {
-			excludeMap[id] = true
-		}
-
-		roomMap.Range(func(key, value interface{}) bool {
+		roomConnections.(*sync.Map).Range(func(key, value interface{}) bool {
 			connID := key.(uuid.UUID)
-			if !excludeMap[connID] {
-				conn := value.(*OptimizedConnection)
-				conn.SendMessage(roomMsg.Message)
+			for _, excluded := range roomMsg.Exclude {
+				if excluded == connID {
+					return true
+				}
 			}
+			p.Send(value.(*WebSocketConnection), roomMsg.Data)
 			return true
 		})
 	}
@@ -308,34 +358,61 @@ func (p *ConnectionPool) handleRoomBroadcast(roomMsg *RoomMessage) {
 	atomic.AddInt64(&p.totalMessages, 1)
 }
 
-// cleanup removes dead connections
-func (p *ConnectionPool) cleanup() {
-	now := time.Now().Unix()
-	timeout := int64(p.config.ReadTimeout.Seconds())
+// Send queues an encoded message for one connection. A connection that cannot
+// keep up is closed; its read pump then unregisters it.
+func (p *ConnectionPool) Send(conn *WebSocketConnection, data []byte) {
+	select {
+	case conn.Send <- data:
+		atomic.AddInt64(&p.sentMessages, 1)
+	case <-conn.Context.Done():
+	default:
+		atomic.AddInt64(&p.failedMessages, 1)
+		conn.close()
+	}
+}
+
+// GetConnection returns the connection of a user
+func (p *ConnectionPool) GetConnection(username string) (*WebSocketConnection, bool) {
+	conn, exists := p.userConnections.Load(username)
+	if !exists {
+		return nil, false
+	}
+	return conn.(*WebSocketConnection), true
+}
+
+// RoomConnections returns the connections in a room
+func (p *ConnectionPool) RoomConnections(roomID uuid.UUID) []*WebSocketConnection {
+	var connections []*WebSocketConnection
+	if roomConnections, ok := p.roomConnections.Load(roomID); ok {
+		roomConnections.(*sync.Map).Range(func(key, value interface{}) bool {
+			connections = append(connections, value.(*WebSocketConnection))
+			return true
+		})
+	}
+	return connections
+}
 
-	var toRemove []*OptimizedConnection
+// cleanup removes connections that stayed silent for longer than the read timeout
+func (p *ConnectionPool) cleanup() {
+	now := time.Now()
 
 	p.connections.Range(func(key, value interface{}) bool {
-		conn := value.(*OptimizedConnection)
-		lastActivity := atomic.LoadInt64(&conn.LastActivity)
+		conn := value.(*WebSocketConnection)
+		conn.mu.RLock()
+		inactive := now.Sub(conn.LastActivity) > p.config.ReadTimeout
+		conn.mu.RUnlock()
 
-		if now-lastActivity > timeout || atomic.LoadInt32(&conn.IsAlive) == 0 {
-			toRemove = append(toRemove, conn)
+		if inactive {
+			p.handleUnregister(conn)
 		}
 		return true
 	})
-
-	// Remove dead connections
-	for _, conn := range toRemove {
-		p.unregister <- conn
-	}
 }
 
-// shutdown gracefully shuts down the connection pool
+// shutdown closes every connection; their write pumps close the sockets
 func (p *ConnectionPool) shutdown() {
 	p.connections.Range(func(key, value interface{}) bool {
-		conn := value.(*OptimizedConnection)
-		conn.Close("Server shutting down")
+		value.(*WebSocketConnection).close()
 		return true
 	})
 }
@@ -350,125 +427,91 @@ func (p *ConnectionPool) GetMetrics() map[string]int64 {
 	return map[string]int64{
 		"active_connections": atomic.LoadInt64(&p.activeConnections),
 		"total_messages":     atomic.LoadInt64(&p.totalMessages),
+		"received_messages":  atomic.LoadInt64(&p.receivedMessages),
+		"sent_messages":      atomic.LoadInt64(&p.sentMessages),
 		"failed_messages":    atomic.LoadInt64(&p.failedMessages),
 	}
 }
 
-// SendMessage sends a message to a specific connection
-func (conn *OptimizedConnection) SendMessage(msg *PooledMessage) {
-	if atomic.LoadInt32(&conn.IsAlive) == 0 {
-		return
-	}
-
-	select {
-	case conn.send <- msg:
-		atomic.StoreInt64(&conn.LastActivity, time.Now().Unix())
-	default:
-		// Channel is full, connection might be slow
-		atomic.StoreInt32(&conn.IsAlive, 0)
-	}
-}
-
-// Close closes the connection gracefully
-func (conn *OptimizedConnection) Close(reason string) {
-	if atomic.SwapInt32(&conn.IsAlive, 0) == 1 {
-		close(conn.done)
-		conn.cancel()
+// writePump writes queued messages to the socket, batching everything queued
+// into one newline separated frame, and keeps the connection alive with pings
+func (p *ConnectionPool) writePump(conn *WebSocketConnection) {
+	ticker := time.NewTicker(p.config.PingPeriod)
+	defer func() {
+		ticker.Stop()
 		conn.Conn.Close()
-	}
-}
-
-// writePump handles writing messages to the WebSocket connection
-func (conn *OptimizedConnection) writePump(pool *ConnectionPool) {
-	defer conn.Conn.Close()
-
-	ticker := time.NewTicker(pool.config.PingPeriod)
-	defer ticker.Stop()
+	}()
 
 	for {
 		select {
-		case msg, ok := <-conn.send:
-			conn.Conn.SetWriteDeadline(time.Now().Add(pool.config.WriteTimeout))
-			if !ok {
-				conn.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+		case <-conn.Context.Done():
+			conn.Conn.WriteControl(websocket.CloseMessage, []byte{}, time.Now().Add(p.config.WriteTimeout))
+			return
+
+		case message := <-conn.Send:
+			conn.Conn.SetWriteDeadline(time.Now().Add(p.config.WriteTimeout))
+			w, err := conn.Conn.NextWriter(websocket.TextMessage)
+			if err != nil {
+				atomic.AddInt64(&p.failedMessages, 1)
 				return
 			}
+			w.Write(message)
 
-			// Get buffer from pool
-			buf := pool.GetBuffer()
-			defer pool.PutBuffer(buf)
+			// Add queued messages to current message
+			n := len(conn.Send)
+			for i := 0; i < n; i++ {
+				w.Write([]byte{'\n'})
+				w.Write(<-conn.Send)
+			}
 
-			if err := conn.Conn.WriteJSON(msg); err != nil {
-				atomic.AddInt64(&pool.failedMessages, 1)
+			if err := w.Close(); err != nil {
+				atomic.AddInt64(&p.failedMessages, int64(n+1))
 				return
 			}
 
-			atomic.AddInt64(&conn.messagesSent, 1)
-
 		case <-ticker.C:
-			conn.Conn.SetWriteDeadline(time.Now().Add(pool.config.WriteTimeout))
+			conn.Conn.SetWriteDeadline(time.Now().Add(p.config.WriteTimeout))
 			if err := conn.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
-
-		case <-conn.done:
-			return
 		}
 	}
 }
 
-// readPump handles reading messages from the WebSocket connection
-func (conn *OptimizedConnection) readPump(pool *ConnectionPool) {
+// readPump reads client messages until the socket fails or the connection
+// closes, then unregisters the connection. Messages are handled in order.
+func (p *ConnectionPool) readPump(conn *WebSocketConnection) {
 	defer func() {
-		pool.unregister <- conn
-		conn.Conn.Close()
+		select {
+		case p.unregister <- conn:
+		case <-p.done:
+		}
 	}()
 
-	conn.Conn.SetReadLimit(pool.config.MaxMessageSize)
-	conn.Conn.SetReadDeadline(time.Now().Add(pool.config.PongTimeout))
+	conn.Conn.SetReadLimit(p.config.MaxMessageSize)
+	conn.Conn.SetReadDeadline(time.Now().Add(p.config.PongTimeout))
 	conn.Conn.SetPongHandler(func(string) error {
-		conn.Conn.SetReadDeadline(time.Now().Add(pool.config.PongTimeout))
-		atomic.StoreInt64(&conn.LastActivity, time.Now().Unix())
+		conn.Conn.SetReadDeadline(time.Now().Add(p.config.PongTimeout))
+		conn.touch()
 		return nil
 	})
 
 	for {
-		select {
-		case <-conn.done:
+		_, data, err := conn.Conn.ReadMessage()
+		if err != nil {
 			return
-		default:
-			msg := GetMessage()
-			err := conn.Conn.ReadJSON(msg)
-			if err != nil {
-				ReleaseMessage(msg)
-				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-					// Log unexpected close
-				}
-				return
-			}
+		}
 
-			atomic.StoreInt64(&conn.LastActivity, time.Now().Unix())
-			atomic.AddInt64(&conn.messagesReceived, 1)
-
-			// Handle message
-			if conn.messageHandler != nil {
-				go func() {
-					defer ReleaseMessage(msg)
-					conn.messageHandler(msg)
-				}()
-			} else {
-				ReleaseMessage(msg)
-			}
+		atomic.AddInt64(&p.receivedMessages, 1)
+		conn.touch()
+		if p.onMessage != nil {
+			p.onMessage(conn, data)
 		}
 	}
 }
 
-// GetBuffer returns a buffer from the pool (reuse pool.GetBuffer)
-func (p *ConnectionPool) GetBuffer() *[]byte {
-	return pool.GetByteSlice()
+// encodeMessage encodes a message once, so a broadcast shares the same bytes
+// across every connection it reaches
+func encodeMessage(message *WebSocketMessage) ([]byte, error) {
+	return json.Marshal(message)
 }
-
-// PutBuffer returns a buffer to the pool (reuse pool.PutByteSlice)
-func (p *ConnectionPool) PutBuffer(buf *[]byte) {
-	pool.PutByteSlice(buf)
-}
\ No newline at end of file
@@ -0,0 +1,113 @@
+// internal/gameserver/connection_pool_test.go
+package gameserver_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameServerConfig_PoolConfig(t *testing.T) {
+	config := gameserver.GetDefaultConfig()
+	config.MaxConnections = 50
+	config.ConnectionTimeout = time.Minute
+	config.MaxMessageSize = 4096
+	config.SendBufferSize = 0
+
+	pool := config.PoolConfig()
+	assert.Equal(t, 50, pool.MaxConnections)
+	assert.Equal(t, time.Minute, pool.ReadTimeout)
+	assert.Equal(t, int64(4096), pool.MaxMessageSize)
+	assert.Equal(t, gameserver.DefaultPoolConfig().SendBufferSize, pool.SendBufferSize)
+}
+
+func TestConnectionPool_RejectsConnectionsOverLimit(t *testing.T) {
+	config := gameserver.GetDefaultConfig()
+	config.MaxConnections = 2
+	gs, tokenSvc, srv := newSocketTestServer(t, config)
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+	require.Eventually(t, func() bool {
+		return gs.GetWebSocketManager().GetActiveConnectionsCount() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	accessToken, err := tokenSvc.CreateAccessToken("carol", "user")
+	require.NoError(t, err)
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+accessToken)
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws/carol", header)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	// A connected user may still replace their own socket
+	alice2 := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice2.Close()
+	assert.Equal(t, 2, gs.GetWebSocketManager().GetActiveConnectionsCount())
+}
+
+func TestConnectionPool_ClosesOversizedMessages(t *testing.T) {
+	config := gameserver.GetDefaultConfig()
+	config.MaxMessageSize = 256
+	gs, tokenSvc, srv := newSocketTestServer(t, config)
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+
+	require.NoError(t, alice.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypePing,
+		"data": map[string]interface{}{"padding": strings.Repeat("x", 512)},
+	}))
+
+	alice.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		if _, _, err := alice.ReadMessage(); err != nil {
+			assert.True(t, websocket.IsCloseError(err, websocket.CloseMessageTooBig), err.Error())
+			break
+		}
+	}
+	require.Eventually(t, func() bool {
+		return gs.GetWebSocketManager().GetActiveConnectionsCount() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestConnectionPool_SlowDisconnectHandlersDoNotStallBroadcasts(t *testing.T) {
+	gs, tokenSvc, srv := newSocketTestServer(t, gameserver.GetDefaultConfig())
+	wsManager := gs.GetWebSocketManager()
+
+	blocked := make(chan string, 1)
+	release := make(chan struct{})
+	defer close(release)
+	wsManager.OnDisconnect(func(conn *gameserver.WebSocketConnection) {
+		blocked <- conn.Username
+		<-release
+	})
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+
+	alice.Close()
+	select {
+	case username := <-blocked:
+		assert.Equal(t, "alice", username)
+	case <-time.After(2 * time.Second):
+		t.Fatal("disconnect handler was not called")
+	}
+
+	// The pool keeps registering and broadcasting while the handler is stuck
+	carol := dialGameSocket(t, srv, tokenSvc, "carol", "/ws/carol")
+	defer carol.Close()
+	wsManager.Broadcast(&gameserver.WebSocketMessage{Type: "announcement", Timestamp: time.Now()})
+	readUntil(t, bob, "announcement")
+	readUntil(t, carol, "announcement")
+}
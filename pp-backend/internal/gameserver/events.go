@@ -542,16 +542,11 @@ func (ep *EventProcessor) handleConnectEvent(event *GameEvent) error {
 }
 
 func (ep *EventProcessor) handleDisconnectEvent(event *GameEvent) error {
-	// TODO: Handle user disconnection events
-	// Could clean up user's room, cancel matchmaking, etc.
+	// Rooms are not left here: the RoomManager keeps the seat for the
+	// reconnect grace window and forfeits the player afterwards
 	if event.Username != "" {
 		// Leave matchmaking if active
 		ep.matchmaking.LeaveMatchmaking(event.Username)
-
-		// Leave room if in one
-		if room, exists := ep.roomManager.GetUserRoom(event.Username); exists {
-			ep.roomManager.LeaveRoom(room.ID, event.Username)
-		}
 	}
 	return nil
 }
@@ -583,33 +578,8 @@ func (ep *EventProcessor) handleGameActionEvent(event *GameEvent) error {
 }
 
 func (ep *EventProcessor) handleGameEndEvent(event *GameEvent) error {
-	// TODO: Calculate final scores, award points, update statistics
-	if event.RoomID != nil {
-		room, exists := ep.roomManager.GetRoom(*event.RoomID)
-		if exists {
-			// Award points based on game results
-			// This is a simplified implementation
-			for username, player := range room.Players {
-				if player.Score > 0 {
-					// Create a simple game result for point calculation
-					result := &minigame.GameResult{
-						SessionID:      uuid.New(),
-						PlayerUsername: username,
-						GameType:       room.GameType,
-						FinalScore:     player.Score,
-						Duration:       time.Since(*room.StartTime),
-						IsValid:        true,
-					}
-
-					// Calculate and award points
-					calculatedResult, err := ep.miniGameEngine.CalculateReward(result)
-					if err == nil && calculatedResult.IsValid {
-						ep.miniGameEngine.AwardPoints(calculatedResult)
-					}
-				}
-			}
-		}
-	}
+	// Rewards are settled by the RoomManager when a room game finishes, so
+	// nothing is awarded here to avoid paying players twice
 	return nil
 }
 
@@ -679,5 +649,6 @@ func (eb *EventBus) Shutdown() {
 		}
 	}
 
-	close(eb.eventQueue)
+	// The event queue stays open: closing it would hand processEvents nil
+	// events and make late publishers panic. The cancelled context stops both.
 }
\ No newline at end of file
@@ -0,0 +1,180 @@
+// internal/gameserver/gametypes.go
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+)
+
+// ErrPlatformNotSupported is returned when a game type cannot be played on the requested platform
+var ErrPlatformNotSupported = errors.New("platform not supported for this game type")
+
+// Platforms a client can connect from
+const (
+	PlatformWeb     = "web"
+	PlatformMobile  = "mobile"
+	PlatformDesktop = "desktop"
+)
+
+var allPlatforms = []string{PlatformWeb, PlatformMobile, PlatformDesktop}
+
+// GameTypeRules describes how players of a game type are matched into rooms
+type GameTypeRules struct {
+	GameType       minigame.GameType `json:"gameType"`
+	MinPlayers     int               `json:"minPlayers"`
+	MaxPlayers     int               `json:"maxPlayers"`
+	OptimalPlayers int               `json:"optimalPlayers"` // Room size to wait for before MatchTimeout
+	MatchTimeout   time.Duration     `json:"matchTimeout"`   // After this long a room of MinPlayers is good enough
+	SkillRange     int               `json:"skillRange"`     // Starting rating difference, widened while waiting
+	CrossPlatform  bool              `json:"crossPlatform"`
+	LateJoin       bool              `json:"lateJoin"` // Players may join, and rooms backfill, while a game is in progress
+	Platforms      []string          `json:"platforms"`
+}
+
+// AllowsPlatform reports whether players on a platform can queue for the game type
+func (r *GameTypeRules) AllowsPlatform(platform string) bool {
+	for _, allowed := range r.Platforms {
+		if allowed == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultGameTypeRules are the built-in matchmaking rules per game type
+var defaultGameTypeRules = map[minigame.GameType]GameTypeRules{
+	minigame.GameTypeClickSpeed:   {MinPlayers: 2, MaxPlayers: 8, OptimalPlayers: 4, MatchTimeout: 10 * time.Second, SkillRange: 100},
+	minigame.GameTypeMemoryMatch:  {MinPlayers: 2, MaxPlayers: 4, OptimalPlayers: 2, MatchTimeout: 20 * time.Second, SkillRange: 150, Platforms: []string{PlatformWeb, PlatformMobile}},
+	minigame.GameTypeNumberGuess:  {MinPlayers: 2, MaxPlayers: 6, OptimalPlayers: 3, MatchTimeout: 20 * time.Second, SkillRange: 150},
+	minigame.GameTypeWordScramble: {MinPlayers: 2, MaxPlayers: 6, OptimalPlayers: 3, MatchTimeout: 30 * time.Second, SkillRange: 200},
+	minigame.GameTypePuzzle:       {MinPlayers: 2, MaxPlayers: 4, OptimalPlayers: 2, MatchTimeout: 30 * time.Second, SkillRange: 200},
+	minigame.GameTypePaintBattle:  {MinPlayers: 2, MaxPlayers: 8, OptimalPlayers: 4, MatchTimeout: 30 * time.Second, SkillRange: 200, LateJoin: true},
+	minigame.GameTypePhysicsJump:  {MinPlayers: 2, MaxPlayers: 6, OptimalPlayers: 3, MatchTimeout: 15 * time.Second, SkillRange: 150, LateJoin: true},
+}
+
+// normalize checks the player range and fills in unset values
+func (r *GameTypeRules) normalize() error {
+	if r.MinPlayers < 2 || r.MaxPlayers < r.MinPlayers || r.MaxPlayers > 8 {
+		return fmt.Errorf("invalid player range %d-%d for %s", r.MinPlayers, r.MaxPlayers, r.GameType)
+	}
+	if r.OptimalPlayers < r.MinPlayers || r.OptimalPlayers > r.MaxPlayers {
+		r.OptimalPlayers = r.MinPlayers
+	}
+	if len(r.Platforms) == 0 {
+		r.Platforms = allPlatforms
+	}
+	return nil
+}
+
+// GameTypeRegistry is the single list of game types that can be matched and played
+type GameTypeRegistry struct {
+	rules map[minigame.GameType]*GameTypeRules
+	mu    sync.RWMutex
+}
+
+// NewGameTypeRegistry registers every game type the engine can run, using the
+// built-in matchmaking rules or generic ones for types without rules
+func NewGameTypeRegistry(miniGameEngine *minigame.MiniGameEngine) *GameTypeRegistry {
+	registry := &GameTypeRegistry{
+		rules: make(map[minigame.GameType]*GameTypeRules),
+	}
+
+	for gameType := range miniGameEngine.ListGameTypes() {
+		rules := builtinGameTypeRules(gameType)
+		registry.rules[gameType] = &rules
+	}
+
+	return registry
+}
+
+// builtinGameTypeRules returns the built-in rules of a game type, or generic
+// ones for types without rules
+func builtinGameTypeRules(gameType minigame.GameType) GameTypeRules {
+	rules, exists := defaultGameTypeRules[gameType]
+	if !exists {
+		rules = GameTypeRules{MinPlayers: 2, MaxPlayers: 4, OptimalPlayers: 2, MatchTimeout: 30 * time.Second, SkillRange: 200}
+	}
+	rules.GameType = gameType
+	rules.CrossPlatform = true
+	if rules.Platforms == nil {
+		rules.Platforms = allPlatforms
+	}
+	return rules
+}
+
+// Register adds or replaces the rules for a game type
+func (r *GameTypeRegistry) Register(rules GameTypeRules) error {
+	return r.RegisterAll([]GameTypeRules{rules})
+}
+
+// RegisterAll adds or replaces the rules for several game types at once. Nothing
+// is registered when any of the rules is invalid.
+func (r *GameTypeRegistry) RegisterAll(list []GameTypeRules) error {
+	for i := range list {
+		if err := list[i].normalize(); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rules := range list {
+		rules := rules
+		r.rules[rules.GameType] = &rules
+	}
+	return nil
+}
+
+// Get returns a copy of the rules for a game type
+func (r *GameTypeRegistry) Get(gameType minigame.GameType) (*GameTypeRules, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules, exists := r.rules[gameType]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedGameType, gameType)
+	}
+	rulesCopy := *rules
+	return &rulesCopy, nil
+}
+
+// AllowsLateJoin reports whether players may join a game of this type in progress
+func (r *GameTypeRegistry) AllowsLateJoin(gameType minigame.GameType) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules, exists := r.rules[gameType]
+	return exists && rules.LateJoin
+}
+
+// GameTypes returns the registered game types in a stable order
+func (r *GameTypeRegistry) GameTypes() []minigame.GameType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	gameTypes := make([]minigame.GameType, 0, len(r.rules))
+	for gameType := range r.rules {
+		gameTypes = append(gameTypes, gameType)
+	}
+	sort.Slice(gameTypes, func(i, j int) bool { return gameTypes[i] < gameTypes[j] })
+	return gameTypes
+}
+
+// List returns copies of all registered rules keyed by game type
+func (r *GameTypeRegistry) List() map[minigame.GameType]*GameTypeRules {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make(map[minigame.GameType]*GameTypeRules, len(r.rules))
+	for gameType, rules := range r.rules {
+		rulesCopy := *rules
+		list[gameType] = &rulesCopy
+	}
+	return list
+}
@@ -0,0 +1,393 @@
+// internal/gameserver/host.go
+package gameserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Host control errors returned by RoomManager
+var (
+	ErrBannedFromRoom      = errors.New("banned from this room")
+	ErrInvalidRoomSettings = errors.New("invalid room settings")
+)
+
+const maxRoomNameLength = 50
+
+// RoomEventPlayerKicked is emitted before a kicked player's player_left event
+const RoomEventPlayerKicked = "player_kicked"
+
+// MessageTypeRoomHost is sent by hosts to manage their room:
+// {action: kick|transfer|settings, username, settings}
+const MessageTypeRoomHost = "room_host"
+
+// RoomSettingsUpdate changes a waiting room's settings. Nil fields stay as they are.
+type RoomSettingsUpdate struct {
+	Name          *string `json:"name,omitempty"`
+	MaxPlayers    *int    `json:"maxPlayers,omitempty"`
+	MinPlayers    *int    `json:"minPlayers,omitempty"`
+	IsPrivate     *bool   `json:"isPrivate,omitempty"`
+	Password      *string `json:"password,omitempty"` // Empty removes the password
+	AllowBots     *bool   `json:"allowBots,omitempty"`
+	BotDifficulty *string `json:"botDifficulty,omitempty"`
+}
+
+// KickPlayer removes a player from the lobby and bans them from rejoining the room
+func (rm *RoomManager) KickPlayer(roomID uuid.UUID, host, username string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	room, exists := rm.rooms[roomID]
+	if !exists {
+		return ErrRoomNotFound
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.HostUsername != host {
+		return ErrNotHost
+	}
+	if room.State != RoomStateWaiting && room.State != RoomStateReady {
+		return fmt.Errorf("%w: players can only be kicked before the game starts", ErrInvalidRoomState)
+	}
+	if username == host {
+		return fmt.Errorf("%w: the host cannot kick themselves", ErrInvalidRoomState)
+	}
+	if _, exists := room.Players[username]; !exists {
+		return ErrPlayerNotInRoom
+	}
+
+	room.bannedUsers[username] = true
+	for inviteID, invite := range rm.invites {
+		if invite.RoomID == roomID && invite.To == username {
+			delete(rm.invites, inviteID)
+		}
+	}
+
+	room.emitEvent(&GameRoomEvent{
+		Type:      RoomEventPlayerKicked,
+		RoomID:    roomID,
+		Username:  username,
+		Data:      map[string]interface{}{"username": username, "by": host},
+		Timestamp: time.Now(),
+	})
+	if err := rm.removePlayer(room, username); err != nil {
+		return err
+	}
+
+	// The kicked player no longer gets room broadcasts
+	if rm.wsManager != nil {
+		rm.wsManager.SendToUser(username, &WebSocketMessage{
+			Type:      MessageTypeRoomLeft,
+			Data:      map[string]interface{}{"roomId": roomID, "reason": "kicked", "by": host},
+			Timestamp: time.Now(),
+			RoomID:    &roomID,
+		})
+	}
+	return nil
+}
+
+// TransferHost hands the room over to another player
+func (rm *RoomManager) TransferHost(roomID uuid.UUID, host, newHost string) error {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	room, exists := rm.rooms[roomID]
+	if !exists {
+		return ErrRoomNotFound
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.HostUsername != host {
+		return ErrNotHost
+	}
+	if room.State == RoomStateClosed {
+		return fmt.Errorf("%w: room is closed", ErrInvalidRoomState)
+	}
+	if newHost == host {
+		return fmt.Errorf("%w: %s already hosts the room", ErrInvalidRoomState, host)
+	}
+	next, exists := room.Players[newHost]
+	if !exists {
+		return ErrPlayerNotInRoom
+	}
+	if next.IsBot {
+		return fmt.Errorf("%w: bots cannot host", ErrInvalidRoomState)
+	}
+
+	if current, exists := room.Players[host]; exists {
+		current.IsHost = false
+	}
+	next.IsHost = true
+	room.HostUsername = newHost
+	room.LastActivity = time.Now()
+
+	room.emitEvent(&GameRoomEvent{
+		Type:      RoomEventHostChanged,
+		RoomID:    roomID,
+		Username:  newHost,
+		Data:      map[string]interface{}{"newHost": newHost, "previousHost": host},
+		Timestamp: time.Now(),
+	})
+	return nil
+}
+
+// UpdateRoomSettings changes the name, player limits, privacy or password of
+// a room that is still waiting for players
+func (rm *RoomManager) UpdateRoomSettings(roomID uuid.UUID, host string, update *RoomSettingsUpdate) (*GameRoom, error) {
+	if update == nil {
+		return nil, fmt.Errorf("%w: nothing to change", ErrInvalidRoomSettings)
+	}
+
+	// Hash the password before taking the manager lock, bcrypt is slow on purpose
+	var passwordHash []byte
+	if update.Password != nil {
+		hash, err := hashRoomPassword(*update.Password)
+		if err != nil {
+			return nil, err
+		}
+		passwordHash = hash
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	room, exists := rm.rooms[roomID]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.HostUsername != host {
+		return nil, ErrNotHost
+	}
+	if room.State != RoomStateWaiting {
+		return nil, fmt.Errorf("%w: settings can only change while waiting for players", ErrInvalidRoomState)
+	}
+
+	name, maxPlayers, minPlayers, isPrivate := room.Name, room.MaxPlayers, room.MinPlayers, room.IsPrivate
+	if update.Name != nil {
+		name = strings.TrimSpace(*update.Name)
+		if name == "" || len([]rune(name)) > maxRoomNameLength {
+			return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidRoomSettings, maxRoomNameLength)
+		}
+	}
+	if update.MaxPlayers != nil {
+		maxPlayers = *update.MaxPlayers
+	}
+	if update.MinPlayers != nil {
+		minPlayers = *update.MinPlayers
+	}
+	if update.IsPrivate != nil {
+		isPrivate = *update.IsPrivate
+	}
+	allowBots, botDifficulty := room.AllowBots, room.BotDifficulty
+	if update.AllowBots != nil {
+		allowBots = *update.AllowBots
+	}
+	if update.BotDifficulty != nil {
+		difficulty, err := validateBotDifficulty(*update.BotDifficulty)
+		if err != nil {
+			return nil, err
+		}
+		botDifficulty = difficulty
+	}
+	if maxPlayers < 2 || maxPlayers > 8 {
+		return nil, fmt.Errorf("%w: maxPlayers must be between 2 and 8", ErrInvalidRoomSettings)
+	}
+	if maxPlayers < len(room.Players) {
+		return nil, fmt.Errorf("%w: %d players are already in the room", ErrInvalidRoomSettings, len(room.Players))
+	}
+	if minPlayers < 2 || minPlayers > maxPlayers {
+		return nil, fmt.Errorf("%w: minPlayers must be between 2 and maxPlayers", ErrInvalidRoomSettings)
+	}
+
+	room.Name, room.MaxPlayers, room.MinPlayers = name, maxPlayers, minPlayers
+	if update.Password != nil {
+		room.passwordHash = passwordHash
+	}
+	if isPrivate != room.IsPrivate {
+		room.IsPrivate = isPrivate
+		rm.setPublic(roomID, !isPrivate)
+	}
+	room.AllowBots, room.BotDifficulty = allowBots, botDifficulty
+	for _, username := range room.botNames() {
+		room.Players[username].BotDifficulty = botDifficulty
+	}
+	if !allowBots {
+		rm.removeBots(room)
+	}
+	if room.Settings == nil {
+		room.Settings = make(map[string]interface{})
+	}
+	room.Settings["name"] = name
+	room.Settings["maxPlayers"] = float64(maxPlayers)
+	room.Settings["minPlayers"] = float64(minPlayers)
+	room.Settings["isPrivate"] = isPrivate
+	room.Settings["allowBots"] = allowBots
+	room.Settings["botDifficulty"] = string(botDifficulty)
+	room.LastActivity = time.Now()
+
+	room.emitEvent(&GameRoomEvent{
+		Type:     RoomEventSettingsChanged,
+		RoomID:   roomID,
+		Username: host,
+		Data: map[string]interface{}{
+			"name":          name,
+			"maxPlayers":    maxPlayers,
+			"minPlayers":    minPlayers,
+			"isPrivate":     isPrivate,
+			"hasPassword":   len(room.passwordHash) > 0,
+			"allowBots":     allowBots,
+			"botDifficulty": botDifficulty,
+		},
+		Timestamp: time.Now(),
+	})
+
+	// A public room with free seats asks matchmaking to fill them
+	if !isPrivate && len(room.Players) < maxPlayers && rm.onSlotOpened != nil {
+		go rm.onSlotOpened(roomID, room.GameType)
+	}
+
+	return room, nil
+}
+
+// setPublic lists or unlists a room (assumes manager lock is held)
+func (rm *RoomManager) setPublic(roomID uuid.UUID, public bool) {
+	for i, id := range rm.publicRooms {
+		if id == roomID {
+			if !public {
+				rm.publicRooms = append(rm.publicRooms[:i], rm.publicRooms[i+1:]...)
+			}
+			return
+		}
+	}
+	if public {
+		rm.publicRooms = append(rm.publicRooms, roomID)
+	}
+}
+
+// handleRoomHostMessage runs host controls sent over the game socket
+func (gs *GameServer) handleRoomHostMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	room, exists := gs.roomManager.GetUserRoom(conn.Username)
+	if !exists {
+		return ErrPlayerNotInRoom
+	}
+
+	action, _ := message.Data["action"].(string)
+	target, _ := message.Data["username"].(string)
+
+	var err error
+	switch action {
+	case "kick":
+		err = gs.roomManager.KickPlayer(room.ID, conn.Username, target)
+	case "transfer":
+		err = gs.roomManager.TransferHost(room.ID, conn.Username, target)
+	case "settings":
+		var update RoomSettingsUpdate
+		if err := remarshal(message.Data["settings"], &update); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRoomSettings, err)
+		}
+		_, err = gs.roomManager.UpdateRoomSettings(room.ID, conn.Username, &update)
+	default:
+		return fmt.Errorf("%w: unknown host action %s", ErrInvalidRoomSettings, action)
+	}
+	if err != nil {
+		return err
+	}
+
+	gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+		Type:      MessageTypeRoomHost,
+		Data:      map[string]interface{}{"action": action, "room": room.GetRoomStats()},
+		Timestamp: time.Now(),
+		RoomID:    &room.ID,
+	})
+	return nil
+}
+
+// remarshal decodes loosely typed message data into a struct
+func remarshal(data interface{}, v interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, v)
+}
+
+func (gs *GameServer) handleKickPlayer(w http.ResponseWriter, r *http.Request) {
+	gs.handleHostTarget(w, r, gs.roomManager.KickPlayer)
+}
+
+func (gs *GameServer) handleTransferHost(w http.ResponseWriter, r *http.Request) {
+	gs.handleHostTarget(w, r, gs.roomManager.TransferHost)
+}
+
+// handleHostTarget runs a host control aimed at the player named in the body
+func (gs *GameServer) handleHostTarget(w http.ResponseWriter, r *http.Request, control func(roomID uuid.UUID, host, username string) error) {
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Username == "" {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "username is required")
+		return
+	}
+
+	if err := control(roomID, username, req.Username); err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	room, exists := gs.roomManager.GetRoom(roomID)
+	if !exists {
+		gs.writeRoomError(w, ErrRoomNotFound)
+		return
+	}
+	gs.writeJSONResponse(w, room.GetRoomStats())
+}
+
+func (gs *GameServer) handleUpdateRoom(w http.ResponseWriter, r *http.Request) {
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	var update RoomSettingsUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	room, err := gs.roomManager.UpdateRoomSettings(roomID, username, &update)
+	if err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	gs.writeJSONResponse(w, room.GetRoomStats())
+}
@@ -0,0 +1,122 @@
+// internal/gameserver/host_test.go
+package gameserver_test
+
+import (
+	"testing"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomHost_KickBansFromRoom(t *testing.T) {
+	gs, tokenSvc, srv := newPartyTestServer(t)
+	rm := gs.GetRoomManager()
+
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+
+	room, err := rm.CreateRoom("alice", minigame.GameTypeClickSpeed, map[string]interface{}{
+		"maxPlayers": float64(3),
+	})
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "bob", "")
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "carol", "")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, rm.KickPlayer(room.ID, "carol", "bob"), gameserver.ErrNotHost)
+	assert.ErrorIs(t, rm.KickPlayer(room.ID, "alice", "alice"), gameserver.ErrInvalidRoomState)
+	assert.ErrorIs(t, rm.KickPlayer(room.ID, "alice", "dave"), gameserver.ErrPlayerNotInRoom)
+
+	require.NoError(t, rm.KickPlayer(room.ID, "alice", "bob"))
+	left := readUntil(t, bob, gameserver.MessageTypeRoomLeft)
+	assert.Equal(t, "kicked", left.Data["reason"])
+	assert.Equal(t, "alice", left.Data["by"])
+
+	_, inRoom := rm.GetUserRoom("bob")
+	assert.False(t, inRoom)
+
+	_, err = rm.JoinRoom(room.ID, "bob", "")
+	assert.ErrorIs(t, err, gameserver.ErrBannedFromRoom)
+	_, err = rm.JoinRoomByCode(room.JoinCode, "bob")
+	assert.ErrorIs(t, err, gameserver.ErrBannedFromRoom)
+	_, err = rm.SpectateRoom(room.ID, "bob", "")
+	assert.ErrorIs(t, err, gameserver.ErrBannedFromRoom)
+
+	invite, err := rm.InviteToRoom(room.ID, "alice", "bob")
+	require.NoError(t, err)
+	_, err = rm.AcceptRoomInvite("bob", invite.ID)
+	assert.ErrorIs(t, err, gameserver.ErrBannedFromRoom)
+}
+
+func TestRoomHost_TransferHost(t *testing.T) {
+	rm := newTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "guest", "")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, rm.TransferHost(room.ID, "guest", "guest"), gameserver.ErrNotHost)
+	assert.ErrorIs(t, rm.TransferHost(room.ID, "host", "nobody"), gameserver.ErrPlayerNotInRoom)
+
+	require.NoError(t, rm.TransferHost(room.ID, "host", "guest"))
+	players := room.GetRoomStats()["players"].(map[string]interface{})
+	assert.Equal(t, true, players["guest"].(map[string]interface{})["isHost"])
+	assert.Equal(t, false, players["host"].(map[string]interface{})["isHost"])
+
+	assert.ErrorIs(t, rm.KickPlayer(room.ID, "host", "guest"), gameserver.ErrNotHost)
+	require.NoError(t, rm.KickPlayer(room.ID, "guest", "host"))
+}
+
+func TestRoomHost_UpdateSettings(t *testing.T) {
+	gs, tokenSvc, srv := newPartyTestServer(t)
+	rm := gs.GetRoomManager()
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+
+	room, err := rm.CreateRoom("alice", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, bob.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeJoinRoom,
+		"data": map[string]interface{}{"roomId": room.ID.String()},
+	}))
+	readUntil(t, bob, gameserver.MessageTypeRoomJoined)
+
+	name, tooMany, tooFew := " ", 9, 1
+	_, err = rm.UpdateRoomSettings(room.ID, "alice", &gameserver.RoomSettingsUpdate{Name: &name})
+	assert.ErrorIs(t, err, gameserver.ErrInvalidRoomSettings)
+	_, err = rm.UpdateRoomSettings(room.ID, "alice", &gameserver.RoomSettingsUpdate{MaxPlayers: &tooMany})
+	assert.ErrorIs(t, err, gameserver.ErrInvalidRoomSettings)
+	_, err = rm.UpdateRoomSettings(room.ID, "alice", &gameserver.RoomSettingsUpdate{MinPlayers: &tooFew})
+	assert.ErrorIs(t, err, gameserver.ErrInvalidRoomSettings)
+	_, err = rm.UpdateRoomSettings(room.ID, "bob", &gameserver.RoomSettingsUpdate{})
+	assert.ErrorIs(t, err, gameserver.ErrNotHost)
+
+	require.NoError(t, alice.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeRoomHost,
+		"data": map[string]interface{}{
+			"action":   "settings",
+			"settings": map[string]interface{}{"name": "Late night", "maxPlayers": 4, "isPrivate": true, "password": "pw"},
+		},
+	}))
+	changed := readUntil(t, bob, gameserver.RoomEventSettingsChanged)
+	assert.Equal(t, "Late night", changed.Data["name"])
+	assert.Equal(t, float64(4), changed.Data["maxPlayers"])
+	assert.Equal(t, true, changed.Data["isPrivate"])
+	assert.Equal(t, true, changed.Data["hasPassword"])
+	readUntil(t, alice, gameserver.MessageTypeRoomHost)
+
+	assert.Equal(t, 4, room.MaxPlayers)
+	for _, public := range rm.ListPublicRooms() {
+		assert.NotEqual(t, room.ID, public.ID)
+	}
+	_, err = rm.JoinRoom(room.ID, "carol", "")
+	assert.ErrorIs(t, err, gameserver.ErrIncorrectPassword)
+}
@@ -0,0 +1,502 @@
+// internal/gameserver/invite.go
+package gameserver
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Invite errors returned by RoomManager
+var (
+	ErrJoinCodeNotFound    = errors.New("join code not found")
+	ErrRoomInviteNotFound  = errors.New("room invite not found or expired")
+	ErrInvalidRoomPassword = errors.New("room password is too long")
+)
+
+const (
+	joinCodeLength        = 6
+	joinCodeAlphabet      = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // No 0/O or 1/I lookalikes
+	roomInviteTTL         = 10 * time.Minute
+	maxRoomPasswordLength = 72 // bcrypt ignores anything longer
+)
+
+// Invite message types
+const (
+	MessageTypeInvite           = "invite"             // client -> server: {action: send|accept|decline|list, username, inviteId}
+	MessageTypeRoomInvite       = "room_invite"        // server -> client: an invite from a friend
+	MessageTypeRoomInviteUpdate = "room_invite_update" // server -> client: an invite you sent was declined
+)
+
+// InviteMessenger delivers invites as direct chat messages
+type InviteMessenger interface {
+	SendMessage(senderUsername, receiverUsername, content string) (*repository.Message, error)
+}
+
+// RoomInvite lets one friend take a seat in the inviter's room once, even
+// when the room is private
+type RoomInvite struct {
+	ID        uuid.UUID         `json:"id"`
+	RoomID    uuid.UUID         `json:"roomId"`
+	RoomName  string            `json:"roomName"`
+	GameType  minigame.GameType `json:"gameType"`
+	From      string            `json:"from"`
+	To        string            `json:"to"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+// hashRoomPassword hashes a room password, returning nil for rooms without one
+func hashRoomPassword(password string) ([]byte, error) {
+	if password == "" {
+		return nil, nil
+	}
+	if len(password) > maxRoomPasswordLength {
+		return nil, fmt.Errorf("%w: at most %d bytes", ErrInvalidRoomPassword, maxRoomPasswordLength)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash room password: %w", err)
+	}
+	return hash, nil
+}
+
+// withoutPassword copies room settings without the plaintext password
+func withoutPassword(settings map[string]interface{}) map[string]interface{} {
+	if settings == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		if key != "password" {
+			copied[key] = value
+		}
+	}
+	return copied
+}
+
+// checkRoomPassword reports whether the password opens the room. It runs
+// without the manager lock since comparing bcrypt hashes is slow.
+func (rm *RoomManager) checkRoomPassword(roomID uuid.UUID, password string) bool {
+	room, exists := rm.GetRoom(roomID)
+	if !exists {
+		return false
+	}
+
+	room.mu.RLock()
+	hash := room.passwordHash
+	room.mu.RUnlock()
+
+	if len(hash) == 0 {
+		return true
+	}
+	return bcrypt.CompareHashAndPassword(hash, []byte(password)) == nil
+}
+
+// newJoinCode picks a join code no open room uses (assumes manager lock is held)
+func (rm *RoomManager) newJoinCode() string {
+	code := make([]byte, joinCodeLength)
+	for {
+		rand.Read(code)
+		for i, b := range code {
+			code[i] = joinCodeAlphabet[int(b)%len(joinCodeAlphabet)]
+		}
+		if _, taken := rm.joinCodes[string(code)]; !taken {
+			return string(code)
+		}
+	}
+}
+
+// normalizeJoinCode accepts codes typed in any case and with surrounding spaces
+func normalizeJoinCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// JoinRoomByCode seats a player in the room a join code belongs to. The code
+// stands in for the password of private rooms.
+func (rm *RoomManager) JoinRoomByCode(code, username string) (*GameRoom, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	roomID, exists := rm.joinCodes[normalizeJoinCode(code)]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrJoinCodeNotFound, code)
+	}
+	return rm.joinRoom(roomID, username, true)
+}
+
+// InviteToRoom invites one of the inviter's friends to the room the inviter
+// plays in. The invite goes out over the friend's game socket, or as a chat
+// message when the friend is not connected.
+func (rm *RoomManager) InviteToRoom(roomID uuid.UUID, from, to string) (*RoomInvite, error) {
+	if err := rm.checkFriends(from, to); err != nil {
+		return nil, err
+	}
+
+	invite, err := rm.createInvite(roomID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rm.deliverInvite(invite); err != nil {
+		rm.mu.Lock()
+		delete(rm.invites, invite.ID)
+		rm.mu.Unlock()
+		return nil, err
+	}
+	return invite, nil
+}
+
+// checkFriends makes sure the invitee is on the inviter's friend list
+func (rm *RoomManager) checkFriends(from, to string) error {
+	if rm.friends == nil {
+		return fmt.Errorf("%w: friend list unavailable", ErrNotFriends)
+	}
+	friends, err := rm.friends.ListFriends(from)
+	if err != nil {
+		return fmt.Errorf("failed to list friends of %s: %w", from, err)
+	}
+	for _, user := range friends {
+		if user.Username == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNotFriends, to)
+}
+
+// createInvite records a pending invite, replacing an earlier one to the same
+// room and friend
+func (rm *RoomManager) createInvite(roomID uuid.UUID, from, to string) (*RoomInvite, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	room, exists := rm.rooms[roomID]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+	if current, seated := rm.userRooms[from]; !seated || current != roomID {
+		return nil, ErrPlayerNotInRoom
+	}
+	if current, seated := rm.userRooms[to]; seated && current == roomID {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInRoom, to)
+	}
+
+	room.mu.RLock()
+	acceptsPlayers := rm.acceptsPlayers(room)
+	name, gameType := room.Name, room.GameType
+	room.mu.RUnlock()
+	if !acceptsPlayers {
+		return nil, fmt.Errorf("%w: room is not accepting new players", ErrInvalidRoomState)
+	}
+
+	rm.expireInvites()
+	for inviteID, invite := range rm.invites {
+		if invite.RoomID == roomID && invite.To == to {
+			delete(rm.invites, inviteID)
+		}
+	}
+
+	now := time.Now()
+	invite := &RoomInvite{
+		ID:        uuid.New(),
+		RoomID:    roomID,
+		RoomName:  name,
+		GameType:  gameType,
+		From:      from,
+		To:        to,
+		ExpiresAt: now.Add(roomInviteTTL),
+		CreatedAt: now,
+	}
+	rm.invites[invite.ID] = invite
+
+	copied := *invite
+	return &copied, nil
+}
+
+// deliverInvite sends an invite over the game socket, falling back to chat
+// for friends without one
+func (rm *RoomManager) deliverInvite(invite *RoomInvite) error {
+	connected, viaBackplane := false, false
+	if rm.wsManager != nil {
+		_, connected = rm.wsManager.GetConnection(invite.To)
+		viaBackplane = rm.wsManager.backplane != nil
+	}
+
+	switch {
+	case connected || (viaBackplane && rm.inviteChat == nil):
+		return rm.wsManager.SendToUser(invite.To, &WebSocketMessage{
+			Type:      MessageTypeRoomInvite,
+			Data:      map[string]interface{}{"invite": invite},
+			Timestamp: time.Now(),
+		})
+
+	case rm.inviteChat != nil:
+		content := fmt.Sprintf("%s invited you to play %s in %q. Accept invite %s before %s.",
+			invite.From, invite.GameType, invite.RoomName, invite.ID, invite.ExpiresAt.Format(time.RFC3339))
+		if _, err := rm.inviteChat.SendMessage(invite.From, invite.To, content); err != nil {
+			return fmt.Errorf("failed to send invite to %s: %w", invite.To, err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %s", ErrNotConnected, invite.To)
+	}
+}
+
+// AcceptRoomInvite seats the invitee in the invite's room. The invite is used
+// up once the seat is taken.
+func (rm *RoomManager) AcceptRoomInvite(username string, inviteID uuid.UUID) (*GameRoom, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	invite, err := rm.pendingInvite(username, inviteID)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := rm.joinRoom(invite.RoomID, username, true)
+	if err != nil {
+		return nil, err
+	}
+	delete(rm.invites, inviteID)
+	return room, nil
+}
+
+// DeclineRoomInvite turns down an invite and tells the inviter
+func (rm *RoomManager) DeclineRoomInvite(username string, inviteID uuid.UUID) error {
+	rm.mu.Lock()
+	invite, err := rm.pendingInvite(username, inviteID)
+	if err == nil {
+		delete(rm.invites, inviteID)
+	}
+	rm.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if rm.wsManager != nil {
+		rm.wsManager.SendToUser(invite.From, &WebSocketMessage{
+			Type: MessageTypeRoomInviteUpdate,
+			Data: map[string]interface{}{
+				"inviteId": invite.ID.String(),
+				"roomId":   invite.RoomID.String(),
+				"username": username,
+				"event":    "declined",
+			},
+			Timestamp: time.Now(),
+		})
+	}
+	return nil
+}
+
+// ListRoomInvites returns the user's pending invites, oldest first
+func (rm *RoomManager) ListRoomInvites(username string) []*RoomInvite {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.expireInvites()
+	invites := make([]*RoomInvite, 0)
+	for _, invite := range rm.invites {
+		if invite.To == username {
+			copied := *invite
+			invites = append(invites, &copied)
+		}
+	}
+	sort.Slice(invites, func(i, j int) bool {
+		return invites[i].CreatedAt.Before(invites[j].CreatedAt)
+	})
+	return invites
+}
+
+// pendingInvite returns an unexpired invite addressed to the user (assumes manager lock is held)
+func (rm *RoomManager) pendingInvite(username string, inviteID uuid.UUID) (*RoomInvite, error) {
+	invite, exists := rm.invites[inviteID]
+	if !exists || invite.To != username {
+		return nil, fmt.Errorf("%w: %s", ErrRoomInviteNotFound, inviteID)
+	}
+	if time.Now().After(invite.ExpiresAt) {
+		delete(rm.invites, inviteID)
+		return nil, fmt.Errorf("%w: %s", ErrRoomInviteNotFound, inviteID)
+	}
+	return invite, nil
+}
+
+// expireInvites drops invites that were not answered in time (assumes manager lock is held)
+func (rm *RoomManager) expireInvites() {
+	now := time.Now()
+	for inviteID, invite := range rm.invites {
+		if now.After(invite.ExpiresAt) {
+			delete(rm.invites, inviteID)
+		}
+	}
+}
+
+// handleInviteMessage sends and answers room invites over the game socket
+func (gs *GameServer) handleInviteMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	action, _ := message.Data["action"].(string)
+	target, _ := message.Data["username"].(string)
+	inviteID, _ := uuid.Parse(fmt.Sprint(message.Data["inviteId"]))
+
+	var data map[string]interface{}
+	switch action {
+	case "send":
+		room, exists := gs.roomManager.GetUserRoom(conn.Username)
+		if !exists {
+			return ErrPlayerNotInRoom
+		}
+		invite, err := gs.roomManager.InviteToRoom(room.ID, conn.Username, target)
+		if err != nil {
+			return err
+		}
+		data = map[string]interface{}{"invite": invite}
+
+	case "accept":
+		room, err := gs.roomManager.AcceptRoomInvite(conn.Username, inviteID)
+		if err != nil {
+			return err
+		}
+		stats := gs.playerRoomStats(room, conn.Username)
+		stats["role"] = "player"
+		gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+			Type:      MessageTypeRoomJoined,
+			Data:      stats,
+			Timestamp: time.Now(),
+			RoomID:    &room.ID,
+		})
+		return nil
+
+	case "decline":
+		return gs.roomManager.DeclineRoomInvite(conn.Username, inviteID)
+
+	case "", "list":
+		data = map[string]interface{}{"invites": gs.roomManager.ListRoomInvites(conn.Username)}
+
+	default:
+		return fmt.Errorf("%w: unknown invite action %s", ErrInvalidMatchmakingRequest, action)
+	}
+
+	gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+		Type:      MessageTypeInvite,
+		Data:      data,
+		Timestamp: time.Now(),
+	})
+	return nil
+}
+
+func (gs *GameServer) handleJoinRoomByCode(w http.ResponseWriter, r *http.Request) {
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	room, err := gs.roomManager.JoinRoomByCode(mux.Vars(r)["code"], username)
+	if err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	stats := gs.playerRoomStats(room, username)
+	stats["role"] = "player"
+	gs.writeJSONResponse(w, stats)
+}
+
+func (gs *GameServer) handleCreateInvite(w http.ResponseWriter, r *http.Request) {
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Username == "" {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "username is required")
+		return
+	}
+
+	invite, err := gs.roomManager.InviteToRoom(roomID, username, req.Username)
+	if err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	gs.writeJSONWithStatus(w, http.StatusCreated, invite)
+}
+
+func (gs *GameServer) handleListInvites(w http.ResponseWriter, r *http.Request) {
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	gs.writeJSONResponse(w, map[string]interface{}{
+		"invites": gs.roomManager.ListRoomInvites(username),
+	})
+}
+
+func (gs *GameServer) handleAcceptInvite(w http.ResponseWriter, r *http.Request) {
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	inviteID, ok := gs.parseInviteID(w, r)
+	if !ok {
+		return
+	}
+
+	room, err := gs.roomManager.AcceptRoomInvite(username, inviteID)
+	if err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	stats := gs.playerRoomStats(room, username)
+	stats["role"] = "player"
+	gs.writeJSONResponse(w, stats)
+}
+
+func (gs *GameServer) handleDeclineInvite(w http.ResponseWriter, r *http.Request) {
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	inviteID, ok := gs.parseInviteID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := gs.roomManager.DeclineRoomInvite(username, inviteID); err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	gs.writeJSONResponse(w, map[string]interface{}{"inviteId": inviteID})
+}
+
+// parseInviteID reads the inviteId path variable
+func (gs *GameServer) parseInviteID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	inviteID, err := uuid.Parse(mux.Vars(r)["inviteId"])
+	if err != nil {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_INVITE_ID", "Invalid invite ID")
+		return uuid.Nil, false
+	}
+	return inviteID, true
+}
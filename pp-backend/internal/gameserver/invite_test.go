@@ -0,0 +1,151 @@
+// internal/gameserver/invite_test.go
+package gameserver_test
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingChat struct {
+	mu       sync.Mutex
+	messages []*repository.Message
+}
+
+func (c *recordingChat) SendMessage(senderUsername, receiverUsername, content string) (*repository.Message, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	message := &repository.Message{SenderUsername: senderUsername, Content: content}
+	message.ReceiverUsername.String, message.ReceiverUsername.Valid = receiverUsername, true
+	c.messages = append(c.messages, message)
+	return message, nil
+}
+
+func TestRoomManager_PasswordIsHashed(t *testing.T) {
+	rm := newTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, map[string]interface{}{
+		"isPrivate": true,
+		"password":  "secret",
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, room.Settings, "password")
+	encoded, err := json.Marshal(room)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "secret")
+	assert.Equal(t, true, room.GetRoomStats()["hasPassword"])
+
+	_, err = rm.SpectateRoom(room.ID, "watcher", "wrong")
+	assert.ErrorIs(t, err, gameserver.ErrIncorrectPassword)
+	_, err = rm.JoinRoom(room.ID, "guest", "secret")
+	require.NoError(t, err)
+
+	_, err = rm.CreateRoom("other", minigame.GameTypeClickSpeed, map[string]interface{}{
+		"password": strings.Repeat("x", 73),
+	})
+	assert.ErrorIs(t, err, gameserver.ErrInvalidRoomPassword)
+}
+
+func TestRoomManager_JoinByCode(t *testing.T) {
+	rm := newTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, map[string]interface{}{
+		"isPrivate": true,
+		"password":  "secret",
+	})
+	require.NoError(t, err)
+	require.Len(t, room.JoinCode, 6)
+
+	_, err = rm.JoinRoomByCode("NOPE00", "guest")
+	assert.ErrorIs(t, err, gameserver.ErrJoinCodeNotFound)
+
+	joined, err := rm.JoinRoomByCode(" "+strings.ToLower(room.JoinCode)+" ", "guest")
+	require.NoError(t, err)
+	assert.Equal(t, room.ID, joined.ID)
+
+	require.NoError(t, rm.LeaveRoom(room.ID, "guest"))
+	require.NoError(t, rm.LeaveRoom(room.ID, "host"))
+	_, err = rm.JoinRoomByCode(room.JoinCode, "guest")
+	assert.ErrorIs(t, err, gameserver.ErrJoinCodeNotFound)
+}
+
+func TestRoomInvite_SingleUseOverSocket(t *testing.T) {
+	gs, tokenSvc, srv := newPartyTestServer(t)
+	rm := gs.GetRoomManager()
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+
+	room, err := rm.CreateRoom("alice", minigame.GameTypeClickSpeed, map[string]interface{}{
+		"isPrivate":  true,
+		"password":   "secret",
+		"maxPlayers": float64(3),
+	})
+	require.NoError(t, err)
+
+	_, err = rm.InviteToRoom(room.ID, "alice", "carol")
+	assert.ErrorIs(t, err, gameserver.ErrNotFriends)
+
+	require.NoError(t, alice.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeInvite,
+		"data": map[string]interface{}{"action": "send", "username": "bob"},
+	}))
+	received := readUntil(t, bob, gameserver.MessageTypeRoomInvite)
+	invite := received.Data["invite"].(map[string]interface{})
+	assert.Equal(t, "alice", invite["from"])
+	assert.Equal(t, room.ID.String(), invite["roomId"])
+	inviteID := uuid.MustParse(invite["id"].(string))
+	require.Len(t, rm.ListRoomInvites("bob"), 1)
+
+	_, err = rm.AcceptRoomInvite("carol", inviteID)
+	assert.ErrorIs(t, err, gameserver.ErrRoomInviteNotFound)
+
+	require.NoError(t, bob.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeInvite,
+		"data": map[string]interface{}{"action": "accept", "inviteId": inviteID.String()},
+	}))
+	joined := readUntil(t, bob, gameserver.MessageTypeRoomJoined)
+	assert.Equal(t, room.ID.String(), joined.Data["id"])
+	assert.Equal(t, room.JoinCode, joined.Data["joinCode"])
+
+	// The invite is used up
+	require.NoError(t, rm.LeaveRoom(room.ID, "bob"))
+	_, err = rm.AcceptRoomInvite("bob", inviteID)
+	assert.ErrorIs(t, err, gameserver.ErrRoomInviteNotFound)
+	assert.Empty(t, rm.ListRoomInvites("bob"))
+}
+
+func TestRoomInvite_DeliveredOverChatWhenOffline(t *testing.T) {
+	chat := &recordingChat{}
+	gs := gameserver.NewGameServer(nil, minigame.NewMiniGameEngine(nil, nil),
+		gameserver.WithFriends(staticFriends{"alice": {"bob"}}),
+		gameserver.WithInviteChat(chat),
+	)
+	rm := gs.GetRoomManager()
+
+	room, err := rm.CreateRoom("alice", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+
+	invite, err := rm.InviteToRoom(room.ID, "alice", "bob")
+	require.NoError(t, err)
+
+	require.Len(t, chat.messages, 1)
+	assert.Equal(t, "alice", chat.messages[0].SenderUsername)
+	assert.Equal(t, "bob", chat.messages[0].ReceiverUsername.String)
+	assert.Contains(t, chat.messages[0].Content, invite.ID.String())
+
+	require.NoError(t, rm.DeclineRoomInvite("bob", invite.ID))
+	_, err = rm.AcceptRoomInvite("bob", invite.ID)
+	assert.ErrorIs(t, err, gameserver.ErrRoomInviteNotFound)
+}
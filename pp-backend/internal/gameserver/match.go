@@ -0,0 +1,60 @@
+// internal/gameserver/match.go
+package gameserver
+
+import (
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+)
+
+// MatchStore keeps the history of finished room games the server statistics are computed from
+type MatchStore interface {
+	RecordMatch(result *RoomResult) error
+	MatchStats() (*repository.MatchStats, error)
+}
+
+// repositoryMatchStore stores matches in the matches table
+type repositoryMatchStore struct {
+	repo repository.MatchRepository
+}
+
+// NewMatchStore creates a match store backed by a match repository
+func NewMatchStore(repo repository.MatchRepository) MatchStore {
+	return &repositoryMatchStore{repo: repo}
+}
+
+// RecordMatch writes a finished room game and its human participants to the repository
+func (s *repositoryMatchStore) RecordMatch(result *RoomResult) error {
+	match := &repository.Match{
+		RoomID:       result.RoomID,
+		SessionID:    result.SessionID,
+		GameType:     string(result.GameType),
+		EndReason:    result.EndReason,
+		StartedAt:    result.StartTime,
+		EndedAt:      result.EndTime,
+		DurationMs:   result.Duration().Milliseconds(),
+		Participants: make([]*repository.MatchParticipant, 0, len(result.Players)),
+	}
+	for _, player := range result.Players {
+		// Bots get a new name every game and would inflate the players served
+		if player.IsBot {
+			continue
+		}
+		match.Participants = append(match.Participants, &repository.MatchParticipant{
+			Username:     player.Username,
+			Placement:    player.Placement,
+			Score:        player.Score,
+			PointsEarned: player.PointsEarned,
+			IsValid:      player.IsValid,
+		})
+	}
+	if len(match.Participants) == 0 {
+		return nil
+	}
+	match.PlayerCount = len(match.Participants)
+
+	return s.repo.CreateMatch(match)
+}
+
+// MatchStats aggregates the recorded matches
+func (s *repositoryMatchStore) MatchStats() (*repository.MatchStats, error) {
+	return s.repo.GetMatchStats()
+}
@@ -0,0 +1,125 @@
+// internal/gameserver/match_test.go
+package gameserver_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryMatchRepo struct {
+	mu         sync.Mutex
+	matches    []*repository.Match
+	statsCalls int
+}
+
+func (r *memoryMatchRepo) CreateMatch(match *repository.Match) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.matches = append(r.matches, match)
+	return nil
+}
+
+func (r *memoryMatchRepo) ListUserMatches(username string, limit, offset int) ([]*repository.Match, error) {
+	return nil, nil
+}
+
+func (r *memoryMatchRepo) GetMatchStats() (*repository.MatchStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statsCalls++
+
+	stats := &repository.MatchStats{GameTypes: make(map[string]int)}
+	players := make(map[string]bool)
+	var total time.Duration
+	for _, match := range r.matches {
+		stats.TotalMatches++
+		stats.GameTypes[match.GameType]++
+		total += time.Duration(match.DurationMs) * time.Millisecond
+		for _, participant := range match.Participants {
+			players[participant.Username] = true
+		}
+	}
+	stats.TotalPlayers = int64(len(players))
+	if stats.TotalMatches > 0 {
+		stats.AverageDuration = total / time.Duration(stats.TotalMatches)
+	}
+	return stats, nil
+}
+
+func (r *memoryMatchRepo) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.matches)
+}
+
+func TestRoomManager_EndGameRecordsMatch(t *testing.T) {
+	repo := &memoryMatchRepo{}
+	gs := gameserver.NewGameServer(nil, minigame.NewMiniGameEngine(nil, nil), gameserver.WithMatchStore(gameserver.NewMatchStore(repo)))
+	rm := gs.GetRoomManager()
+
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+	click := map[string]interface{}{"type": "click"}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, rm.ProcessGameAction(room.ID, "host", click))
+	}
+	require.NoError(t, rm.EndGame(room.ID))
+
+	require.Eventually(t, func() bool { return repo.count() == 1 }, time.Second, 10*time.Millisecond)
+
+	match := repo.matches[0]
+	assert.Equal(t, room.ID, match.RoomID)
+	assert.Equal(t, string(minigame.GameTypeClickSpeed), match.GameType)
+	assert.Equal(t, 2, match.PlayerCount)
+	assert.GreaterOrEqual(t, match.DurationMs, int64(0))
+	require.Len(t, match.Participants, 2)
+	assert.Equal(t, "host", match.Participants[0].Username)
+	assert.Equal(t, 1, match.Participants[0].Placement)
+	assert.Equal(t, 3, match.Participants[0].Score)
+
+	rec, envelope := doRequest(t, gs, "GET", "/api/v1/stats", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	stats := envelope.Data.(map[string]interface{})
+	assert.Equal(t, float64(1), stats["totalGamesPlayed"])
+	assert.Equal(t, float64(2), stats["totalPlayersServed"])
+	assert.Equal(t, map[string]interface{}{"click_speed": float64(1)}, stats["popularGameTypes"])
+
+	// Later requests are served from the cached aggregates
+	rec, _ = doRequest(t, gs, "GET", "/api/v1/stats", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	repo.mu.Lock()
+	assert.Equal(t, 1, repo.statsCalls)
+	repo.mu.Unlock()
+}
+
+func TestMatchStore_SkipsBots(t *testing.T) {
+	repo := &memoryMatchRepo{}
+	store := gameserver.NewMatchStore(repo)
+
+	require.NoError(t, store.RecordMatch(&gameserver.RoomResult{
+		GameType: minigame.GameTypeClickSpeed,
+		Players: []*gameserver.PlayerResult{
+			{Username: "bot-1a2b3c4d", Placement: 1, Score: 20, IsBot: true},
+			{Username: "host", Placement: 2, Score: 10, IsValid: true},
+		},
+	}))
+	require.Equal(t, 1, repo.count())
+	assert.Equal(t, 1, repo.matches[0].PlayerCount)
+	require.Len(t, repo.matches[0].Participants, 1)
+	assert.Equal(t, "host", repo.matches[0].Participants[0].Username)
+
+	// Games without people are not recorded
+	require.NoError(t, store.RecordMatch(&gameserver.RoomResult{
+		GameType: minigame.GameTypeClickSpeed,
+		Players:  []*gameserver.PlayerResult{{Username: "bot-5e6f7a8b", IsBot: true}},
+	}))
+	assert.Equal(t, 1, repo.count())
+}
@@ -1,327 +1,158 @@
+// internal/gameserver/matching_handler.go
 package gameserver
 
 import (
-	"encoding/json"
-	"log"
-	"net/http"
-	"strconv"
+	"fmt"
 	"time"
 
-	"github.com/gorilla/websocket"
-	"github.com/pitturu-ppaturu/backend/internal/matching"
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
 )
 
-// MatchingWebSocketHandler handles WebSocket connections for matchmaking
-type MatchingWebSocketHandler struct {
-	matchmaker *matching.Matchmaker
-	upgrader   websocket.Upgrader
-}
-
-// NewMatchingWebSocketHandler creates a new matching WebSocket handler
-func NewMatchingWebSocketHandler() *MatchingWebSocketHandler {
-	return &MatchingWebSocketHandler{
-		matchmaker: matching.NewMatchmaker(),
-		upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				// Allow all origins for development
-				return true
-			},
-		},
-	}
-}
-
-// HandleWebSocket handles WebSocket connections for matchmaking
-func (h *MatchingWebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
-	conn, err := h.upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Printf("WebSocket upgrade error: %v", err)
-		return
-	}
-	defer conn.Close()
-
-	// Get player info from query parameters
-	playerID := r.URL.Query().Get("player_id")
-	if playerID == "" {
-		playerID = "player_" + generateRandomID()
-	}
-
-	platform := r.URL.Query().Get("platform")
-	if platform == "" {
-		platform = "web"
-	}
-
-	skillLevelStr := r.URL.Query().Get("skill_level")
-	skillLevel := 1000 // Default skill level
-	if skillLevelStr != "" {
-		if sl, err := strconv.Atoi(skillLevelStr); err == nil {
-			skillLevel = sl
-		}
-	}
-
-	log.Printf("New matching connection: player=%s, platform=%s, skill=%d",
-		playerID, platform, skillLevel)
-
-	// Send welcome message
-	welcomeMsg := map[string]interface{}{
-		"type": "connected",
-		"player_id": playerID,
-		"platform": platform,
-		"skill_level": skillLevel,
-		"supported_games": []string{"paint_battle", "physics_jump", "memory_match", "click_speed"},
-	}
-	h.sendMessage(conn, welcomeMsg)
+// Matchmaking message types shared by /ws/{username} and /ws/matching
+const (
+	MessageTypeJoinQueue      = "join_queue"       // client -> server: {gameType, preferences}
+	MessageTypeLeaveQueue     = "leave_queue"      // client -> server
+	MessageTypeGetQueueStatus = "get_queue_status" // client -> server: {gameType}
+	MessageTypeQueueStatus    = "queue_status"     // server -> client
+	MessageTypeGetRoomInfo    = "get_room_info"    // client -> server: {roomId}
+	MessageTypeRoomInfo       = "room_info"        // server -> client
+)
 
-	// Handle messages
-	for {
-		var msg map[string]interface{}
-		err := conn.ReadJSON(&msg)
+// registerMatchmakingHandlers routes matchmaking messages to the matchmaking service
+func (gs *GameServer) registerMatchmakingHandlers() {
+	gs.wsManager.HandleMessage(MessageTypeMatchmaking, gs.handleMatchmakingMessage)
+	gs.wsManager.HandleMessage(MessageTypeJoinQueue, gs.handleJoinQueueMessage)
+	gs.wsManager.HandleMessage(MessageTypeLeaveQueue, gs.handleLeaveQueueMessage)
+	gs.wsManager.HandleMessage(MessageTypeGetQueueStatus, gs.handleQueueStatusMessage)
+	gs.wsManager.HandleMessage(MessageTypeGetRoomInfo, gs.handleRoomInfoMessage)
+	gs.wsManager.HandleMessage(MessageTypeParty, gs.handlePartyMessage)
+	gs.wsManager.HandleMessage(MessageTypeMatchAccept, gs.handleMatchAcceptMessage)
+	gs.wsManager.HandleMessage(MessageTypeMatchDecline, gs.handleMatchDeclineMessage)
+}
+
+// handleMatchmakingMessage handles {"type": "matchmaking", "data": {"action": ...}},
+// defaulting to joining the queue
+func (gs *GameServer) handleMatchmakingMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	action, _ := message.Data["action"].(string)
+	switch action {
+	case "", "join":
+		return gs.handleJoinQueueMessage(conn, message)
+	case "leave":
+		return gs.handleLeaveQueueMessage(conn, message)
+	case "status":
+		status, err := gs.matchmaking.GetMatchmakingStatus(conn.Username)
 		if err != nil {
-			log.Printf("WebSocket read error: %v", err)
-			break
+			return err
 		}
-
-		h.handleMessage(conn, playerID, platform, skillLevel, msg)
-	}
-
-	// Clean up: remove player from queues
-	h.matchmaker.LeaveQueue(playerID)
-	log.Printf("Player %s disconnected", playerID)
-}
-
-// handleMessage processes incoming WebSocket messages
-func (h *MatchingWebSocketHandler) handleMessage(conn *websocket.Conn, playerID, platform string, skillLevel int, msg map[string]interface{}) {
-	msgType, ok := msg["type"].(string)
-	if !ok {
-		h.sendError(conn, "INVALID_MESSAGE", "Message type required")
-		return
-	}
-
-	switch msgType {
-	case "join_queue":
-		h.handleJoinQueue(conn, playerID, platform, skillLevel, msg)
-	case "leave_queue":
-		h.handleLeaveQueue(conn, playerID)
-	case "get_queue_status":
-		h.handleGetQueueStatus(conn, msg)
-	case "get_room_info":
-		h.handleGetRoomInfo(conn, msg)
-	case "ping":
-		h.handlePing(conn)
+		gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+			Type:      MessageTypeMatchmaking,
+			Data:      status,
+			Timestamp: time.Now(),
+		})
+		return nil
 	default:
-		h.sendError(conn, "UNKNOWN_MESSAGE_TYPE", "Unknown message type: "+msgType)
+		return fmt.Errorf("%w: unknown action %s", ErrInvalidMatchmakingRequest, action)
 	}
 }
 
-// handleJoinQueue processes join queue requests
-func (h *MatchingWebSocketHandler) handleJoinQueue(conn *websocket.Conn, playerID, platform string, skillLevel int, msg map[string]interface{}) {
-	gameType, ok := msg["game_type"].(string)
-	if !ok {
-		h.sendError(conn, "INVALID_GAME_TYPE", "Game type required")
-		return
-	}
-
-	// Extract preferences if provided
-	preferences := make(map[string]interface{})
-	if prefs, ok := msg["preferences"].(map[string]interface{}); ok {
-		preferences = prefs
-	}
-
-	// Create match request
-	request := &matching.MatchRequest{
-		PlayerID:    playerID,
-		GameType:    gameType,
-		Platform:    platform,
-		SkillLevel:  skillLevel,
-		Preferences: preferences,
-		Connection:  conn,
-	}
-
-	// Join queue
-	err := h.matchmaker.JoinQueue(request)
-	if err != nil {
-		if matchErr, ok := err.(*matching.MatchError); ok {
-			h.sendError(conn, matchErr.Code, matchErr.Message)
-		} else {
-			h.sendError(conn, "QUEUE_JOIN_FAILED", err.Error())
-		}
-		return
-	}
-
-	log.Printf("Player %s joined queue for %s", playerID, gameType)
-}
-
-// handleLeaveQueue processes leave queue requests
-func (h *MatchingWebSocketHandler) handleLeaveQueue(conn *websocket.Conn, playerID string) {
-	err := h.matchmaker.LeaveQueue(playerID)
-	if err != nil {
-		if matchErr, ok := err.(*matching.MatchError); ok {
-			h.sendError(conn, matchErr.Code, matchErr.Message)
-		} else {
-			h.sendError(conn, "QUEUE_LEAVE_FAILED", err.Error())
-		}
-		return
-	}
+func (gs *GameServer) handleJoinQueueMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	gameType, _ := message.Data["gameType"].(string)
+	preferences, _ := message.Data["preferences"].(map[string]interface{})
 
-	h.sendMessage(conn, map[string]interface{}{
-		"type": "queue_left_success",
-	})
+	_, err := gs.matchmaking.JoinMatchmaking(conn.Username, minigame.GameType(gameType), preferences)
+	return err
 }
 
-// handleGetQueueStatus provides queue status information
-func (h *MatchingWebSocketHandler) handleGetQueueStatus(conn *websocket.Conn, msg map[string]interface{}) {
-	gameType, ok := msg["game_type"].(string)
-	if !ok {
-		h.sendError(conn, "INVALID_GAME_TYPE", "Game type required")
-		return
-	}
-
-	status := h.matchmaker.GetQueueStatus(gameType)
-	status["type"] = "queue_status"
-
-	h.sendMessage(conn, status)
+func (gs *GameServer) handleLeaveQueueMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	return gs.matchmaking.LeaveMatchmaking(conn.Username)
 }
 
-// handleGetRoomInfo provides room information
-func (h *MatchingWebSocketHandler) handleGetRoomInfo(conn *websocket.Conn, msg map[string]interface{}) {
-	roomID, ok := msg["room_id"].(string)
-	if !ok {
-		h.sendError(conn, "INVALID_ROOM_ID", "Room ID required")
-		return
-	}
-
-	room, err := h.matchmaker.GetRoomInfo(roomID)
+func (gs *GameServer) handleQueueStatusMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	gameType, _ := message.Data["gameType"].(string)
+	status, err := gs.matchmaking.GetQueueStatus(minigame.GameType(gameType))
 	if err != nil {
-		if matchErr, ok := err.(*matching.MatchError); ok {
-			h.sendError(conn, matchErr.Code, matchErr.Message)
-		} else {
-			h.sendError(conn, "ROOM_INFO_FAILED", err.Error())
-		}
-		return
-	}
-
-	response := map[string]interface{}{
-		"type": "room_info",
-		"room": room,
+		return err
 	}
 
-	h.sendMessage(conn, response)
-}
-
-// handlePing responds to ping messages
-func (h *MatchingWebSocketHandler) handlePing(conn *websocket.Conn) {
-	h.sendMessage(conn, map[string]interface{}{
-		"type": "pong",
-		"timestamp": getCurrentTimestamp(),
+	gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+		Type:      MessageTypeQueueStatus,
+		Data:      status,
+		Timestamp: time.Now(),
 	})
+	return nil
 }
 
-// HTTP API Handlers
-
-// HandleGetQueueStatusHTTP provides queue status via HTTP
-func (h *MatchingWebSocketHandler) HandleGetQueueStatusHTTP(w http.ResponseWriter, r *http.Request) {
-	gameType := r.URL.Query().Get("game_type")
-	if gameType == "" {
-		http.Error(w, "game_type parameter required", http.StatusBadRequest)
-		return
-	}
-
-	status := h.matchmaker.GetQueueStatus(gameType)
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(status)
-}
-
-// HandleGetRoomInfoHTTP provides room information via HTTP
-func (h *MatchingWebSocketHandler) HandleGetRoomInfoHTTP(w http.ResponseWriter, r *http.Request) {
-	roomID := r.URL.Query().Get("room_id")
-	if roomID == "" {
-		http.Error(w, "room_id parameter required", http.StatusBadRequest)
-		return
-	}
-
-	room, err := h.matchmaker.GetRoomInfo(roomID)
+func (gs *GameServer) handleRoomInfoMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	roomIDStr, _ := message.Data["roomId"].(string)
+	roomID, err := uuid.Parse(roomIDStr)
 	if err != nil {
-		if matchErr, ok := err.(*matching.MatchError); ok {
-			http.Error(w, matchErr.Message, http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-		}
-		return
+		return ErrRoomNotFound
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(room)
-}
-
-// HandleEndGameHTTP marks a game as finished via HTTP
-func (h *MatchingWebSocketHandler) HandleEndGameHTTP(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
+	room, exists := gs.roomManager.GetRoom(roomID)
+	if !exists {
+		return ErrRoomNotFound
 	}
 
-	var request struct {
-		RoomID  string                 `json:"room_id"`
-		Results map[string]interface{} `json:"results"`
+	gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+		Type:      MessageTypeRoomInfo,
+		Data:      room.GetRoomStats(),
+		Timestamp: time.Now(),
+		RoomID:    &roomID,
+	})
+	return nil
+}
+
+// handlePartyMessage handles {"type": "party", "data": {"action": ...}} and
+// answers with the caller's party
+func (gs *GameServer) handlePartyMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	action, _ := message.Data["action"].(string)
+	target, _ := message.Data["username"].(string)
+	partyID, _ := uuid.Parse(fmt.Sprint(message.Data["partyId"]))
+
+	var party *Party
+	var err error
+	switch action {
+	case "create":
+		party, err = gs.matchmaking.CreateParty(conn.Username)
+	case "invite":
+		party, err = gs.matchmaking.InviteToParty(conn.Username, target)
+	case "accept":
+		party, err = gs.matchmaking.AcceptPartyInvite(conn.Username, partyID)
+	case "decline":
+		return gs.matchmaking.DeclinePartyInvite(conn.Username, partyID)
+	case "leave":
+		return gs.matchmaking.LeaveParty(conn.Username)
+	case "", "status":
+		party, err = gs.matchmaking.GetParty(conn.Username)
+	default:
+		return fmt.Errorf("%w: unknown party action %s", ErrInvalidMatchmakingRequest, action)
 	}
-
-	err := json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
-		return
-	}
-
-	if request.RoomID == "" {
-		http.Error(w, "room_id required", http.StatusBadRequest)
-		return
+		return err
 	}
 
-	err = h.matchmaker.EndGame(request.RoomID, request.Results)
-	if err != nil {
-		if matchErr, ok := err.(*matching.MatchError); ok {
-			http.Error(w, matchErr.Message, http.StatusNotFound)
-		} else {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-		}
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-		"message": "Game ended successfully",
+	gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+		Type:      MessageTypeParty,
+		Data:      map[string]interface{}{"party": party},
+		Timestamp: time.Now(),
 	})
+	return nil
 }
 
-// Utility methods
-
-func (h *MatchingWebSocketHandler) sendMessage(conn *websocket.Conn, message map[string]interface{}) {
-	err := conn.WriteJSON(message)
+func (gs *GameServer) handleMatchAcceptMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	matchID, err := uuid.Parse(fmt.Sprint(message.Data["matchId"]))
 	if err != nil {
-		log.Printf("WebSocket write error: %v", err)
+		return fmt.Errorf("%w: invalid matchId", ErrMatchProposalNotFound)
 	}
+	return gs.matchmaking.AcceptMatch(conn.Username, matchID)
 }
 
-func (h *MatchingWebSocketHandler) sendError(conn *websocket.Conn, code, message string) {
-	errorMsg := map[string]interface{}{
-		"type": "error",
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
+func (gs *GameServer) handleMatchDeclineMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	matchID, err := uuid.Parse(fmt.Sprint(message.Data["matchId"]))
+	if err != nil {
+		return fmt.Errorf("%w: invalid matchId", ErrMatchProposalNotFound)
 	}
-	h.sendMessage(conn, errorMsg)
+	return gs.matchmaking.DeclineMatch(conn.Username, matchID)
 }
-
-func generateRandomID() string {
-	return strconv.FormatInt(getCurrentTimestamp(), 36)
-}
-
-func getCurrentTimestamp() int64 {
-	return time.Now().UnixMilli()
-}
-
-// GetMatchmaker returns the matchmaker instance for testing
-func (h *MatchingWebSocketHandler) GetMatchmaker() *matching.Matchmaker {
-	return h.matchmaker
-}
\ No newline at end of file
@@ -3,14 +3,30 @@ package gameserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"math"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxRecentWaits is how many matched wait times are kept per game type for estimates
+const maxRecentWaits = 50
+
+// Matchmaking errors returned by MatchmakingService
+var (
+	ErrAlreadyInQueue            = errors.New("already in matchmaking")
+	ErrNotInQueue                = errors.New("not in matchmaking")
+	ErrMatchmakingCooldown       = errors.New("matchmaking cooldown")
+	ErrNotConnected              = errors.New("not connected to the game server")
+	ErrInvalidMatchmakingRequest = errors.New("invalid matchmaking request")
 )
 
 // MatchmakingRequest represents a request for matchmaking
@@ -18,8 +34,10 @@ type MatchmakingRequest struct {
 	ID              uuid.UUID             `json:"id"`
 	Username        string                `json:"username"`
 	GameType        minigame.GameType     `json:"gameType"`
-	SkillLevel      int                   `json:"skillLevel"`      // 1-100 skill rating
-	PreferredPlayers int                  `json:"preferredPlayers"` // 2, 4, 6, 8
+	Platform        string                `json:"platform"`
+	SkillLevel      int                   `json:"skillLevel"`      // Stored rating for the game type
+	PartyID         *uuid.UUID            `json:"partyId,omitempty"` // Set for players queued with their party
+	PreferredPlayers int                  `json:"preferredPlayers"` // Room size to aim for, 0 = game type default
 	MaxWaitTime     time.Duration         `json:"maxWaitTime"`     // Maximum time to wait
 	CreatedAt       time.Time             `json:"createdAt"`
 	Preferences     map[string]interface{} `json:"preferences"`
@@ -48,8 +66,22 @@ type MatchmakingService struct {
 	activeRequests  map[uuid.UUID]*MatchmakingRequest
 	userRequests    map[string]uuid.UUID // username -> request ID
 	matchHistory    map[string][]time.Time // username -> match times (for cooldown)
+	recentWaits     map[minigame.GameType][]time.Duration // Wait times of recently matched players
+	parties         map[uuid.UUID]*Party
+	userParties     map[string]uuid.UUID // username -> party ID
+	friends         FriendLister
+	proposals       map[uuid.UUID]*matchProposal // Matches waiting for every player to accept
+	userProposals   map[string]uuid.UUID         // username -> proposal ID
+	penalties       map[string]time.Time         // username -> end of decline penalty
+	backfills       map[uuid.UUID]*BackfillRequest // Rooms waiting for players to fill open seats
+	acceptTimeout   time.Duration
+	declinePenalty  time.Duration
 	wsManager       *WebSocketManager
 	roomManager     *RoomManager
+	registry        *GameTypeRegistry
+	ratings         RatingStore
+	waitHistogram   *prometheus.HistogramVec // Observes the wait of every matched player
+	config          atomic.Pointer[MatchmakingConfig] // Replaced when the rules are reloaded
 	mu              sync.RWMutex
 	matchTicker     *time.Ticker
 	ctx             context.Context
@@ -69,7 +101,7 @@ type MatchmakingConfig struct {
 }
 
 // NewMatchmakingService creates a new matchmaking service
-func NewMatchmakingService(ctx context.Context, wsManager *WebSocketManager, roomManager *RoomManager) *MatchmakingService {
+func NewMatchmakingService(ctx context.Context, wsManager *WebSocketManager, roomManager *RoomManager, registry *GameTypeRegistry) *MatchmakingService {
 	serviceCtx, cancel := context.WithCancel(ctx)
 
 	ms := &MatchmakingService{
@@ -77,30 +109,31 @@ func NewMatchmakingService(ctx context.Context, wsManager *WebSocketManager, roo
 		activeRequests: make(map[uuid.UUID]*MatchmakingRequest),
 		userRequests:   make(map[string]uuid.UUID),
 		matchHistory:   make(map[string][]time.Time),
+		recentWaits:    make(map[minigame.GameType][]time.Duration),
+		parties:        make(map[uuid.UUID]*Party),
+		userParties:    make(map[string]uuid.UUID),
+		proposals:      make(map[uuid.UUID]*matchProposal),
+		userProposals:  make(map[string]uuid.UUID),
+		penalties:      make(map[string]time.Time),
+		backfills:      make(map[uuid.UUID]*BackfillRequest),
+		acceptTimeout:  defaultMatchAcceptTimeout,
+		declinePenalty: defaultMatchDeclinePenalty,
 		wsManager:      wsManager,
 		roomManager:    roomManager,
+		registry:       registry,
 		ctx:            serviceCtx,
 		cancel:         cancel,
 	}
 
-	// Initialize pools for each game type
-	gameTypes := []minigame.GameType{
-		minigame.GameTypeClickSpeed,
-		minigame.GameTypeMemoryMatch,
-		minigame.GameTypeNumberGuess,
-		minigame.GameTypeWordScramble,
-		minigame.GameTypePuzzle,
-	}
-
-	for _, gameType := range gameTypes {
-		ms.pools[gameType] = &MatchmakingPool{
-			gameType: gameType,
-			requests: make([]*MatchmakingRequest, 0),
-		}
+	// Initialize pools for each registered game type
+	for _, gameType := range registry.GameTypes() {
+		ms.pools[gameType] = newMatchmakingPool(gameType)
 	}
 
 	// Start matchmaking ticker
-	ms.matchTicker = time.NewTicker(2 * time.Second)
+	config := ms.GetDefaultConfig()
+	ms.config.Store(config)
+	ms.matchTicker = time.NewTicker(config.TickInterval)
 	go ms.matchmakingLoop()
 
 	// Start cleanup routine
@@ -109,150 +142,279 @@ func NewMatchmakingService(ctx context.Context, wsManager *WebSocketManager, roo
 	return ms
 }
 
-// GetDefaultConfig returns default matchmaking configuration
+// skillRating looks up a player's stored rating, falling back to the starting rating
+func (ms *MatchmakingService) skillRating(username string, gameType minigame.GameType) int {
+	if ms.ratings == nil {
+		return DefaultSkillRating
+	}
+	rating, err := ms.ratings.SkillRating(username, gameType)
+	if err != nil {
+		log.Printf("Failed to load rating of %s for %s: %v", username, gameType, err)
+		return DefaultSkillRating
+	}
+	return rating
+}
+
+// newMatchmakingPool creates an empty pool for a game type
+func newMatchmakingPool(gameType minigame.GameType) *MatchmakingPool {
+	return &MatchmakingPool{
+		gameType: gameType,
+		requests: make([]*MatchmakingRequest, 0),
+	}
+}
+
+// GetDefaultConfig returns the built-in matchmaking configuration
 func (ms *MatchmakingService) GetDefaultConfig() *MatchmakingConfig {
+	return defaultMatchmakingConfig()
+}
+
+// defaultMatchmakingConfig is the matchmaking configuration used until rules are loaded
+func defaultMatchmakingConfig() *MatchmakingConfig {
 	return &MatchmakingConfig{
 		TickInterval:       2 * time.Second,
-		MaxSkillDifference: 20,
+		MaxSkillDifference: 500,
 		MinPlayersPerMatch: 2,
 		MaxPlayersPerMatch: 8,
 		DefaultWaitTime:    60 * time.Second,
 		MaxWaitTime:        300 * time.Second, // 5 minutes
-		SkillExpansionRate: 1.2,               // 20% expansion per tick
+		SkillExpansionRate: 1.2,               // 20% expansion every 30 seconds
 		MatchCooldown:      10 * time.Second,
 	}
 }
 
-// JoinMatchmaking adds a player to the matchmaking queue
-func (ms *MatchmakingService) JoinMatchmaking(username string, gameType minigame.GameType, skillLevel int, preferences map[string]interface{}) (*MatchmakingRequest, error) {
+// Config returns a copy of the matchmaking configuration in use
+func (ms *MatchmakingService) Config() *MatchmakingConfig {
+	config := *ms.config.Load()
+	return &config
+}
+
+// JoinMatchmaking adds a player, or the whole party they lead, to the matchmaking
+// queue. Players are matched by their stored rating.
+func (ms *MatchmakingService) JoinMatchmaking(username string, gameType minigame.GameType, preferences map[string]interface{}) (*MatchmakingRequest, error) {
+	skills := make(map[string]int)
+	for _, member := range ms.partyMembers(username) {
+		skills[member] = ms.skillRating(member, gameType)
+	}
+
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
-	// Check if user is already in matchmaking
-	if _, exists := ms.userRequests[username]; exists {
-		return nil, fmt.Errorf("user %s is already in matchmaking", username)
+	// Party members queue together and only the leader can start the search
+	players := []string{username}
+	var partyID *uuid.UUID
+	if party := ms.partyOf(username); party != nil {
+		if party.Leader != username {
+			return nil, fmt.Errorf("%w: %s", ErrNotPartyLeader, username)
+		}
+		players = append([]string(nil), party.Members...)
+		id := party.ID
+		partyID = &id
 	}
 
-	// Check if user is in a room
-	if _, exists := ms.roomManager.GetUserRoom(username); exists {
-		return nil, fmt.Errorf("user %s is already in a game room", username)
+	rules, err := ms.registry.Get(gameType)
+	if err != nil {
+		return nil, err
 	}
-
-	// Check cooldown
-	if ms.isInCooldown(username) {
-		return nil, fmt.Errorf("user %s is in matchmaking cooldown", username)
+	if len(players) >= rules.MaxPlayers {
+		return nil, fmt.Errorf("%w: party of %d is too large for %s", ErrInvalidMatchmakingRequest, len(players), gameType)
 	}
 
-	// Get user connection
-	conn, exists := ms.wsManager.GetConnection(username)
-	if !exists {
-		return nil, fmt.Errorf("user %s is not connected", username)
-	}
+	platformOverride, _ := preferences["platform"].(string)
+	connections := make(map[string]*WebSocketConnection, len(players))
+	platforms := make(map[string]string, len(players))
+	for _, player := range players {
+		// Check if user is already in matchmaking
+		if _, exists := ms.userRequests[player]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrAlreadyInQueue, player)
+		}
 
-	// Validate skill level
-	if skillLevel < 1 || skillLevel > 100 {
-		skillLevel = 50 // Default skill level
+		// Check if user is in a room
+		if _, exists := ms.roomManager.GetUserRoom(player); exists {
+			return nil, fmt.Errorf("%w: %s", ErrAlreadyInRoom, player)
+		}
+
+		// Check cooldown
+		if ms.isInCooldown(player) {
+			return nil, fmt.Errorf("%w: %s", ErrMatchmakingCooldown, player)
+		}
+		if ms.penalized(player) {
+			return nil, fmt.Errorf("%w: %s", ErrMatchmakingPenalty, player)
+		}
+
+		// Get user connection
+		conn, exists := ms.wsManager.GetConnection(player)
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrNotConnected, player)
+		}
+		connections[player] = conn
+
+		platform := conn.Platform
+		if player == username && platformOverride != "" {
+			platform = platformOverride
+		}
+		if platform == "" {
+			platform = PlatformWeb
+		}
+		if !rules.AllowsPlatform(platform) {
+			return nil, fmt.Errorf("%w: %s on %s", ErrPlatformNotSupported, gameType, platform)
+		}
+		if !rules.CrossPlatform && platform != platforms[username] && player != username {
+			return nil, fmt.Errorf("%w: party mixes platforms for %s", ErrPlatformNotSupported, gameType)
+		}
+		platforms[player] = platform
 	}
 
 	// Parse preferences
-	preferredPlayers := 2 // default
-	maxWaitTime := ms.GetDefaultConfig().DefaultWaitTime
+	preferredPlayers := 0 // game type default
+	maxWaitTime := ms.Config().DefaultWaitTime
 
 	if preferences != nil {
-		if v, ok := preferences["preferredPlayers"].(float64); ok && v >= 2 && v <= 8 {
+		if v, ok := preferences["preferredPlayers"].(float64); ok && int(v) >= rules.MinPlayers && int(v) <= rules.MaxPlayers {
 			preferredPlayers = int(v)
 		}
 		if v, ok := preferences["maxWaitTime"].(float64); ok {
 			waitTime := time.Duration(v) * time.Second
-			if waitTime > 0 && waitTime <= ms.GetDefaultConfig().MaxWaitTime {
+			if waitTime > 0 && waitTime <= ms.Config().MaxWaitTime {
 				maxWaitTime = waitTime
 			}
 		}
 	}
 
-	// Create matchmaking request
-	request := &MatchmakingRequest{
-		ID:              uuid.New(),
-		Username:        username,
-		GameType:        gameType,
-		SkillLevel:      skillLevel,
-		PreferredPlayers: preferredPlayers,
-		MaxWaitTime:     maxWaitTime,
-		CreatedAt:       time.Now(),
-		Preferences:     preferences,
-		Connection:      conn,
+	// Create one matchmaking request per player; party requests share their creation time
+	now := time.Now()
+	requests := make([]*MatchmakingRequest, 0, len(players))
+	for _, player := range players {
+		skillLevel, exists := skills[player]
+		if !exists {
+			skillLevel = DefaultSkillRating
+		}
+		requests = append(requests, &MatchmakingRequest{
+			ID:               uuid.New(),
+			Username:         player,
+			GameType:         gameType,
+			Platform:         platforms[player],
+			SkillLevel:       skillLevel,
+			PartyID:          partyID,
+			PreferredPlayers: preferredPlayers,
+			MaxWaitTime:      maxWaitTime,
+			CreatedAt:        now,
+			Preferences:      preferences,
+			Connection:       connections[player],
+		})
 	}
 
-	// Add to pool
+	// Add to pool, creating it for game types registered after startup
 	pool, exists := ms.pools[gameType]
 	if !exists {
-		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+		pool = newMatchmakingPool(gameType)
+		ms.pools[gameType] = pool
 	}
 
 	pool.mu.Lock()
-	pool.requests = append(pool.requests, request)
+	pool.requests = append(pool.requests, requests...)
+	position := len(pool.requests)
 	pool.mu.Unlock()
 
-	ms.activeRequests[request.ID] = request
-	ms.userRequests[username] = request.ID
+	for _, request := range requests {
+		ms.activeRequests[request.ID] = request
+		ms.userRequests[request.Username] = request.ID
+	}
 
 	// Send matchmaking started message
-	message := &WebSocketMessage{
-		Type: MessageTypeMatchmaking,
-		Data: map[string]interface{}{
+	estimatedWait := ms.estimateMatchTime(requests[0])
+	for _, request := range requests {
+		data := map[string]interface{}{
 			"status":            "searching",
 			"requestId":         request.ID.String(),
 			"gameType":          gameType,
-			"estimatedWaitTime": maxWaitTime.Seconds(),
-		},
-		Timestamp: time.Now(),
+			"platform":          request.Platform,
+			"position":          position,
+			"estimatedWaitTime": estimatedWait,
+		}
+		if partyID != nil {
+			data["partyId"] = partyID.String()
+		}
+		ms.wsManager.SendToUser(request.Username, &WebSocketMessage{
+			Type:      MessageTypeMatchmaking,
+			Data:      data,
+			Timestamp: time.Now(),
+		})
 	}
-	ms.wsManager.SendToUser(username, message)
 
-	return request, nil
+	return requests[0], nil
 }
 
-// LeaveMatchmaking removes a player from the matchmaking queue
+// LeaveMatchmaking removes a player from the matchmaking queue. A party is
+// matched as one unit, so any member leaving dequeues the whole party.
 func (ms *MatchmakingService) LeaveMatchmaking(username string) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
 	requestID, exists := ms.userRequests[username]
 	if !exists {
-		return fmt.Errorf("user %s is not in matchmaking", username)
+		return fmt.Errorf("%w: %s", ErrNotInQueue, username)
 	}
 
 	request, exists := ms.activeRequests[requestID]
 	if !exists {
-		return fmt.Errorf("matchmaking request not found")
+		return fmt.Errorf("%w: request not found", ErrNotInQueue)
+	}
+
+	ms.dequeue(request, username)
+	return nil
+}
+
+// dequeue removes a request and its party from the queue and tells every
+// removed player why. Leaving during a ready-check declines the match.
+// (assumes ms.mu is held)
+func (ms *MatchmakingService) dequeue(request *MatchmakingRequest, leaver string) {
+	if proposalID, exists := ms.userProposals[leaver]; exists {
+		if proposal, exists := ms.proposals[proposalID]; exists {
+			ms.failProposal(proposal, map[string]bool{leaver: true}, "declined")
+			return
+		}
 	}
 
+	removed := []*MatchmakingRequest{request}
+
 	// Remove from pool
 	pool := ms.pools[request.GameType]
 	pool.mu.Lock()
-	for i, req := range pool.requests {
-		if req.ID == requestID {
-			pool.requests = append(pool.requests[:i], pool.requests[i+1:]...)
-			break
+	remaining := pool.requests[:0]
+	for _, req := range pool.requests {
+		if req.ID == request.ID {
+			continue
 		}
+		if request.PartyID != nil && req.PartyID != nil && *req.PartyID == *request.PartyID {
+			removed = append(removed, req)
+			continue
+		}
+		remaining = append(remaining, req)
 	}
+	pool.requests = remaining
 	pool.mu.Unlock()
 
 	// Clean up
-	delete(ms.activeRequests, requestID)
-	delete(ms.userRequests, username)
+	for _, req := range removed {
+		delete(ms.activeRequests, req.ID)
+		delete(ms.userRequests, req.Username)
 
-	// Send matchmaking cancelled message
-	message := &WebSocketMessage{
-		Type: MessageTypeMatchCancelled,
-		Data: map[string]interface{}{
+		data := map[string]interface{}{
 			"reason": "user_cancelled",
-		},
-		Timestamp: time.Now(),
-	}
-	ms.wsManager.SendToUser(username, message)
+		}
+		if req.Username != leaver {
+			data["reason"] = "party_member_left"
+			data["username"] = leaver
+		}
 
-	return nil
+		// Send matchmaking cancelled message
+		message := &WebSocketMessage{
+			Type:      MessageTypeMatchCancelled,
+			Data:      data,
+			Timestamp: time.Now(),
+		}
+		ms.wsManager.SendToUser(req.Username, message)
+	}
 }
 
 // matchmakingLoop runs the main matchmaking algorithm
@@ -272,54 +434,51 @@ func (ms *MatchmakingService) matchmakingLoop() {
 
 // processMatchmaking attempts to create matches from the current pools
 func (ms *MatchmakingService) processMatchmaking() {
-	config := ms.GetDefaultConfig()
+	config := ms.Config()
+	now := time.Now()
+
+	ms.mu.RLock()
+	pools := make([]*MatchmakingPool, 0, len(ms.pools))
+	for _, pool := range ms.pools {
+		pools = append(pools, pool)
+	}
+	ms.mu.RUnlock()
+
+	for _, pool := range pools {
+		rules, err := ms.registry.Get(pool.gameType)
+		if err != nil {
+			continue
+		}
+
+		backfills, reserved := ms.pendingBackfills(pool.gameType)
 
-	for gameType, pool := range ms.pools {
 		pool.mu.Lock()
-		if len(pool.requests) < config.MinPlayersPerMatch {
+		if len(pool.requests) == 0 {
 			pool.mu.Unlock()
 			continue
 		}
 
-		// Sort requests by wait time (longest waiting first)
-		sort.Slice(pool.requests, func(i, j int) bool {
-			return pool.requests[i].CreatedAt.Before(pool.requests[j].CreatedAt)
-		})
-
-		var matchedRequests []*MatchmakingRequest
-		var remainingRequests []*MatchmakingRequest
-
-		i := 0
-		for i < len(pool.requests) {
-			// Try to create a match starting with the longest waiting player
-			match := ms.findMatch(pool.requests[i:], config)
-			if match != nil {
-				matchedRequests = append(matchedRequests, match...)
-				// Remove matched players from the slice
-				requestMap := make(map[uuid.UUID]bool)
-				for _, req := range match {
-					requestMap[req.ID] = true
-				}
+		sortByWait(pool.requests)
 
-				for j := i; j < len(pool.requests); j++ {
-					if !requestMap[pool.requests[j].ID] {
-						remainingRequests = append(remainingRequests, pool.requests[j])
-					}
-				}
-				break
-			} else {
-				remainingRequests = append(remainingRequests, pool.requests[i])
-				i++
-			}
-		}
+		// Open seats in existing rooms are filled before new rooms are formed
+		units := partyUnits(pool.requests)
+		backfillMatches, units, filled := ms.matchBackfills(backfills, reserved, units, config, rules, now)
 
-		// Update the pool with remaining requests
-		pool.requests = remainingRequests
+		matches, units := matchUnits(units, config, rules, now)
+		pool.requests = flattenUnits(units)
 		pool.mu.Unlock()
 
-		// Create room for matched players
-		if len(matchedRequests) > 0 {
-			ms.createMatchRoom(gameType, matchedRequests)
+		for _, roomID := range filled {
+			ms.CancelBackfill(roomID)
+		}
+
+		// Ask matched players to accept before they are seated
+		for _, match := range backfillMatches {
+			roomID := match.roomID
+			ms.proposeMatch(pool.gameType, match.players, &roomID)
+		}
+		for _, match := range matches {
+			ms.proposeMatch(pool.gameType, match, nil)
 		}
 	}
 
@@ -327,61 +486,184 @@ func (ms *MatchmakingService) processMatchmaking() {
 	ms.cleanupExpiredRequests()
 }
 
-// findMatch attempts to find a suitable match from a list of requests
-func (ms *MatchmakingService) findMatch(requests []*MatchmakingRequest, config *MatchmakingConfig) []*MatchmakingRequest {
-	if len(requests) < config.MinPlayersPerMatch {
-		return nil
+// MatchPool runs one matchmaking pass over a pool's queued requests at the
+// given time, exactly like the live matchmaking loop minus backfill. It returns
+// the matches found and the requests left waiting, longest waiting first.
+func MatchPool(requests []*MatchmakingRequest, config *MatchmakingConfig, rules *GameTypeRules, now time.Time) ([][]*MatchmakingRequest, []*MatchmakingRequest) {
+	requests = append([]*MatchmakingRequest(nil), requests...)
+	sortByWait(requests)
+
+	matches, units := matchUnits(partyUnits(requests), config, rules, now)
+	return matches, flattenUnits(units)
+}
+
+// sortByWait orders requests by wait time, longest waiting first
+func sortByWait(requests []*MatchmakingRequest) {
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+}
+
+// matchUnits forms as many matches as it can. Each unmatched anchor is kept and
+// the next longest waiting player or party tried; anchors that found no match
+// can still be picked up by a later anchor.
+func matchUnits(units [][]*MatchmakingRequest, config *MatchmakingConfig, rules *GameTypeRules, now time.Time) ([][]*MatchmakingRequest, [][]*MatchmakingRequest) {
+	var matches [][]*MatchmakingRequest
+	for i := 0; i < len(units); {
+		others := make([][]*MatchmakingRequest, 0, len(units))
+		others = append(others, units[i])
+		others = append(others, units[:i]...)
+		others = append(others, units[i+1:]...)
+
+		match := findMatch(others, config, rules, now)
+		if match == nil {
+			i++
+			continue
+		}
+		matches = append(matches, match)
+		units = removeUnits(units, match)
+	}
+	return matches, units
+}
+
+// flattenUnits turns units back into a list of requests
+func flattenUnits(units [][]*MatchmakingRequest) []*MatchmakingRequest {
+	requests := make([]*MatchmakingRequest, 0, len(units))
+	for _, unit := range units {
+		requests = append(requests, unit...)
+	}
+	return requests
+}
+
+// partyUnits groups requests into the units that are matched together: a
+// party's members form one unit, every solo player their own
+func partyUnits(requests []*MatchmakingRequest) [][]*MatchmakingRequest {
+	units := make([][]*MatchmakingRequest, 0, len(requests))
+	partyIndex := make(map[uuid.UUID]int)
+	for _, req := range requests {
+		if req.PartyID == nil {
+			units = append(units, []*MatchmakingRequest{req})
+			continue
+		}
+		if i, exists := partyIndex[*req.PartyID]; exists {
+			units[i] = append(units[i], req)
+			continue
+		}
+		partyIndex[*req.PartyID] = len(units)
+		units = append(units, []*MatchmakingRequest{req})
 	}
+	return units
+}
 
-	anchor := requests[0]
-	waitTime := time.Since(anchor.CreatedAt)
+// removeUnits returns the units that are not part of a match
+func removeUnits(units [][]*MatchmakingRequest, matched []*MatchmakingRequest) [][]*MatchmakingRequest {
+	matchedIDs := make(map[uuid.UUID]bool, len(matched))
+	for _, req := range matched {
+		matchedIDs[req.ID] = true
+	}
 
-	// Calculate dynamic skill range based on wait time
-	baseSkillRange := config.MaxSkillDifference
+	remaining := make([][]*MatchmakingRequest, 0, len(units))
+	for _, unit := range units {
+		if !matchedIDs[unit[0].ID] {
+			remaining = append(remaining, unit)
+		}
+	}
+	return remaining
+}
+
+// unitSkill is the average skill of a player or party
+func unitSkill(unit []*MatchmakingRequest) int {
+	total := 0
+	for _, req := range unit {
+		total += req.SkillLevel
+	}
+	return total / len(unit)
+}
+
+// skillRange returns how far apart players may be after waiting for a while
+func skillRange(config *MatchmakingConfig, rules *GameTypeRules, waitTime time.Duration) int {
 	expansionFactor := math.Pow(config.SkillExpansionRate, waitTime.Seconds()/30.0) // Expand every 30 seconds
-	skillRange := int(float64(baseSkillRange) * expansionFactor)
+	skillRange := int(float64(rules.SkillRange) * expansionFactor)
 
-	if skillRange > 50 { // Cap at 50 skill difference
-		skillRange = 50
+	if skillRange > config.MaxSkillDifference {
+		skillRange = config.MaxSkillDifference
 	}
+	return skillRange
+}
 
-	var candidates []*MatchmakingRequest
-	candidates = append(candidates, anchor)
+// findMatch attempts to find a suitable match for the first unit in the list.
+// Parties are never split and are compared by their average skill.
+func findMatch(units [][]*MatchmakingRequest, config *MatchmakingConfig, rules *GameTypeRules, now time.Time) []*MatchmakingRequest {
+	if len(units) == 0 {
+		return nil
+	}
 
-	// Find compatible players
-	for i := 1; i < len(requests) && len(candidates) < config.MaxPlayersPerMatch; i++ {
-		candidate := requests[i]
+	anchorUnit := units[0]
+	anchor := anchorUnit[0]
+	anchorSkill := unitSkill(anchorUnit)
+	waitTime := now.Sub(anchor.CreatedAt)
+	maxSkillDiff := skillRange(config, rules, waitTime)
 
-		// Check skill compatibility
-		skillDiff := int(math.Abs(float64(anchor.SkillLevel - candidate.SkillLevel)))
-		if skillDiff <= skillRange {
-			candidates = append(candidates, candidate)
-		}
+	// Aim for the anchor's preferred room size or the game type's optimal size
+	targetSize := anchor.PreferredPlayers
+	if targetSize == 0 {
+		targetSize = rules.OptimalPlayers
+	}
+	if targetSize <= len(anchorUnit) { // A party is always matched with other players
+		targetSize = len(anchorUnit) + 1
 	}
+	if targetSize > rules.MaxPlayers {
+		targetSize = rules.MaxPlayers
+	}
+	if targetSize < rules.MinPlayers {
+		targetSize = rules.MinPlayers
+	}
+
+	candidates := append([]*MatchmakingRequest(nil), anchorUnit...)
 
-	// Check if we have enough players for a match
-	if len(candidates) >= config.MinPlayersPerMatch {
-		// Try to match preferred player count
-		targetSize := anchor.PreferredPlayers
-		if targetSize > len(candidates) {
-			targetSize = len(candidates)
+	// Find compatible players and parties that still fit into the room
+	for i := 1; i < len(units) && len(candidates) < targetSize; i++ {
+		unit := units[i]
+		if len(candidates)+len(unit) > targetSize {
+			continue
 		}
-		if targetSize < config.MinPlayersPerMatch {
-			targetSize = config.MinPlayersPerMatch
+
+		// Check skill compatibility
+		skillDiff := int(math.Abs(float64(anchorSkill - unitSkill(unit))))
+		if skillDiff > maxSkillDiff {
+			continue
+		}
+
+		// Check platform compatibility
+		if !rules.CrossPlatform && unit[0].Platform != anchor.Platform {
+			continue
 		}
 
-		return candidates[:targetSize]
+		candidates = append(candidates, unit...)
+	}
+
+	// A full room is matched right away; a smaller one once the anchor waited long enough
+	if len(candidates) >= targetSize {
+		return candidates
+	}
+	if len(candidates) >= rules.MinPlayers && len(candidates) > len(anchorUnit) && waitTime >= rules.MatchTimeout {
+		return candidates
 	}
 
 	return nil
 }
 
-// createMatchRoom creates a game room for matched players
+// createMatchRoom creates a game room for players who accepted their match;
+// accepting counts as being ready
 func (ms *MatchmakingService) createMatchRoom(gameType minigame.GameType, players []*MatchmakingRequest) {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
-	if len(players) == 0 {
+	// Players may have left matchmaking since they were taken out of the pool
+	players = ms.stillQueued(players)
+	rules, err := ms.registry.Get(gameType)
+	if err != nil || len(players) < rules.MinPlayers {
+		ms.requeue(gameType, players)
 		return
 	}
 
@@ -390,8 +672,8 @@ func (ms *MatchmakingService) createMatchRoom(gameType minigame.GameType, player
 
 	// Create room settings
 	settings := map[string]interface{}{
-		"maxPlayers": len(players),
-		"minPlayers": len(players),
+		"maxPlayers": float64(len(players)),
+		"minPlayers": float64(len(players)),
 		"isPrivate":  false,
 		"name":       fmt.Sprintf("Match Room - %s", gameType),
 	}
@@ -401,6 +683,9 @@ func (ms *MatchmakingService) createMatchRoom(gameType minigame.GameType, player
 	if err != nil {
 		// Handle error - notify players that match failed
 		for _, player := range players {
+			delete(ms.activeRequests, player.ID)
+			delete(ms.userRequests, player.Username)
+
 			message := &WebSocketMessage{
 				Type: MessageTypeError,
 				Data: map[string]interface{}{
@@ -415,11 +700,15 @@ func (ms *MatchmakingService) createMatchRoom(gameType minigame.GameType, player
 	}
 
 	// Add remaining players to room
+	joined := []*MatchmakingRequest{host}
 	for i := 1; i < len(players); i++ {
 		player := players[i]
 		_, err := ms.roomManager.JoinRoom(room.ID, player.Username, "")
 		if err != nil {
 			// Handle error - player couldn't join
+			delete(ms.activeRequests, player.ID)
+			delete(ms.userRequests, player.Username)
+
 			message := &WebSocketMessage{
 				Type: MessageTypeError,
 				Data: map[string]interface{}{
@@ -431,10 +720,19 @@ func (ms *MatchmakingService) createMatchRoom(gameType minigame.GameType, player
 			ms.wsManager.SendToUser(player.Username, message)
 			continue
 		}
+		joined = append(joined, player)
+	}
+
+	ms.recordWaits(gameType, joined)
+
+	for _, player := range joined {
+		if err := ms.roomManager.SetPlayerReady(room.ID, player.Username, true); err != nil {
+			log.Printf("Failed to mark %s ready in match room %s: %v", player.Username, room.ID, err)
+		}
 	}
 
 	// Clean up matchmaking requests
-	for _, player := range players {
+	for _, player := range joined {
 		delete(ms.activeRequests, player.ID)
 		delete(ms.userRequests, player.Username)
 
@@ -442,20 +740,58 @@ func (ms *MatchmakingService) createMatchRoom(gameType minigame.GameType, player
 		ms.addToMatchHistory(player.Username)
 
 		// Send match found message
+		data := map[string]interface{}{
+			"roomId":       room.ID.String(),
+			"gameType":     gameType,
+			"playerCount":  len(joined),
+			"averageSkill": ms.calculateAverageSkill(joined),
+			"room":         ms.matchRoomStats(room, player.Username),
+		}
+		if player.PartyID != nil {
+			data["partyId"] = player.PartyID.String()
+		}
 		message := &WebSocketMessage{
-			Type: MessageTypeMatchFound,
-			Data: map[string]interface{}{
-				"roomId":       room.ID.String(),
-				"gameType":     gameType,
-				"playerCount":  len(players),
-				"averageSkill": ms.calculateAverageSkill(players),
-			},
+			Type:      MessageTypeMatchFound,
+			Data:      data,
 			Timestamp: time.Now(),
+			RoomID:    &room.ID,
 		}
 		ms.wsManager.SendToUser(player.Username, message)
 	}
 }
 
+// matchRoomStats returns a room snapshot with the player's resume token
+func (ms *MatchmakingService) matchRoomStats(room *GameRoom, username string) map[string]interface{} {
+	stats := room.GetRoomStats()
+	if token, ok := ms.roomManager.ResumeToken(room.ID, username); ok {
+		stats["resumeToken"] = token
+	}
+	return stats
+}
+
+// stillQueued filters out players whose request was cancelled while they were being matched
+func (ms *MatchmakingService) stillQueued(players []*MatchmakingRequest) []*MatchmakingRequest {
+	queued := make([]*MatchmakingRequest, 0, len(players))
+	for _, player := range players {
+		if requestID, exists := ms.userRequests[player.Username]; exists && requestID == player.ID {
+			queued = append(queued, player)
+		}
+	}
+	return queued
+}
+
+// requeue puts requests back into their pool, keeping their original wait time
+func (ms *MatchmakingService) requeue(gameType minigame.GameType, players []*MatchmakingRequest) {
+	pool, exists := ms.pools[gameType]
+	if !exists || len(players) == 0 {
+		return
+	}
+
+	pool.mu.Lock()
+	pool.requests = append(pool.requests, players...)
+	pool.mu.Unlock()
+}
+
 // calculateAverageSkill calculates the average skill level of players
 func (ms *MatchmakingService) calculateAverageSkill(players []*MatchmakingRequest) float64 {
 	if len(players) == 0 {
@@ -477,7 +813,7 @@ func (ms *MatchmakingService) isInCooldown(username string) bool {
 		return false
 	}
 
-	config := ms.GetDefaultConfig()
+	config := ms.Config()
 	now := time.Now()
 
 	for _, matchTime := range history {
@@ -578,6 +914,19 @@ func (ms *MatchmakingService) cleanupMatchHistory() {
 			ms.matchHistory[username] = validHistory
 		}
 	}
+
+	for username, until := range ms.penalties {
+		if now.After(until) {
+			delete(ms.penalties, username)
+		}
+	}
+
+	// Rooms that closed or started a game without late joins no longer need players
+	for roomID := range ms.backfills {
+		if slots, ok := ms.roomManager.OpenSlots(roomID); !ok || slots <= 0 {
+			delete(ms.backfills, roomID)
+		}
+	}
 }
 
 // GetMatchmakingStatus returns the current status of a user's matchmaking request
@@ -587,12 +936,12 @@ func (ms *MatchmakingService) GetMatchmakingStatus(username string) (map[string]
 
 	requestID, exists := ms.userRequests[username]
 	if !exists {
-		return nil, fmt.Errorf("user %s is not in matchmaking", username)
+		return nil, fmt.Errorf("%w: %s", ErrNotInQueue, username)
 	}
 
 	request, exists := ms.activeRequests[requestID]
 	if !exists {
-		return nil, fmt.Errorf("matchmaking request not found")
+		return nil, fmt.Errorf("%w: request not found", ErrNotInQueue)
 	}
 
 	waitTime := time.Since(request.CreatedAt)
@@ -608,7 +957,7 @@ func (ms *MatchmakingService) GetMatchmakingStatus(username string) (map[string]
 	poolSize := len(pool.requests)
 	pool.mu.RUnlock()
 
-	return map[string]interface{}{
+	status := map[string]interface{}{
 		"requestId":       request.ID.String(),
 		"gameType":        request.GameType,
 		"skillLevel":      request.SkillLevel,
@@ -616,37 +965,139 @@ func (ms *MatchmakingService) GetMatchmakingStatus(username string) (map[string]
 		"remainingTime":   remainingTime.Seconds(),
 		"poolSize":        poolSize,
 		"estimatedMatch":  ms.estimateMatchTime(request),
-	}, nil
+	}
+	if proposalID, exists := ms.userProposals[username]; exists {
+		status["matchId"] = proposalID.String()
+	}
+	return status, nil
 }
 
 // estimateMatchTime estimates when a match might be found
 func (ms *MatchmakingService) estimateMatchTime(request *MatchmakingRequest) float64 {
+	rules, err := ms.registry.Get(request.GameType)
+	if err != nil {
+		return 60.0
+	}
+
 	pool := ms.pools[request.GameType]
 	pool.mu.RLock()
 	defer pool.mu.RUnlock()
 
-	poolSize := len(pool.requests)
-	config := ms.GetDefaultConfig()
-
-	if poolSize >= config.MinPlayersPerMatch {
+	if len(pool.requests) >= rules.MinPlayers {
 		// Estimate based on current pool size and skill compatibility
 		compatibleCount := 0
 		for _, req := range pool.requests {
 			skillDiff := int(math.Abs(float64(request.SkillLevel - req.SkillLevel)))
-			if skillDiff <= config.MaxSkillDifference*2 { // Allow wider range for estimation
+			if skillDiff <= rules.SkillRange*2 { // Allow wider range for estimation
 				compatibleCount++
 			}
 		}
 
-		if compatibleCount >= config.MinPlayersPerMatch {
-			return 10.0 // Should match soon
+		if compatibleCount >= rules.MinPlayers {
+			return math.Min(10.0, rules.MatchTimeout.Seconds()) // Should match soon
 		}
 	}
 
-	// Estimate based on average arrival rate (simplified)
+	// Fall back to how long recent matches of this game type waited
+	if average, ok := ms.averageRecentWait(request.GameType); ok {
+		return average
+	}
 	return 60.0 // 1 minute estimate if no immediate match
 }
 
+// recordWaits remembers how long matched players waited, for queue estimates
+func (ms *MatchmakingService) recordWaits(gameType minigame.GameType, players []*MatchmakingRequest) {
+	now := time.Now()
+	waits := ms.recentWaits[gameType]
+	for _, player := range players {
+		wait := now.Sub(player.CreatedAt)
+		waits = append(waits, wait)
+		if ms.waitHistogram != nil {
+			ms.waitHistogram.WithLabelValues(string(gameType)).Observe(wait.Seconds())
+		}
+	}
+	if len(waits) > maxRecentWaits {
+		waits = waits[len(waits)-maxRecentWaits:]
+	}
+	ms.recentWaits[gameType] = waits
+}
+
+// averageRecentWait returns the average wait in seconds of recently matched players
+func (ms *MatchmakingService) averageRecentWait(gameType minigame.GameType) (float64, bool) {
+	waits := ms.recentWaits[gameType]
+	if len(waits) == 0 {
+		return 0, false
+	}
+
+	var total time.Duration
+	for _, wait := range waits {
+		total += wait
+	}
+	return (total / time.Duration(len(waits))).Seconds(), true
+}
+
+// GetQueueStatus returns the live queue of a game type
+func (ms *MatchmakingService) GetQueueStatus(gameType minigame.GameType) (map[string]interface{}, error) {
+	rules, err := ms.registry.Get(gameType)
+	if err != nil {
+		return nil, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	queueLength := 0
+	averageWait := 0.0
+	if pool, exists := ms.pools[gameType]; exists {
+		pool.mu.RLock()
+		queueLength = len(pool.requests)
+		averageWait = ms.calculateAverageWaitTime(pool.requests)
+		pool.mu.RUnlock()
+	}
+
+	estimatedWait := 60.0
+	if average, ok := ms.averageRecentWait(gameType); ok {
+		estimatedWait = average
+	} else if queueLength+1 >= rules.MinPlayers {
+		estimatedWait = rules.MatchTimeout.Seconds()
+	}
+
+	waitingRooms, activeRooms := ms.roomManager.CountRooms(gameType)
+
+	backfillRooms := 0
+	for _, backfill := range ms.backfills {
+		if backfill.GameType == gameType {
+			backfillRooms++
+		}
+	}
+
+	return map[string]interface{}{
+		"gameType":        gameType,
+		"queueLength":     queueLength,
+		"averageWaitTime": averageWait,
+		"estimatedWait":   estimatedWait,
+		"waitingRooms":    waitingRooms,
+		"activeRooms":     activeRooms,
+		"backfillRooms":   backfillRooms,
+		"minPlayers":      rules.MinPlayers,
+		"maxPlayers":      rules.MaxPlayers,
+	}, nil
+}
+
+// QueueSizes returns how many players wait in the pool of each game type
+func (ms *MatchmakingService) QueueSizes() map[minigame.GameType]int {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	sizes := make(map[minigame.GameType]int, len(ms.pools))
+	for gameType, pool := range ms.pools {
+		pool.mu.RLock()
+		sizes[gameType] = len(pool.requests)
+		pool.mu.RUnlock()
+	}
+	return sizes
+}
+
 // GetPoolStats returns statistics about all matchmaking pools
 func (ms *MatchmakingService) GetPoolStats() map[string]interface{} {
 	ms.mu.RLock()
@@ -668,6 +1119,8 @@ func (ms *MatchmakingService) GetPoolStats() map[string]interface{} {
 
 	stats["totalPlayers"] = len(ms.activeRequests)
 	stats["totalPools"] = len(ms.pools)
+	stats["config"] = ms.Config()
+	stats["rules"] = ms.registry.List()
 
 	return stats
 }
@@ -691,26 +1144,26 @@ func (ms *MatchmakingService) calculateAverageWaitTime(requests []*MatchmakingRe
 // calculateSkillDistribution calculates skill level distribution in a pool
 func (ms *MatchmakingService) calculateSkillDistribution(requests []*MatchmakingRequest) map[string]int {
 	distribution := map[string]int{
-		"1-20":   0,
-		"21-40":  0,
-		"41-60":  0,
-		"61-80":  0,
-		"81-100": 0,
+		"<1200":     0,
+		"1200-1399": 0,
+		"1400-1599": 0,
+		"1600-1799": 0,
+		"1800+":     0,
 	}
 
 	for _, req := range requests {
 		skill := req.SkillLevel
 		switch {
-		case skill <= 20:
-			distribution["1-20"]++
-		case skill <= 40:
-			distribution["21-40"]++
-		case skill <= 60:
-			distribution["41-60"]++
-		case skill <= 80:
-			distribution["61-80"]++
+		case skill < 1200:
+			distribution["<1200"]++
+		case skill < 1400:
+			distribution["1200-1399"]++
+		case skill < 1600:
+			distribution["1400-1599"]++
+		case skill < 1800:
+			distribution["1600-1799"]++
 		default:
-			distribution["81-100"]++
+			distribution["1800+"]++
 		}
 	}
 
@@ -0,0 +1,150 @@
+// internal/gameserver/matchmaking_rules.go
+package gameserver
+
+import (
+	"fmt"
+
+	"github.com/pitturu-ppaturu/backend/internal/config"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+)
+
+// ApplyRules replaces the matchmaking configuration and the per-game-type rules
+// with the ones loaded from the config file. Nothing changes when any of the
+// rules is invalid.
+func (ms *MatchmakingService) ApplyRules(rules config.MatchmakingRules) error {
+	settings, list, err := ResolveMatchmakingRules(rules, ms.registry.GameTypes())
+	if err != nil {
+		return err
+	}
+	if err := ms.registry.RegisterAll(list); err != nil {
+		return err
+	}
+
+	previous := ms.config.Swap(settings)
+	if previous != nil && previous.TickInterval != settings.TickInterval {
+		ms.matchTicker.Reset(settings.TickInterval)
+	}
+	return nil
+}
+
+// ResolveMatchmakingRules turns the rules from the config file into the
+// matchmaking configuration and the rules of each game type. Unset values fall
+// back to the built-in defaults, so removing a setting restores its default on
+// reload.
+func ResolveMatchmakingRules(rules config.MatchmakingRules, gameTypes []minigame.GameType) (*MatchmakingConfig, []GameTypeRules, error) {
+	settings := defaultMatchmakingConfig()
+	if rules.TickInterval > 0 {
+		settings.TickInterval = rules.TickInterval
+	}
+	if rules.MaxSkillDifference > 0 {
+		settings.MaxSkillDifference = rules.MaxSkillDifference
+	}
+	if rules.MinPlayersPerMatch > 0 {
+		settings.MinPlayersPerMatch = rules.MinPlayersPerMatch
+	}
+	if rules.MaxPlayersPerMatch > 0 {
+		settings.MaxPlayersPerMatch = rules.MaxPlayersPerMatch
+	}
+	if rules.DefaultWaitTime > 0 {
+		settings.DefaultWaitTime = rules.DefaultWaitTime
+	}
+	if rules.MaxWaitTime > 0 {
+		settings.MaxWaitTime = rules.MaxWaitTime
+	}
+	if rules.SkillExpansionRate > 0 {
+		settings.SkillExpansionRate = rules.SkillExpansionRate
+	}
+	if rules.MatchCooldown > 0 {
+		settings.MatchCooldown = rules.MatchCooldown
+	}
+
+	if settings.MinPlayersPerMatch < 2 || settings.MaxPlayersPerMatch < settings.MinPlayersPerMatch {
+		return nil, nil, fmt.Errorf("invalid players per match %d-%d", settings.MinPlayersPerMatch, settings.MaxPlayersPerMatch)
+	}
+	if settings.MaxWaitTime < settings.DefaultWaitTime {
+		return nil, nil, fmt.Errorf("max wait time %s is shorter than default wait time %s", settings.MaxWaitTime, settings.DefaultWaitTime)
+	}
+	if settings.SkillExpansionRate < 1 {
+		return nil, nil, fmt.Errorf("skill expansion rate %.2f would shrink the skill range", settings.SkillExpansionRate)
+	}
+
+	registered := make(map[minigame.GameType]bool, len(gameTypes))
+	for _, gameType := range gameTypes {
+		registered[gameType] = true
+	}
+	for name, overrides := range rules.GameTypes {
+		if !registered[minigame.GameType(name)] {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedGameType, name)
+		}
+		for _, platform := range overrides.Platforms {
+			if !isKnownPlatform(platform) {
+				return nil, nil, fmt.Errorf("unknown platform %q for %s", platform, name)
+			}
+		}
+	}
+
+	list := make([]GameTypeRules, 0, len(gameTypes))
+	for _, gameType := range gameTypes {
+		gameRules := builtinGameTypeRules(gameType)
+		if overrides, exists := rules.GameTypes[string(gameType)]; exists {
+			applyGameTypeOverrides(&gameRules, overrides)
+		}
+
+		// The global bounds apply to every game type
+		if gameRules.MinPlayers < settings.MinPlayersPerMatch {
+			gameRules.MinPlayers = settings.MinPlayersPerMatch
+		}
+		if gameRules.MaxPlayers > settings.MaxPlayersPerMatch {
+			gameRules.MaxPlayers = settings.MaxPlayersPerMatch
+		}
+		if err := gameRules.normalize(); err != nil {
+			return nil, nil, err
+		}
+		list = append(list, gameRules)
+	}
+
+	return settings, list, nil
+}
+
+// applyGameTypeOverrides copies the values set in the config file over a game type's rules
+func applyGameTypeOverrides(rules *GameTypeRules, overrides config.GameTypeMatchmakingRules) {
+	if overrides.MinPlayers > 0 {
+		rules.MinPlayers = overrides.MinPlayers
+	}
+	if overrides.MaxPlayers > 0 {
+		rules.MaxPlayers = overrides.MaxPlayers
+	}
+	if overrides.OptimalPlayers > 0 {
+		rules.OptimalPlayers = overrides.OptimalPlayers
+	}
+	if overrides.MatchTimeout > 0 {
+		rules.MatchTimeout = overrides.MatchTimeout
+	}
+	if overrides.SkillRange > 0 {
+		rules.SkillRange = overrides.SkillRange
+	}
+	if overrides.CrossPlatform != nil {
+		rules.CrossPlatform = *overrides.CrossPlatform
+	}
+	if overrides.LateJoin != nil {
+		rules.LateJoin = *overrides.LateJoin
+	}
+	if len(overrides.Platforms) > 0 {
+		rules.Platforms = append([]string(nil), overrides.Platforms...)
+	}
+}
+
+// isKnownPlatform reports whether clients can connect from the platform
+func isKnownPlatform(platform string) bool {
+	for _, known := range allPlatforms {
+		if known == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyMatchmakingRules reloads the matchmaking rules without restarting the server
+func (gs *GameServer) ApplyMatchmakingRules(rules config.MatchmakingRules) error {
+	return gs.matchmaking.ApplyRules(rules)
+}
@@ -0,0 +1,66 @@
+// internal/gameserver/matchmaking_rules_test.go
+package gameserver_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pitturu-ppaturu/backend/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func poolRules(t *testing.T, data interface{}, gameType string) map[string]interface{} {
+	t.Helper()
+	stats, ok := data.(map[string]interface{})
+	require.True(t, ok)
+	rules, ok := stats["rules"].(map[string]interface{})
+	require.True(t, ok)
+	return rules[gameType].(map[string]interface{})
+}
+
+func TestMatchmaking_ApplyRulesFromConfig(t *testing.T) {
+	gs := newTestGameServer(t)
+	lateJoin := false
+
+	require.NoError(t, gs.ApplyMatchmakingRules(config.MatchmakingRules{
+		MaxPlayersPerMatch: 6,
+		MatchCooldown:      time.Minute,
+		GameTypes: map[string]config.GameTypeMatchmakingRules{
+			"paint_battle": {MinPlayers: 3, MaxPlayers: 4, SkillRange: 300, LateJoin: &lateJoin},
+		},
+	}))
+
+	rec, envelope := doRequest(t, gs, "GET", "/api/v1/stats/pools", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	paint := poolRules(t, envelope.Data, "paint_battle")
+	assert.Equal(t, float64(3), paint["minPlayers"])
+	assert.Equal(t, float64(4), paint["maxPlayers"])
+	assert.Equal(t, float64(300), paint["skillRange"])
+	assert.Equal(t, false, paint["lateJoin"])
+	assert.Equal(t, float64(6), poolRules(t, envelope.Data, "click_speed")["maxPlayers"])
+	settings := envelope.Data.(map[string]interface{})["config"].(map[string]interface{})
+	assert.Equal(t, float64(time.Minute), settings["matchCooldown"])
+
+	// Invalid rules are rejected as a whole
+	err := gs.ApplyMatchmakingRules(config.MatchmakingRules{
+		GameTypes: map[string]config.GameTypeMatchmakingRules{
+			"click_speed":  {MaxPlayers: 3},
+			"paint_battle": {MinPlayers: 5, MaxPlayers: 4},
+		},
+	})
+	assert.Error(t, err)
+	assert.Error(t, gs.ApplyMatchmakingRules(config.MatchmakingRules{
+		GameTypes: map[string]config.GameTypeMatchmakingRules{"tetris": {MinPlayers: 2}},
+	}))
+	_, envelope = doRequest(t, gs, "GET", "/api/v1/stats/pools", "", nil)
+	assert.Equal(t, float64(6), poolRules(t, envelope.Data, "click_speed")["maxPlayers"])
+
+	// Removed settings fall back to the built-in rules
+	require.NoError(t, gs.ApplyMatchmakingRules(config.MatchmakingRules{}))
+	_, envelope = doRequest(t, gs, "GET", "/api/v1/stats/pools", "", nil)
+	paint = poolRules(t, envelope.Data, "paint_battle")
+	assert.Equal(t, float64(8), paint["maxPlayers"])
+	assert.Equal(t, true, paint["lateJoin"])
+}
@@ -0,0 +1,163 @@
+// internal/gameserver/matchmaking_test.go
+package gameserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/pitturu-ppaturu/backend/internal/config"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSocketTestServer serves a game server whose sockets and API authenticate
+// with tokens from the returned token service
+func newSocketTestServer(t *testing.T, config *gameserver.GameServerConfig, opts ...gameserver.Option) (*gameserver.GameServer, *service.TokenService, *httptest.Server) {
+	t.Helper()
+	tokenSvc := service.NewTokenService("access-secret", "refresh-secret", 15, 7)
+	opts = append([]gameserver.Option{gameserver.WithAuthenticator(gameserver.NewAuthenticator(tokenSvc, nil))}, opts...)
+	gs := gameserver.NewGameServer(config, minigame.NewMiniGameEngine(nil, nil), opts...)
+	srv := httptest.NewServer(gs.Handler())
+	t.Cleanup(srv.Close)
+	return gs, tokenSvc, srv
+}
+
+func dialGameSocket(t *testing.T, srv *httptest.Server, tokenSvc *service.TokenService, username, path string) *websocket.Conn {
+	t.Helper()
+	accessToken, err := tokenSvc.CreateAccessToken(username, "user")
+	require.NoError(t, err)
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+accessToken)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+path, header)
+	require.NoError(t, err)
+	readUntil(t, conn, "connected")
+	return conn
+}
+
+// acceptMatch waits for the ready-check and accepts it
+func acceptMatch(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+	check := readUntil(t, conn, gameserver.MessageTypeMatchReadyCheck)
+	matchID := check.Data["matchId"].(string)
+	require.NoError(t, conn.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeMatchAccept,
+		"data": map[string]interface{}{"matchId": matchID},
+	}))
+	return matchID
+}
+
+func TestGameTypeRegistry_CoversEngineGameTypes(t *testing.T) {
+	registry := gameserver.NewGameTypeRegistry(minigame.NewMiniGameEngine(nil, nil))
+
+	for _, gameType := range []minigame.GameType{minigame.GameTypeClickSpeed, minigame.GameTypePaintBattle, minigame.GameTypePhysicsJump} {
+		rules, err := registry.Get(gameType)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, rules.MinPlayers, 2)
+		assert.True(t, rules.AllowsPlatform(gameserver.PlatformWeb))
+	}
+
+	memory, err := registry.Get(minigame.GameTypeMemoryMatch)
+	require.NoError(t, err)
+	assert.False(t, memory.AllowsPlatform(gameserver.PlatformDesktop))
+
+	_, err = registry.Get("tetris")
+	assert.ErrorIs(t, err, gameserver.ErrUnsupportedGameType)
+}
+
+func TestMatchmaking_BothSocketsMatchIntoARoom(t *testing.T) {
+	gs, tokenSvc, srv := newReconnectTestServer(t, 0)
+
+	first := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer first.Close()
+	second := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/matching?platform=mobile")
+	defer second.Close()
+
+	join := map[string]interface{}{
+		"type": gameserver.MessageTypeJoinQueue,
+		"data": map[string]interface{}{"gameType": minigame.GameTypeMemoryMatch},
+	}
+	require.NoError(t, first.WriteJSON(join))
+	searching := readUntil(t, first, gameserver.MessageTypeMatchmaking)
+	assert.Equal(t, "searching", searching.Data["status"])
+
+	status, err := gs.GetMatchmakingService().GetQueueStatus(minigame.GameTypeMemoryMatch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, status["queueLength"])
+
+	require.NoError(t, second.WriteJSON(join))
+	searching = readUntil(t, second, gameserver.MessageTypeMatchmaking)
+	assert.Equal(t, gameserver.PlatformMobile, searching.Data["platform"])
+
+	acceptMatch(t, first)
+	acceptMatch(t, second)
+
+	found := readUntil(t, first, gameserver.MessageTypeMatchFound)
+	assert.NotEmpty(t, found.Data["roomId"])
+	readUntil(t, second, gameserver.MessageTypeMatchFound)
+
+	aliceRoom, inRoom := gs.GetRoomManager().GetUserRoom("alice")
+	require.True(t, inRoom)
+	bobRoom, inRoom := gs.GetRoomManager().GetUserRoom("bob")
+	require.True(t, inRoom)
+	assert.Equal(t, aliceRoom.ID, bobRoom.ID)
+	assert.Equal(t, found.Data["roomId"], aliceRoom.ID.String())
+
+	waiting, _ := gs.GetRoomManager().CountRooms(minigame.GameTypeMemoryMatch)
+	assert.Equal(t, 1, waiting)
+}
+
+func TestMatchmaking_QueueStatusEndpoint(t *testing.T) {
+	gs := newTestGameServer(t)
+
+	rec, envelope := doRequest(t, gs, "GET", "/api/v1/matchmaking/queue/paint_battle", "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	data := envelope.Data.(map[string]interface{})
+	assert.Equal(t, float64(0), data["queueLength"])
+	assert.Equal(t, float64(8), data["maxPlayers"])
+
+	rec, envelope = doRequest(t, gs, "GET", "/api/v1/matchmaking/queue/tetris", "", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "UNSUPPORTED_GAME_TYPE", envelope.Error.Code)
+}
+
+func TestMatchPool_UsesTheGivenClock(t *testing.T) {
+	settings, rules, err := gameserver.ResolveMatchmakingRules(config.MatchmakingRules{}, []minigame.GameType{minigame.GameTypeMemoryMatch})
+	require.NoError(t, err)
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	partyID := uuid.New()
+
+	request := func(username string, skill int, offset time.Duration, party *uuid.UUID) *gameserver.MatchmakingRequest {
+		return &gameserver.MatchmakingRequest{ID: uuid.New(), Username: username, SkillLevel: skill, CreatedAt: start.Add(offset), PartyID: party}
+	}
+	pool := []*gameserver.MatchmakingRequest{
+		request("far", 2400, 0, nil),
+		request("near", 2000, time.Second, nil),
+	}
+
+	// The skill range only widens as virtual time passes
+	matches, remaining := gameserver.MatchPool(pool, settings, &rules[0], start.Add(2*time.Second))
+	assert.Empty(t, matches)
+	assert.Len(t, remaining, 2)
+	matches, remaining = gameserver.MatchPool(pool, settings, &rules[0], start.Add(3*time.Minute))
+	require.Len(t, matches, 1)
+	assert.Empty(t, remaining)
+
+	// A party can be matched with a player who queued before it
+	pool = []*gameserver.MatchmakingRequest{
+		request("solo", 1500, 0, nil),
+		request("leader", 1550, time.Second, &partyID),
+		request("member", 1550, time.Second, &partyID),
+	}
+	matches, _ = gameserver.MatchPool(pool, settings, &rules[0], start.Add(2*time.Second))
+	require.Len(t, matches, 1)
+	assert.Len(t, matches[0], 3)
+}
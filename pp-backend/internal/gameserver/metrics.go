@@ -0,0 +1,130 @@
+// internal/gameserver/metrics.go
+package gameserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsNamespace prefixes every game server metric
+const metricsNamespace = "gameserver"
+
+// matchWaitBuckets cover matchmaking waits from a second up to the absolute wait limit
+var matchWaitBuckets = []float64{1, 2, 5, 10, 20, 30, 45, 60, 90, 120, 180, 300, 600}
+
+// reportedRoomStates are always exported, so a state without rooms reads as zero
+var reportedRoomStates = []GameRoomState{RoomStateWaiting, RoomStateReady, RoomStateInProgress, RoomStateCompleted}
+
+// Metric descriptions read from the live server state on every scrape
+var (
+	connectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "connections"),
+		"WebSocket connections currently open on this instance",
+		nil, nil,
+	)
+	roomsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "rooms"),
+		"Game rooms on this instance by state",
+		[]string{"state"}, nil,
+	)
+	queueSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "matchmaking", "queue_size"),
+		"Players waiting in matchmaking by game type",
+		[]string{"game_type"}, nil,
+	)
+	messagesReceivedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "messages", "received_total"),
+		"WebSocket messages read from clients",
+		nil, nil,
+	)
+	messagesSentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "messages", "sent_total"),
+		"WebSocket messages queued to clients",
+		nil, nil,
+	)
+	droppedSendsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "messages", "dropped_total"),
+		"WebSocket sends dropped because the client's send buffer was full or the socket write failed",
+		nil, nil,
+	)
+	uptimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "uptime_seconds"),
+		"Seconds since the game server was created",
+		nil, nil,
+	)
+)
+
+// serverMetrics is the Prometheus registry served on /metrics
+type serverMetrics struct {
+	registry  *prometheus.Registry
+	matchWait *prometheus.HistogramVec
+}
+
+// newServerMetrics registers the game server collectors in a registry of their own,
+// so several servers in one process do not clash
+func newServerMetrics(gs *GameServer) *serverMetrics {
+	matchWait := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "matchmaking",
+		Name:      "wait_seconds",
+		Help:      "Time players waited in matchmaking until they were placed in a room",
+		Buckets:   matchWaitBuckets,
+	}, []string{"game_type"})
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(matchWait, &gameServerCollector{gs: gs})
+
+	return &serverMetrics{
+		registry:  registry,
+		matchWait: matchWait,
+	}
+}
+
+// handler serves the metrics in the Prometheus text format
+func (m *serverMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// gameServerCollector reads gauges and counters from the live server state at scrape time
+type gameServerCollector struct {
+	gs *GameServer
+}
+
+func (c *gameServerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- connectionsDesc
+	ch <- roomsDesc
+	ch <- queueSizeDesc
+	ch <- messagesReceivedDesc
+	ch <- messagesSentDesc
+	ch <- droppedSendsDesc
+	ch <- uptimeDesc
+}
+
+func (c *gameServerCollector) Collect(ch chan<- prometheus.Metric) {
+	gs := c.gs
+
+	ch <- prometheus.MustNewConstMetric(connectionsDesc, prometheus.GaugeValue, float64(gs.wsManager.GetActiveConnectionsCount()))
+
+	rooms := gs.roomManager.CountRoomsByState()
+	for _, state := range reportedRoomStates {
+		ch <- prometheus.MustNewConstMetric(roomsDesc, prometheus.GaugeValue, float64(rooms[state]), string(state))
+		delete(rooms, state)
+	}
+	for state, count := range rooms {
+		ch <- prometheus.MustNewConstMetric(roomsDesc, prometheus.GaugeValue, float64(count), string(state))
+	}
+
+	for gameType, size := range gs.matchmaking.QueueSizes() {
+		ch <- prometheus.MustNewConstMetric(queueSizeDesc, prometheus.GaugeValue, float64(size), string(gameType))
+	}
+
+	messages := gs.wsManager.GetMessageStats()
+	ch <- prometheus.MustNewConstMetric(messagesReceivedDesc, prometheus.CounterValue, float64(messages.Received))
+	ch <- prometheus.MustNewConstMetric(messagesSentDesc, prometheus.CounterValue, float64(messages.Sent))
+	ch <- prometheus.MustNewConstMetric(droppedSendsDesc, prometheus.CounterValue, float64(messages.Dropped))
+
+	ch <- prometheus.MustNewConstMetric(uptimeDesc, prometheus.GaugeValue, time.Since(gs.startTime).Seconds())
+}
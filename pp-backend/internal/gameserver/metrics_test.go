@@ -0,0 +1,60 @@
+// internal/gameserver/metrics_test.go
+package gameserver_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_ServedInPrometheusFormat(t *testing.T) {
+	gs, tokenSvc, srv := newReadyCheckTestServer(t, 5*time.Second)
+	ms := gs.GetMatchmakingService()
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+	dave := dialGameSocket(t, srv, tokenSvc, "dave", "/ws/dave")
+	defer dave.Close()
+
+	_, err := gs.GetRoomManager().CreateRoom("carol", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+	_, err = ms.JoinMatchmaking("dave", minigame.GameTypePuzzle, nil)
+	require.NoError(t, err)
+
+	_, err = ms.JoinMatchmaking("alice", minigame.GameTypeMemoryMatch, nil)
+	require.NoError(t, err)
+	_, err = ms.JoinMatchmaking("bob", minigame.GameTypeMemoryMatch, nil)
+	require.NoError(t, err)
+	matchID := acceptMatch(t, alice)
+	require.NoError(t, ms.AcceptMatch("bob", uuid.MustParse(matchID)))
+	readUntil(t, alice, gameserver.MessageTypeMatchFound)
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	metrics := string(body)
+
+	assert.Contains(t, metrics, "gameserver_connections 3")
+	assert.Contains(t, metrics, `gameserver_rooms{state="waiting"} 1`)
+	assert.Contains(t, metrics, `gameserver_rooms{state="ready"} 1`)
+	assert.Contains(t, metrics, `gameserver_rooms{state="in_progress"} 0`)
+	assert.Contains(t, metrics, `gameserver_matchmaking_queue_size{game_type="puzzle"} 1`)
+	assert.Contains(t, metrics, `gameserver_matchmaking_queue_size{game_type="memory_match"} 0`)
+	assert.Contains(t, metrics, `gameserver_matchmaking_wait_seconds_count{game_type="memory_match"} 2`)
+	assert.Regexp(t, `gameserver_messages_received_total [1-9]`, metrics)
+	assert.Contains(t, metrics, "gameserver_messages_dropped_total 0")
+	assert.Regexp(t, `gameserver_messages_sent_total [1-9]`, metrics)
+}
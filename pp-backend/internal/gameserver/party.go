@@ -0,0 +1,340 @@
+// internal/gameserver/party.go
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+)
+
+// Party errors returned by MatchmakingService
+var (
+	ErrPartyNotFound       = errors.New("party not found")
+	ErrNotPartyLeader      = errors.New("only the party leader can do this")
+	ErrAlreadyInParty      = errors.New("already in a party")
+	ErrNotFriends          = errors.New("only friends can be invited")
+	ErrPartyFull           = errors.New("party is full")
+	ErrPartyInviteNotFound = errors.New("party invite not found or expired")
+)
+
+const (
+	maxPartySize   = 4
+	partyInviteTTL = 2 * time.Minute
+)
+
+// Party message types
+const (
+	MessageTypeParty       = "party"        // client -> server: {action: create|invite|accept|decline|leave, username, partyId}
+	MessageTypePartyInvite = "party_invite" // server -> client: an invite from a friend
+	MessageTypePartyUpdate = "party_update" // server -> client: the party's members changed
+)
+
+// FriendLister lists a user's friends
+type FriendLister interface {
+	ListFriends(username string) ([]*repository.User, error)
+}
+
+// Party is a group of friends that queues for matchmaking as one unit
+type Party struct {
+	ID        uuid.UUID            `json:"id"`
+	Leader    string               `json:"leader"`
+	Members   []string             `json:"members"` // The leader is always first
+	Invites   map[string]time.Time `json:"-"`       // invitee -> expiry
+	CreatedAt time.Time            `json:"createdAt"`
+}
+
+// snapshot returns a copy of the party safe to hand out (assumes ms.mu is held)
+func (p *Party) snapshot() *Party {
+	invites := make(map[string]time.Time, len(p.Invites))
+	for username, expiry := range p.Invites {
+		invites[username] = expiry
+	}
+	return &Party{
+		ID:        p.ID,
+		Leader:    p.Leader,
+		Members:   append([]string(nil), p.Members...),
+		Invites:   invites,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+// CreateParty starts a party led by the user
+func (ms *MatchmakingService) CreateParty(leader string) (*Party, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	party, err := ms.createParty(leader)
+	if err != nil {
+		return nil, err
+	}
+	return party.snapshot(), nil
+}
+
+// createParty starts a party (assumes ms.mu is held)
+func (ms *MatchmakingService) createParty(leader string) (*Party, error) {
+	if _, exists := ms.userParties[leader]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInParty, leader)
+	}
+	if _, exists := ms.userRequests[leader]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInQueue, leader)
+	}
+
+	party := &Party{
+		ID:        uuid.New(),
+		Leader:    leader,
+		Members:   []string{leader},
+		Invites:   make(map[string]time.Time),
+		CreatedAt: time.Now(),
+	}
+	ms.parties[party.ID] = party
+	ms.userParties[leader] = party.ID
+	return party, nil
+}
+
+// InviteToParty invites one of the leader's friends, creating the party if the
+// leader is not in one yet
+func (ms *MatchmakingService) InviteToParty(leader, friend string) (*Party, error) {
+	if ms.friends == nil {
+		return nil, fmt.Errorf("%w: friend list unavailable", ErrNotFriends)
+	}
+	friends, err := ms.friends.ListFriends(leader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list friends of %s: %w", leader, err)
+	}
+	isFriend := false
+	for _, user := range friends {
+		if user.Username == friend {
+			isFriend = true
+			break
+		}
+	}
+	if !isFriend {
+		return nil, fmt.Errorf("%w: %s", ErrNotFriends, friend)
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	party := ms.partyOf(leader)
+	if party == nil {
+		if party, err = ms.createParty(leader); err != nil {
+			return nil, err
+		}
+	}
+	if party.Leader != leader {
+		return nil, fmt.Errorf("%w: %s", ErrNotPartyLeader, leader)
+	}
+	if ms.partyQueued(party) {
+		return nil, fmt.Errorf("%w: party is searching for a match", ErrAlreadyInQueue)
+	}
+	if _, exists := ms.userParties[friend]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInParty, friend)
+	}
+	ms.expirePartyInvites(party)
+	if _, invited := party.Invites[friend]; !invited && len(party.Members)+len(party.Invites) >= maxPartySize {
+		return nil, fmt.Errorf("%w: at most %d players", ErrPartyFull, maxPartySize)
+	}
+	if _, connected := ms.wsManager.GetConnection(friend); !connected {
+		return nil, fmt.Errorf("%w: %s", ErrNotConnected, friend)
+	}
+
+	expiresAt := time.Now().Add(partyInviteTTL)
+	party.Invites[friend] = expiresAt
+
+	ms.wsManager.SendToUser(friend, &WebSocketMessage{
+		Type: MessageTypePartyInvite,
+		Data: map[string]interface{}{
+			"partyId":   party.ID.String(),
+			"leader":    party.Leader,
+			"members":   append([]string(nil), party.Members...),
+			"expiresAt": expiresAt,
+		},
+		Timestamp: time.Now(),
+	})
+	ms.broadcastParty(party, "invited", friend)
+
+	return party.snapshot(), nil
+}
+
+// AcceptPartyInvite joins the party the user was invited to
+func (ms *MatchmakingService) AcceptPartyInvite(username string, partyID uuid.UUID) (*Party, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	party, exists := ms.parties[partyID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrPartyNotFound, partyID)
+	}
+	ms.expirePartyInvites(party)
+	if _, invited := party.Invites[username]; !invited {
+		return nil, fmt.Errorf("%w: %s", ErrPartyInviteNotFound, username)
+	}
+	if _, exists := ms.userParties[username]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInParty, username)
+	}
+	if _, exists := ms.userRequests[username]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInQueue, username)
+	}
+	if ms.partyQueued(party) {
+		return nil, fmt.Errorf("%w: party is searching for a match", ErrAlreadyInQueue)
+	}
+	if len(party.Members) >= maxPartySize {
+		return nil, fmt.Errorf("%w: at most %d players", ErrPartyFull, maxPartySize)
+	}
+
+	delete(party.Invites, username)
+	party.Members = append(party.Members, username)
+	ms.userParties[username] = party.ID
+	ms.broadcastParty(party, "joined", username)
+
+	return party.snapshot(), nil
+}
+
+// DeclinePartyInvite turns down an invite
+func (ms *MatchmakingService) DeclinePartyInvite(username string, partyID uuid.UUID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	party, exists := ms.parties[partyID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrPartyNotFound, partyID)
+	}
+	if _, invited := party.Invites[username]; !invited {
+		return fmt.Errorf("%w: %s", ErrPartyInviteNotFound, username)
+	}
+
+	delete(party.Invites, username)
+	ms.broadcastParty(party, "declined", username)
+	return nil
+}
+
+// LeaveParty removes the user from their party, dequeuing the party if it was
+// searching. A leaving leader hands the party to the next member; a party with
+// a single member left is disbanded.
+func (ms *MatchmakingService) LeaveParty(username string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	party := ms.partyOf(username)
+	if party == nil {
+		return fmt.Errorf("%w: %s", ErrPartyNotFound, username)
+	}
+
+	if requestID, exists := ms.userRequests[username]; exists {
+		if request, exists := ms.activeRequests[requestID]; exists {
+			ms.dequeue(request, username)
+		}
+	}
+
+	members := party.Members[:0]
+	for _, member := range party.Members {
+		if member != username {
+			members = append(members, member)
+		}
+	}
+	party.Members = members
+	delete(ms.userParties, username)
+
+	ms.wsManager.SendToUser(username, &WebSocketMessage{
+		Type: MessageTypePartyUpdate,
+		Data: map[string]interface{}{
+			"partyId": party.ID.String(),
+			"event":   "left",
+			"members": []string{},
+		},
+		Timestamp: time.Now(),
+	})
+
+	if len(party.Members) <= 1 {
+		for _, member := range party.Members {
+			delete(ms.userParties, member)
+		}
+		delete(ms.parties, party.ID)
+		ms.broadcastParty(party, "disbanded", username)
+		return nil
+	}
+
+	if party.Leader == username {
+		party.Leader = party.Members[0]
+	}
+	ms.broadcastParty(party, "left", username)
+	return nil
+}
+
+// GetParty returns the user's party
+func (ms *MatchmakingService) GetParty(username string) (*Party, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	party := ms.partyOf(username)
+	if party == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPartyNotFound, username)
+	}
+	return party.snapshot(), nil
+}
+
+// partyOf returns the user's party, if any (assumes ms.mu is held)
+func (ms *MatchmakingService) partyOf(username string) *Party {
+	partyID, exists := ms.userParties[username]
+	if !exists {
+		return nil
+	}
+	return ms.parties[partyID]
+}
+
+// partyMembers returns everyone who queues together with the user
+func (ms *MatchmakingService) partyMembers(username string) []string {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if party := ms.partyOf(username); party != nil {
+		return append([]string(nil), party.Members...)
+	}
+	return []string{username}
+}
+
+// partyQueued reports whether the party is searching for a match (assumes ms.mu is held)
+func (ms *MatchmakingService) partyQueued(party *Party) bool {
+	for _, member := range party.Members {
+		if _, exists := ms.userRequests[member]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// expirePartyInvites drops invites that were not answered in time (assumes ms.mu is held)
+func (ms *MatchmakingService) expirePartyInvites(party *Party) {
+	now := time.Now()
+	for username, expiry := range party.Invites {
+		if now.After(expiry) {
+			delete(party.Invites, username)
+		}
+	}
+}
+
+// broadcastParty tells every member what changed in the party (assumes ms.mu is held)
+func (ms *MatchmakingService) broadcastParty(party *Party, event, username string) {
+	invited := make([]string, 0, len(party.Invites))
+	for invitee := range party.Invites {
+		invited = append(invited, invitee)
+	}
+
+	for _, member := range party.Members {
+		ms.wsManager.SendToUser(member, &WebSocketMessage{
+			Type: MessageTypePartyUpdate,
+			Data: map[string]interface{}{
+				"partyId":  party.ID.String(),
+				"event":    event,
+				"username": username,
+				"leader":   party.Leader,
+				"members":  append([]string(nil), party.Members...),
+				"invited":  invited,
+			},
+			Timestamp: time.Now(),
+		})
+	}
+}
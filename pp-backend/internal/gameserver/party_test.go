@@ -0,0 +1,114 @@
+// internal/gameserver/party_test.go
+package gameserver_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticFriends map[string][]string
+
+func (f staticFriends) ListFriends(username string) ([]*repository.User, error) {
+	var users []*repository.User
+	for _, friend := range f[username] {
+		users = append(users, &repository.User{Username: friend})
+	}
+	return users, nil
+}
+
+func newPartyTestServer(t *testing.T) (*gameserver.GameServer, *service.TokenService, *httptest.Server) {
+	t.Helper()
+	return newSocketTestServer(t, gameserver.GetDefaultConfig(), gameserver.WithFriends(staticFriends{"alice": {"bob"}}))
+}
+
+func TestParty_InviteOnlyFriends(t *testing.T) {
+	gs, tokenSvc, srv := newPartyTestServer(t)
+	ms := gs.GetMatchmakingService()
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+	carol := dialGameSocket(t, srv, tokenSvc, "carol", "/ws/carol")
+	defer carol.Close()
+
+	_, err := ms.InviteToParty("alice", "carol")
+	assert.ErrorIs(t, err, gameserver.ErrNotFriends)
+
+	require.NoError(t, alice.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeParty,
+		"data": map[string]interface{}{"action": "invite", "username": "bob"},
+	}))
+	invite := readUntil(t, bob, gameserver.MessageTypePartyInvite)
+	assert.Equal(t, "alice", invite.Data["leader"])
+
+	require.NoError(t, bob.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeParty,
+		"data": map[string]interface{}{"action": "accept", "partyId": invite.Data["partyId"]},
+	}))
+	update := readUntil(t, alice, gameserver.MessageTypePartyUpdate)
+	for update.Data["event"] != "joined" {
+		update = readUntil(t, alice, gameserver.MessageTypePartyUpdate)
+	}
+	assert.Equal(t, []interface{}{"alice", "bob"}, update.Data["members"])
+
+	_, err = ms.JoinMatchmaking("bob", minigame.GameTypeNumberGuess, nil)
+	assert.ErrorIs(t, err, gameserver.ErrNotPartyLeader)
+}
+
+func TestParty_QueuesAndMatchesAsOneUnit(t *testing.T) {
+	gs, tokenSvc, srv := newPartyTestServer(t)
+	ms := gs.GetMatchmakingService()
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+	carol := dialGameSocket(t, srv, tokenSvc, "carol", "/ws/carol")
+	defer carol.Close()
+
+	party, err := ms.InviteToParty("alice", "bob")
+	require.NoError(t, err)
+	_, err = ms.AcceptPartyInvite("bob", party.ID)
+	require.NoError(t, err)
+
+	// A member leaving the queue dequeues the whole party
+	_, err = ms.JoinMatchmaking("alice", minigame.GameTypeNumberGuess, nil)
+	require.NoError(t, err)
+	searching := readUntil(t, bob, gameserver.MessageTypeMatchmaking)
+	assert.Equal(t, party.ID.String(), searching.Data["partyId"])
+
+	require.NoError(t, ms.LeaveMatchmaking("bob"))
+	cancelled := readUntil(t, alice, gameserver.MessageTypeMatchCancelled)
+	assert.Equal(t, "party_member_left", cancelled.Data["reason"])
+	_, err = ms.GetMatchmakingStatus("alice")
+	assert.ErrorIs(t, err, gameserver.ErrNotInQueue)
+
+	// The party is matched together with a solo player
+	_, err = ms.JoinMatchmaking("alice", minigame.GameTypeNumberGuess, nil)
+	require.NoError(t, err)
+	_, err = ms.JoinMatchmaking("carol", minigame.GameTypeNumberGuess, nil)
+	require.NoError(t, err)
+
+	acceptMatch(t, alice)
+	acceptMatch(t, bob)
+	acceptMatch(t, carol)
+
+	found := readUntil(t, carol, gameserver.MessageTypeMatchFound)
+	assert.Equal(t, float64(3), found.Data["playerCount"])
+	readUntil(t, alice, gameserver.MessageTypeMatchFound)
+	readUntil(t, bob, gameserver.MessageTypeMatchFound)
+
+	aliceRoom, inRoom := gs.GetRoomManager().GetUserRoom("alice")
+	require.True(t, inRoom)
+	bobRoom, inRoom := gs.GetRoomManager().GetUserRoom("bob")
+	require.True(t, inRoom)
+	assert.Equal(t, aliceRoom.ID, bobRoom.ID)
+}
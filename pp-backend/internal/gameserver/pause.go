@@ -0,0 +1,283 @@
+// internal/gameserver/pause.go
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pause errors returned by RoomManager
+var (
+	ErrGamePaused   = errors.New("game is paused")
+	ErrNoPausesLeft = errors.New("no pauses left")
+	ErrAlreadyVoted = errors.New("already voted to abort")
+)
+
+const (
+	// defaultPausesPerPlayer is how many times each player may pause a match
+	defaultPausesPerPlayer = 2
+
+	// defaultMaxPause is how long a pause lasts before the game resumes on its own
+	defaultMaxPause = 60 * time.Second
+)
+
+// GameEndReasonAborted ends a game the players voted to abort. Nobody earns
+// points or rating from it.
+const GameEndReasonAborted = "aborted"
+
+// RoomEventAbortVote reports a player's vote to abort the game
+const RoomEventAbortVote = "abort_vote"
+
+// Message types players send to pause, resume and abort a running game
+const (
+	MessageTypePauseGame  = "pause_game"
+	MessageTypeResumeGame = "resume_game"
+	MessageTypeVoteAbort  = "vote_abort"
+)
+
+// resetPauses clears pause and abort state for a new session (assumes room lock is held)
+func (room *GameRoom) resetPauses() {
+	room.pausedAt = nil
+	room.pausedBy = ""
+	room.pausedTotal = 0
+	room.pausesUsed = make(map[string]int)
+	room.abortVotes = make(map[string]bool)
+}
+
+// isPaused reports whether the session clock is frozen (assumes room lock is held)
+func (room *GameRoom) isPaused() bool {
+	return room.pausedAt != nil
+}
+
+// wakeSessionTimer makes the session timer recompute its deadline
+func (room *GameRoom) wakeSessionTimer() {
+	select {
+	case room.sessionWake <- struct{}{}:
+	default:
+	}
+}
+
+// PauseGame freezes the game clock. Each player has a limited number of pauses per match.
+func (rm *RoomManager) PauseGame(roomID uuid.UUID, username string) error {
+	room, exists := rm.GetRoom(roomID)
+	if !exists {
+		return ErrRoomNotFound
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.State != RoomStateInProgress {
+		return fmt.Errorf("%w: game is not in progress", ErrInvalidRoomState)
+	}
+	if _, exists := room.Players[username]; !exists {
+		if room.isSpectator(username) {
+			return ErrSpectatorCannotAct
+		}
+		return ErrPlayerNotInRoom
+	}
+	if room.isPaused() {
+		return ErrGamePaused
+	}
+	if room.pausesUsed[username] >= rm.pausesPerPlayer {
+		return ErrNoPausesLeft
+	}
+
+	now := time.Now()
+	if !now.Before(room.sessionDeadline()) {
+		rm.finishGame(room, GameEndReasonTimeout)
+		return fmt.Errorf("%w: game time is over", ErrInvalidRoomState)
+	}
+
+	room.pausesUsed[username]++
+	room.pausedAt = &now
+	room.pausedBy = username
+	room.LastActivity = now
+
+	room.emitEvent(&GameRoomEvent{
+		Type:     string(EventTypeGamePause),
+		RoomID:   roomID,
+		Username: username,
+		Data: map[string]interface{}{
+			"pausedBy":    username,
+			"pausesLeft":  rm.pausesPerPlayer - room.pausesUsed[username],
+			"resumesAt":   now.Add(rm.maxPause),
+			"remainingMs": room.sessionDeadline().Sub(now).Milliseconds(),
+		},
+		Timestamp: now,
+	})
+	room.wakeSessionTimer()
+
+	return nil
+}
+
+// ResumeGame restarts the game clock. Only the player who paused or the host may resume early.
+func (rm *RoomManager) ResumeGame(roomID uuid.UUID, username string) error {
+	room, exists := rm.GetRoom(roomID)
+	if !exists {
+		return ErrRoomNotFound
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.State != RoomStateInProgress || !room.isPaused() {
+		return fmt.Errorf("%w: game is not paused", ErrInvalidRoomState)
+	}
+	if _, exists := room.Players[username]; !exists {
+		return ErrPlayerNotInRoom
+	}
+	if username != room.pausedBy && username != room.HostUsername {
+		return fmt.Errorf("%w: only %s or the host can resume", ErrNotHost, room.pausedBy)
+	}
+
+	rm.resumeGame(room, username, time.Now())
+	return nil
+}
+
+// resumeGame unfreezes the clock, pushing the deadline back by the time spent
+// paused. An empty username means the pause ran out. (assumes room lock is held)
+func (rm *RoomManager) resumeGame(room *GameRoom, username string, now time.Time) {
+	if !room.isPaused() {
+		return
+	}
+
+	paused := now.Sub(*room.pausedAt)
+	room.pausedTotal += paused
+	room.pausedAt = nil
+	room.pausedBy = ""
+	room.LastActivity = now
+
+	room.emitEvent(&GameRoomEvent{
+		Type:     string(EventTypeGameResume),
+		RoomID:   room.ID,
+		Username: username,
+		Data: map[string]interface{}{
+			"resumedBy":   username,
+			"pausedMs":    paused.Milliseconds(),
+			"endsAt":      room.sessionDeadline(),
+			"remainingMs": room.sessionDeadline().Sub(now).Milliseconds(),
+		},
+		Timestamp: now,
+	})
+	room.wakeSessionTimer()
+}
+
+// VoteAbort records a player's vote to abort the game. The game ends without
+// rewards once more than half of the human players have voted.
+func (rm *RoomManager) VoteAbort(roomID uuid.UUID, username string) error {
+	room, exists := rm.GetRoom(roomID)
+	if !exists {
+		return ErrRoomNotFound
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.State != RoomStateInProgress {
+		return fmt.Errorf("%w: game is not in progress", ErrInvalidRoomState)
+	}
+	if _, exists := room.Players[username]; !exists {
+		if room.isSpectator(username) {
+			return ErrSpectatorCannotAct
+		}
+		return ErrPlayerNotInRoom
+	}
+	if room.abortVotes[username] {
+		return ErrAlreadyVoted
+	}
+	room.abortVotes[username] = true
+
+	// Votes of players who have since left do not count, and bots never vote
+	votes := 0
+	for voter := range room.abortVotes {
+		if _, exists := room.Players[voter]; exists {
+			votes++
+		}
+	}
+	needed := room.humanCount()/2 + 1
+
+	now := time.Now()
+	room.emitEvent(&GameRoomEvent{
+		Type:      RoomEventAbortVote,
+		RoomID:    roomID,
+		Username:  username,
+		Data:      map[string]interface{}{"username": username, "votes": votes, "needed": needed},
+		Timestamp: now,
+	})
+
+	if votes >= needed {
+		rm.finishGame(room, GameEndReasonAborted)
+	}
+	return nil
+}
+
+// sessionCheckIn returns how long the session timer may sleep before the game
+// has to end or a pause runs out (assumes room lock is held)
+func (rm *RoomManager) sessionCheckIn(room *GameRoom, now time.Time) time.Duration {
+	if room.isPaused() {
+		return room.pausedAt.Add(rm.maxPause).Sub(now)
+	}
+	return room.sessionDeadline().Sub(now)
+}
+
+// handlePauseMessage handles pause_game, resume_game and vote_abort from players
+func (gs *GameServer) handlePauseMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	room, exists := gs.roomManager.GetUserRoom(conn.Username)
+	if !exists {
+		if _, spectating := gs.roomManager.GetSpectatingRoom(conn.Username); spectating {
+			return ErrSpectatorCannotAct
+		}
+		return ErrPlayerNotInRoom
+	}
+
+	switch message.Type {
+	case MessageTypePauseGame:
+		return gs.roomManager.PauseGame(room.ID, conn.Username)
+	case MessageTypeResumeGame:
+		return gs.roomManager.ResumeGame(room.ID, conn.Username)
+	default:
+		return gs.roomManager.VoteAbort(room.ID, conn.Username)
+	}
+}
+
+func (gs *GameServer) handlePauseGame(w http.ResponseWriter, r *http.Request) {
+	gs.handlePlayerControl(w, r, gs.roomManager.PauseGame)
+}
+
+func (gs *GameServer) handleResumeGame(w http.ResponseWriter, r *http.Request) {
+	gs.handlePlayerControl(w, r, gs.roomManager.ResumeGame)
+}
+
+func (gs *GameServer) handleVoteAbort(w http.ResponseWriter, r *http.Request) {
+	gs.handlePlayerControl(w, r, gs.roomManager.VoteAbort)
+}
+
+// handlePlayerControl runs a control a player applies to their running game
+func (gs *GameServer) handlePlayerControl(w http.ResponseWriter, r *http.Request, control func(roomID uuid.UUID, username string) error) {
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := control(roomID, username); err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	room, exists := gs.roomManager.GetRoom(roomID)
+	if !exists {
+		gs.writeRoomError(w, ErrRoomNotFound)
+		return
+	}
+	gs.writeJSONResponse(w, room.GetRoomStats())
+}
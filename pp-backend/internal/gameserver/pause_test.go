@@ -0,0 +1,96 @@
+// internal/gameserver/pause_test.go
+package gameserver_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPause_FreezesGameClock(t *testing.T) {
+	config := gameserver.GetDefaultConfig()
+	config.PausesPerPlayer = 1
+	gs, tokenSvc, srv := newSocketTestServer(t, config)
+	rm := gs.GetRoomManager()
+
+	guest := dialGameSocket(t, srv, tokenSvc, "guest", "/ws/guest")
+	defer guest.Close()
+
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+	room.GameConfig.Duration = 150 * time.Millisecond
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+
+	require.NoError(t, rm.PauseGame(room.ID, "host"))
+	paused := readUntil(t, guest, string(gameserver.EventTypeGamePause))
+	assert.Equal(t, "host", paused.Data["pausedBy"])
+	assert.Equal(t, float64(0), paused.Data["pausesLeft"])
+
+	assert.ErrorIs(t, rm.PauseGame(room.ID, "guest"), gameserver.ErrGamePaused)
+	err := rm.ProcessGameAction(room.ID, "guest", map[string]interface{}{"type": "click"})
+	assert.ErrorIs(t, err, gameserver.ErrGamePaused)
+
+	// The match outlives its duration while paused
+	time.Sleep(300 * time.Millisecond)
+	stats := room.GetRoomStats()
+	assert.Equal(t, gameserver.RoomStateInProgress, stats["state"])
+	assert.Equal(t, true, stats["paused"])
+
+	assert.ErrorIs(t, rm.ResumeGame(room.ID, "guest"), gameserver.ErrNotHost)
+	require.NoError(t, rm.ResumeGame(room.ID, "host"))
+	resumed := readUntil(t, guest, string(gameserver.EventTypeGameResume))
+	assert.Equal(t, "host", resumed.Data["resumedBy"])
+	assert.Greater(t, resumed.Data["remainingMs"].(float64), float64(0))
+
+	require.NoError(t, rm.ProcessGameAction(room.ID, "guest", map[string]interface{}{"type": "click"}))
+	assert.ErrorIs(t, rm.PauseGame(room.ID, "host"), gameserver.ErrNoPausesLeft)
+
+	require.Eventually(t, func() bool {
+		return room.GetRoomStats()["state"] == gameserver.RoomStateCompleted
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPause_ResumesWhenPauseRunsOut(t *testing.T) {
+	config := gameserver.GetDefaultConfig()
+	config.MaxPauseDuration = 100 * time.Millisecond
+	gs := gameserver.NewGameServer(config, minigame.NewMiniGameEngine(nil, nil))
+	rm := gs.GetRoomManager()
+
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+	require.NoError(t, rm.PauseGame(room.ID, "guest"))
+
+	require.Eventually(t, func() bool {
+		return room.GetRoomStats()["paused"] == false
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, gameserver.RoomStateInProgress, room.GetRoomStats()["state"])
+}
+
+func TestVoteAbort_EndsGameWithoutRewards(t *testing.T) {
+	rm := newTestRoomManager(t)
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, rm.ProcessGameAction(room.ID, "host", map[string]interface{}{"type": "click"}))
+	}
+
+	require.NoError(t, rm.VoteAbort(room.ID, "host"))
+	assert.ErrorIs(t, rm.VoteAbort(room.ID, "host"), gameserver.ErrAlreadyVoted)
+	assert.Equal(t, gameserver.RoomStateInProgress, room.GetRoomStats()["state"])
+
+	require.NoError(t, rm.VoteAbort(room.ID, "guest"))
+	assert.Equal(t, gameserver.RoomStateCompleted, room.GetRoomStats()["state"])
+
+	require.NotNil(t, room.Result)
+	assert.Equal(t, gameserver.GameEndReasonAborted, room.Result.EndReason)
+	for _, player := range room.Result.Players {
+		assert.Zero(t, player.PointsEarned)
+		assert.False(t, player.IsValid)
+	}
+
+	assert.ErrorIs(t, rm.VoteAbort(room.ID, "guest"), gameserver.ErrInvalidRoomState)
+}
@@ -0,0 +1,53 @@
+// internal/gameserver/rating.go
+package gameserver
+
+import (
+	"math"
+
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+)
+
+// DefaultSkillRating is the rating used for players without a stored rating
+const DefaultSkillRating = int(service.DefaultRating)
+
+// RatingStore reads and updates the persistent skill ratings used by matchmaking
+type RatingStore interface {
+	SkillRating(username string, gameType minigame.GameType) (int, error)
+	RecordResult(result *RoomResult) error
+}
+
+// serviceRatingStore keeps Glicko-2 ratings through the rating service
+type serviceRatingStore struct {
+	ratings service.RatingService
+}
+
+// NewRatingStore creates a rating store backed by the rating service
+func NewRatingStore(ratings service.RatingService) RatingStore {
+	return &serviceRatingStore{ratings: ratings}
+}
+
+func (s *serviceRatingStore) SkillRating(username string, gameType minigame.GameType) (int, error) {
+	rating, err := s.ratings.GetRating(username, string(gameType))
+	if err != nil {
+		return DefaultSkillRating, err
+	}
+	return int(math.Round(rating.Rating)), nil
+}
+
+// RecordResult rates the human players of a finished room from their final placements
+func (s *serviceRatingStore) RecordResult(result *RoomResult) error {
+	placements := make([]service.RatingPlacement, 0, len(result.Players))
+	for _, player := range result.Players {
+		if player.IsBot {
+			continue
+		}
+		placements = append(placements, service.RatingPlacement{Username: player.Username, Placement: player.Placement})
+	}
+	if len(placements) < 2 {
+		return nil
+	}
+
+	_, err := s.ratings.RecordPlacements(result.RoomID, string(result.GameType), placements)
+	return err
+}
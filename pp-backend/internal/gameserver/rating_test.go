@@ -0,0 +1,90 @@
+// internal/gameserver/rating_test.go
+package gameserver_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryRatingRepo struct {
+	mu      sync.Mutex
+	ratings map[string]*repository.PlayerRating
+	history []*repository.PlayerRatingChange
+}
+
+func newMemoryRatingRepo() *memoryRatingRepo {
+	return &memoryRatingRepo{ratings: make(map[string]*repository.PlayerRating)}
+}
+
+func (r *memoryRatingRepo) GetRating(username, gameType string) (*repository.PlayerRating, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rating, ok := r.ratings[username+"/"+gameType]
+	if !ok {
+		return nil, repository.ErrPlayerRatingNotFound
+	}
+	ratingCopy := *rating
+	return &ratingCopy, nil
+}
+
+func (r *memoryRatingRepo) ListRatings(username string) ([]*repository.PlayerRating, error) {
+	return nil, nil
+}
+
+func (r *memoryRatingRepo) SaveRatingChange(rating *repository.PlayerRating, change *repository.PlayerRatingChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ratings[rating.Username+"/"+rating.GameType] = rating
+	r.history = append(r.history, change)
+	return nil
+}
+
+func (r *memoryRatingRepo) ListRatingHistory(username string, limit int) ([]*repository.PlayerRatingChange, error) {
+	return nil, nil
+}
+
+func (r *memoryRatingRepo) changes() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.history)
+}
+
+func TestRoomManager_EndGameUpdatesRatings(t *testing.T) {
+	repo := newMemoryRatingRepo()
+	ratings := gameserver.NewRatingStore(service.NewRatingService(repo, nil))
+	gs := gameserver.NewGameServer(nil, minigame.NewMiniGameEngine(nil, nil), gameserver.WithRatings(ratings))
+	rm := gs.GetRoomManager()
+
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+
+	click := map[string]interface{}{"type": "click"}
+	for i := 0; i < 12; i++ {
+		require.NoError(t, rm.ProcessGameAction(room.ID, "host", click))
+	}
+	for i := 0; i < 5; i++ {
+		require.NoError(t, rm.ProcessGameAction(room.ID, "guest", click))
+	}
+	require.NoError(t, rm.EndGame(room.ID))
+
+	require.Eventually(t, func() bool { return repo.changes() == 2 }, time.Second, 10*time.Millisecond)
+
+	hostRating, err := ratings.SkillRating("host", minigame.GameTypeClickSpeed)
+	require.NoError(t, err)
+	guestRating, err := ratings.SkillRating("guest", minigame.GameTypeClickSpeed)
+	require.NoError(t, err)
+	assert.Greater(t, hostRating, gameserver.DefaultSkillRating)
+	assert.Less(t, guestRating, gameserver.DefaultSkillRating)
+
+	newcomer, err := ratings.SkillRating("newcomer", minigame.GameTypeClickSpeed)
+	require.NoError(t, err)
+	assert.Equal(t, gameserver.DefaultSkillRating, newcomer)
+}
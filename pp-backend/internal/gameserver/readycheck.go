@@ -0,0 +1,288 @@
+// internal/gameserver/readycheck.go
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+)
+
+// Ready-check errors returned by MatchmakingService
+var (
+	ErrMatchProposalNotFound = errors.New("match proposal not found or expired")
+	ErrMatchmakingPenalty    = errors.New("matchmaking penalty for declining a match")
+)
+
+// Default ready-check timings
+const (
+	defaultMatchAcceptTimeout  = 15 * time.Second
+	defaultMatchDeclinePenalty = 30 * time.Second
+)
+
+// Ready-check message types
+const (
+	MessageTypeMatchReadyCheck  = "match_ready_check"  // server -> client: {matchId, gameType, players, expiresAt, backfill, roomId}
+	MessageTypeMatchAccept      = "match_accept"       // client -> server: {matchId}
+	MessageTypeMatchDecline     = "match_decline"      // client -> server: {matchId}
+	MessageTypeMatchReadyStatus = "match_ready_status" // server -> client: {matchId, accepted, total}
+)
+
+// matchProposal is a match waiting for every player to accept before its room is created
+type matchProposal struct {
+	ID        uuid.UUID
+	GameType  minigame.GameType
+	Players   []*MatchmakingRequest
+	Accepted  map[string]bool
+	ExpiresAt time.Time
+	RoomID    *uuid.UUID // Set when the players backfill an existing room
+	timer     *time.Timer
+}
+
+// proposeMatch asks the matched players to accept before the room is created,
+// or before they take the open seats of roomID when it is set
+func (ms *MatchmakingService) proposeMatch(gameType minigame.GameType, players []*MatchmakingRequest, roomID *uuid.UUID) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	// Players may have left matchmaking since they were taken out of the pool
+	players = ms.stillQueued(players)
+	minPlayers := 1
+	if roomID == nil {
+		rules, err := ms.registry.Get(gameType)
+		if err != nil {
+			ms.requeue(gameType, players)
+			return
+		}
+		minPlayers = rules.MinPlayers
+	}
+	if len(players) < minPlayers {
+		ms.requeue(gameType, players)
+		return
+	}
+
+	proposal := &matchProposal{
+		ID:        uuid.New(),
+		GameType:  gameType,
+		Players:   players,
+		Accepted:  make(map[string]bool, len(players)),
+		ExpiresAt: time.Now().Add(ms.acceptTimeout),
+		RoomID:    roomID,
+	}
+	ms.proposals[proposal.ID] = proposal
+	for _, player := range players {
+		ms.userProposals[player.Username] = proposal.ID
+	}
+	proposal.timer = time.AfterFunc(ms.acceptTimeout, func() {
+		ms.expireProposal(proposal.ID)
+	})
+
+	usernames := make([]string, 0, len(players))
+	for _, player := range players {
+		usernames = append(usernames, player.Username)
+	}
+	for _, player := range players {
+		data := map[string]interface{}{
+			"matchId":      proposal.ID.String(),
+			"gameType":     gameType,
+			"players":      usernames,
+			"averageSkill": ms.calculateAverageSkill(players),
+			"expiresAt":    proposal.ExpiresAt,
+			"backfill":     roomID != nil,
+		}
+		if roomID != nil {
+			data["roomId"] = roomID.String()
+		}
+		ms.wsManager.SendToUser(player.Username, &WebSocketMessage{
+			Type:      MessageTypeMatchReadyCheck,
+			Data:      data,
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+// AcceptMatch confirms the player is ready; the room is created once everyone accepted
+func (ms *MatchmakingService) AcceptMatch(username string, matchID uuid.UUID) error {
+	ms.mu.Lock()
+
+	proposal, err := ms.proposalFor(username, matchID)
+	if err != nil {
+		ms.mu.Unlock()
+		return err
+	}
+
+	proposal.Accepted[username] = true
+	for _, player := range proposal.Players {
+		ms.wsManager.SendToUser(player.Username, &WebSocketMessage{
+			Type: MessageTypeMatchReadyStatus,
+			Data: map[string]interface{}{
+				"matchId":  proposal.ID.String(),
+				"username": username,
+				"accepted": len(proposal.Accepted),
+				"total":    len(proposal.Players),
+			},
+			Timestamp: time.Now(),
+		})
+	}
+
+	if len(proposal.Accepted) < len(proposal.Players) {
+		ms.mu.Unlock()
+		return nil
+	}
+
+	ms.closeProposal(proposal)
+	ms.mu.Unlock()
+
+	if proposal.RoomID != nil {
+		ms.joinBackfillRoom(*proposal.RoomID, proposal.GameType, proposal.Players)
+		return nil
+	}
+	ms.createMatchRoom(proposal.GameType, proposal.Players)
+	return nil
+}
+
+// DeclineMatch turns the match down. The player gets a short queue penalty and
+// everyone else goes back to the front of the queue.
+func (ms *MatchmakingService) DeclineMatch(username string, matchID uuid.UUID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	proposal, err := ms.proposalFor(username, matchID)
+	if err != nil {
+		return err
+	}
+
+	ms.failProposal(proposal, map[string]bool{username: true}, "declined")
+	return nil
+}
+
+// expireProposal fails a proposal that was not accepted by everyone in time
+func (ms *MatchmakingService) expireProposal(matchID uuid.UUID) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	proposal, exists := ms.proposals[matchID]
+	if !exists {
+		return
+	}
+
+	failed := make(map[string]bool)
+	for _, player := range proposal.Players {
+		if !proposal.Accepted[player.Username] {
+			failed[player.Username] = true
+		}
+	}
+	ms.failProposal(proposal, failed, "accept_timeout")
+}
+
+// proposalFor returns the pending match the player was offered (assumes ms.mu is held)
+func (ms *MatchmakingService) proposalFor(username string, matchID uuid.UUID) (*matchProposal, error) {
+	proposalID, exists := ms.userProposals[username]
+	if !exists || proposalID != matchID {
+		return nil, fmt.Errorf("%w: %s", ErrMatchProposalNotFound, matchID)
+	}
+	proposal, exists := ms.proposals[proposalID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrMatchProposalNotFound, matchID)
+	}
+	return proposal, nil
+}
+
+// closeProposal forgets a proposal and stops its timer (assumes ms.mu is held)
+func (ms *MatchmakingService) closeProposal(proposal *matchProposal) {
+	proposal.timer.Stop()
+	delete(ms.proposals, proposal.ID)
+	for _, player := range proposal.Players {
+		if ms.userProposals[player.Username] == proposal.ID {
+			delete(ms.userProposals, player.Username)
+		}
+	}
+}
+
+// failProposal penalizes the players who did not accept and puts everyone else
+// back at the front of the queue with their original wait time. A party is only
+// requeued when all of its members are still in. (assumes ms.mu is held)
+func (ms *MatchmakingService) failProposal(proposal *matchProposal, failed map[string]bool, reason string) {
+	ms.closeProposal(proposal)
+
+	failedParties := make(map[uuid.UUID]bool)
+	for _, player := range proposal.Players {
+		if failed[player.Username] && player.PartyID != nil {
+			failedParties[*player.PartyID] = true
+		}
+	}
+
+	penaltyUntil := time.Now().Add(ms.declinePenalty)
+	var requeued []*MatchmakingRequest
+	for _, player := range proposal.Players {
+		switch {
+		case failed[player.Username]:
+			ms.penalties[player.Username] = penaltyUntil
+			delete(ms.activeRequests, player.ID)
+			delete(ms.userRequests, player.Username)
+			ms.wsManager.SendToUser(player.Username, &WebSocketMessage{
+				Type: MessageTypeMatchCancelled,
+				Data: map[string]interface{}{
+					"reason":       reason,
+					"matchId":      proposal.ID.String(),
+					"penaltyUntil": penaltyUntil,
+				},
+				Timestamp: time.Now(),
+			})
+		case player.PartyID != nil && failedParties[*player.PartyID]:
+			delete(ms.activeRequests, player.ID)
+			delete(ms.userRequests, player.Username)
+			ms.wsManager.SendToUser(player.Username, &WebSocketMessage{
+				Type: MessageTypeMatchCancelled,
+				Data: map[string]interface{}{
+					"reason":  "party_member_" + reason,
+					"matchId": proposal.ID.String(),
+				},
+				Timestamp: time.Now(),
+			})
+		default:
+			requeued = append(requeued, player)
+			ms.wsManager.SendToUser(player.Username, &WebSocketMessage{
+				Type: MessageTypeMatchmaking,
+				Data: map[string]interface{}{
+					"status":    "searching",
+					"requestId": player.ID.String(),
+					"gameType":  player.GameType,
+					"requeued":  true,
+					"reason":    reason,
+					"waitTime":  time.Since(player.CreatedAt).Seconds(),
+				},
+				Timestamp: time.Now(),
+			})
+		}
+	}
+
+	ms.requeueFront(proposal.GameType, ms.stillQueued(requeued))
+}
+
+// requeueFront puts requests back at the head of their pool, keeping their original wait time
+func (ms *MatchmakingService) requeueFront(gameType minigame.GameType, players []*MatchmakingRequest) {
+	pool, exists := ms.pools[gameType]
+	if !exists || len(players) == 0 {
+		return
+	}
+
+	pool.mu.Lock()
+	pool.requests = append(append([]*MatchmakingRequest(nil), players...), pool.requests...)
+	pool.mu.Unlock()
+}
+
+// penalized reports whether the user is serving a penalty for declining a match (assumes ms.mu is held)
+func (ms *MatchmakingService) penalized(username string) bool {
+	until, exists := ms.penalties[username]
+	if !exists {
+		return false
+	}
+	if time.Now().After(until) {
+		delete(ms.penalties, username)
+		return false
+	}
+	return true
+}
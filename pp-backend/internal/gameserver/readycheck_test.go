@@ -0,0 +1,114 @@
+// internal/gameserver/readycheck_test.go
+package gameserver_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReadyCheckTestServer(t *testing.T, acceptTimeout time.Duration) (*gameserver.GameServer, *service.TokenService, *httptest.Server) {
+	t.Helper()
+	config := gameserver.GetDefaultConfig()
+	config.MatchAcceptTimeout = acceptTimeout
+	config.MatchDeclinePenalty = time.Minute
+	return newSocketTestServer(t, config)
+}
+
+func TestReadyCheck_AcceptedPlayersStartReady(t *testing.T) {
+	gs, tokenSvc, srv := newReadyCheckTestServer(t, 5*time.Second)
+	ms := gs.GetMatchmakingService()
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+
+	_, err := ms.JoinMatchmaking("alice", minigame.GameTypeMemoryMatch, nil)
+	require.NoError(t, err)
+	_, err = ms.JoinMatchmaking("bob", minigame.GameTypeMemoryMatch, nil)
+	require.NoError(t, err)
+
+	matchID := acceptMatch(t, alice)
+	status := readUntil(t, bob, gameserver.MessageTypeMatchReadyStatus)
+	assert.Equal(t, float64(1), status.Data["accepted"])
+	_, inRoom := gs.GetRoomManager().GetUserRoom("alice")
+	assert.False(t, inRoom)
+
+	require.NoError(t, ms.AcceptMatch("bob", uuid.MustParse(matchID)))
+	readUntil(t, alice, gameserver.MessageTypeMatchFound)
+
+	room, inRoom := gs.GetRoomManager().GetUserRoom("alice")
+	require.True(t, inRoom)
+	assert.Equal(t, gameserver.RoomStateReady, room.State)
+}
+
+func TestReadyCheck_TimeoutPenalizesAndRequeues(t *testing.T) {
+	gs, tokenSvc, srv := newReadyCheckTestServer(t, 300*time.Millisecond)
+	ms := gs.GetMatchmakingService()
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+
+	request, err := ms.JoinMatchmaking("alice", minigame.GameTypeMemoryMatch, nil)
+	require.NoError(t, err)
+	_, err = ms.JoinMatchmaking("bob", minigame.GameTypeMemoryMatch, nil)
+	require.NoError(t, err)
+
+	acceptMatch(t, alice)
+	readUntil(t, bob, gameserver.MessageTypeMatchReadyCheck)
+
+	cancelled := readUntil(t, bob, gameserver.MessageTypeMatchCancelled)
+	assert.Equal(t, "accept_timeout", cancelled.Data["reason"])
+	requeued := readUntil(t, alice, gameserver.MessageTypeMatchmaking)
+	for requeued.Data["requeued"] != true {
+		requeued = readUntil(t, alice, gameserver.MessageTypeMatchmaking)
+	}
+
+	status, err := ms.GetMatchmakingStatus("alice")
+	require.NoError(t, err)
+	assert.Equal(t, request.ID.String(), status["requestId"])
+	assert.GreaterOrEqual(t, status["waitTime"].(float64), time.Since(request.CreatedAt).Seconds()-0.1)
+
+	_, err = ms.JoinMatchmaking("bob", minigame.GameTypeMemoryMatch, nil)
+	assert.ErrorIs(t, err, gameserver.ErrMatchmakingPenalty)
+}
+
+func TestReadyCheck_DeclineEndsTheMatch(t *testing.T) {
+	gs, tokenSvc, srv := newReadyCheckTestServer(t, 5*time.Second)
+	ms := gs.GetMatchmakingService()
+
+	alice := dialGameSocket(t, srv, tokenSvc, "alice", "/ws/alice")
+	defer alice.Close()
+	bob := dialGameSocket(t, srv, tokenSvc, "bob", "/ws/bob")
+	defer bob.Close()
+
+	_, err := ms.JoinMatchmaking("alice", minigame.GameTypeMemoryMatch, nil)
+	require.NoError(t, err)
+	_, err = ms.JoinMatchmaking("bob", minigame.GameTypeMemoryMatch, nil)
+	require.NoError(t, err)
+
+	check := readUntil(t, bob, gameserver.MessageTypeMatchReadyCheck)
+	require.NoError(t, bob.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeMatchDecline,
+		"data": map[string]interface{}{"matchId": check.Data["matchId"]},
+	}))
+
+	cancelled := readUntil(t, bob, gameserver.MessageTypeMatchCancelled)
+	assert.Equal(t, "declined", cancelled.Data["reason"])
+
+	_, err = ms.GetMatchmakingStatus("alice")
+	assert.NoError(t, err)
+	_, err = ms.GetMatchmakingStatus("bob")
+	assert.ErrorIs(t, err, gameserver.ErrNotInQueue)
+	assert.ErrorIs(t, ms.AcceptMatch("alice", uuid.MustParse(check.Data["matchId"].(string))), gameserver.ErrMatchProposalNotFound)
+}
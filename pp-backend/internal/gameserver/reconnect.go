@@ -0,0 +1,231 @@
+// internal/gameserver/reconnect.go
+package gameserver
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidResumeToken is returned when a resume token does not match the player's session
+var ErrInvalidResumeToken = errors.New("invalid resume token")
+
+const (
+	// defaultReconnectGrace is how long a dropped player keeps their seat
+	defaultReconnectGrace = 30 * time.Second
+
+	// roomEventLogSize is the number of recent room broadcasts kept for replay
+	roomEventLogSize = 256
+)
+
+// Message types for session resumption
+const (
+	MessageTypeResume      = "resume"       // client -> server: {"token": "...", "lastSeq": 42}
+	MessageTypeResumed     = "resumed"      // server -> client: room snapshot after replay
+	MessageTypeResumeToken = "resume_token" // server -> client: token to use after a drop
+)
+
+// newResumeToken creates a random token identifying a player's seat in a room
+func newResumeToken() string {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return uuid.NewString()
+	}
+	return hex.EncodeToString(buf)
+}
+
+// ResumeToken returns the current resume token of a player in a room
+func (rm *RoomManager) ResumeToken(roomID uuid.UUID, username string) (string, bool) {
+	room, exists := rm.GetRoom(roomID)
+	if !exists {
+		return "", false
+	}
+
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+
+	player, exists := room.Players[username]
+	if !exists {
+		return "", false
+	}
+	return player.resumeToken, true
+}
+
+// PlayerDisconnected marks a player as dropped and starts the reconnect grace window.
+// Players that do not resume within the window forfeit and leave the room.
+func (rm *RoomManager) PlayerDisconnected(username string) {
+	room, exists := rm.GetUserRoom(username)
+	if !exists {
+		return
+	}
+
+	room.mu.Lock()
+	player, exists := room.Players[username]
+	if !exists || !player.Connected {
+		room.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	player.Connected = false
+	player.DisconnectedAt = &now
+	room.LastActivity = now
+
+	room.emitEvent(&GameRoomEvent{
+		Type:     RoomEventPlayerDisconnected,
+		RoomID:   room.ID,
+		Username: username,
+		Data: map[string]interface{}{
+			"graceMs":    rm.reconnectGrace.Milliseconds(),
+			"forfeitsAt": now.Add(rm.reconnectGrace),
+		},
+		Timestamp: now,
+	})
+	room.mu.Unlock()
+
+	go rm.forfeitAfterGrace(room, username, now)
+}
+
+// forfeitAfterGrace removes a player who has not resumed by the end of the grace window
+func (rm *RoomManager) forfeitAfterGrace(room *GameRoom, username string, disconnectedAt time.Time) {
+	timer := time.NewTimer(rm.reconnectGrace)
+	defer timer.Stop()
+
+	select {
+	case <-room.ctx.Done():
+		return
+	case <-timer.C:
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if _, exists := rm.rooms[room.ID]; !exists {
+		return
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	player, exists := room.Players[username]
+	if !exists || player.Connected || player.DisconnectedAt == nil || !player.DisconnectedAt.Equal(disconnectedAt) {
+		return
+	}
+
+	room.emitEvent(&GameRoomEvent{
+		Type:      RoomEventPlayerForfeited,
+		RoomID:    room.ID,
+		Username:  username,
+		Data:      map[string]interface{}{"state": room.State},
+		Timestamp: time.Now(),
+	})
+
+	rm.removePlayer(room, username)
+}
+
+// ResumePlayer restores a dropped player's seat, re-subscribes their connection
+// to room broadcasts and rotates the resume token
+func (rm *RoomManager) ResumePlayer(username, token string) (*GameRoom, string, error) {
+	room, exists := rm.GetUserRoom(username)
+	if !exists {
+		return nil, "", ErrPlayerNotInRoom
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	player, exists := room.Players[username]
+	if !exists {
+		return nil, "", ErrPlayerNotInRoom
+	}
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(player.resumeToken)) != 1 {
+		return nil, "", ErrInvalidResumeToken
+	}
+
+	wasDisconnected := !player.Connected
+	player.Connected = true
+	player.DisconnectedAt = nil
+	player.resumeToken = newResumeToken()
+	room.LastActivity = time.Now()
+
+	if wasDisconnected {
+		room.emitEvent(&GameRoomEvent{
+			Type:      RoomEventPlayerReconnected,
+			RoomID:    room.ID,
+			Username:  username,
+			Data:      map[string]interface{}{},
+			Timestamp: time.Now(),
+		})
+	}
+
+	rm.attachConnection(username, room.ID)
+
+	return room, player.resumeToken, nil
+}
+
+// sendResumeToken delivers a player's resume token over their socket, if connected
+func (rm *RoomManager) sendResumeToken(username string, roomID uuid.UUID, token string) {
+	if rm.wsManager == nil {
+		return
+	}
+	rm.wsManager.SendToUser(username, &WebSocketMessage{
+		Type: MessageTypeResumeToken,
+		Data: map[string]interface{}{
+			"roomId":      roomID,
+			"resumeToken": token,
+		},
+		Timestamp: time.Now(),
+		RoomID:    &roomID,
+	})
+}
+
+// recordEvent numbers a room broadcast and keeps it for replay
+func (room *GameRoom) recordEvent(message *WebSocketMessage) {
+	room.logMu.Lock()
+	defer room.logMu.Unlock()
+
+	room.eventSeq++
+	message.Seq = room.eventSeq
+
+	room.eventLog = append(room.eventLog, message)
+	if len(room.eventLog) > roomEventLogSize {
+		room.eventLog = room.eventLog[len(room.eventLog)-roomEventLogSize:]
+	}
+}
+
+// EventsSince returns the recorded broadcasts after the given sequence number.
+// truncated reports whether older events have already been dropped from the log.
+func (room *GameRoom) EventsSince(seq int64) (events []*WebSocketMessage, truncated bool) {
+	room.logMu.Lock()
+	defer room.logMu.Unlock()
+
+	if len(room.eventLog) > 0 && room.eventLog[0].Seq > seq+1 {
+		truncated = true
+	}
+	for _, message := range room.eventLog {
+		if message.Seq > seq {
+			events = append(events, message)
+		}
+	}
+	return events, truncated
+}
+
+// LastEventSeq returns the sequence number of the latest room broadcast
+func (room *GameRoom) LastEventSeq() int64 {
+	room.logMu.Lock()
+	defer room.logMu.Unlock()
+
+	return room.eventSeq
+}
+
+// StateSnapshot returns the authoritative game state of the room
+func (room *GameRoom) StateSnapshot() map[string]interface{} {
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+
+	return room.sessionSnapshot(time.Now())
+}
@@ -0,0 +1,144 @@
+// internal/gameserver/reconnect_test.go
+package gameserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReconnectTestServer(t *testing.T, grace time.Duration) (*gameserver.GameServer, *service.TokenService, *httptest.Server) {
+	t.Helper()
+	config := gameserver.GetDefaultConfig()
+	config.ReconnectGracePeriod = grace
+	return newSocketTestServer(t, config)
+}
+
+func playerConnected(room *gameserver.GameRoom, username string) (bool, bool) {
+	players := room.GetRoomStats()["players"].(map[string]interface{})
+	player, ok := players[username].(map[string]interface{})
+	if !ok {
+		return false, false
+	}
+	return player["connected"].(bool), true
+}
+
+func TestRoomManager_ResumeWithinGrace(t *testing.T) {
+	gs, _, _ := newReconnectTestServer(t, time.Minute)
+	rm := gs.GetRoomManager()
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "guest", "")
+	require.NoError(t, err)
+
+	token, ok := rm.ResumeToken(room.ID, "guest")
+	require.True(t, ok)
+
+	rm.PlayerDisconnected("guest")
+	connected, _ := playerConnected(room, "guest")
+	assert.False(t, connected)
+
+	_, _, err = rm.ResumePlayer("guest", "wrong")
+	assert.ErrorIs(t, err, gameserver.ErrInvalidResumeToken)
+
+	resumed, newToken, err := rm.ResumePlayer("guest", token)
+	require.NoError(t, err)
+	assert.Equal(t, room.ID, resumed.ID)
+	assert.NotEqual(t, token, newToken)
+
+	connected, _ = playerConnected(room, "guest")
+	assert.True(t, connected)
+
+	// The old token is single-use
+	_, _, err = rm.ResumePlayer("guest", token)
+	assert.ErrorIs(t, err, gameserver.ErrInvalidResumeToken)
+
+	require.Eventually(t, func() bool { return room.LastEventSeq() >= 3 }, time.Second, 10*time.Millisecond)
+	events, truncated := room.EventsSince(1)
+	assert.False(t, truncated)
+	require.Len(t, events, 2)
+	assert.Equal(t, gameserver.RoomEventPlayerDisconnected, events[0].Type)
+	assert.Equal(t, gameserver.RoomEventPlayerReconnected, events[1].Type)
+	assert.Equal(t, int64(3), events[1].Seq)
+}
+
+func TestRoomManager_ForfeitAfterGrace(t *testing.T) {
+	gs, _, _ := newReconnectTestServer(t, 50*time.Millisecond)
+	rm := gs.GetRoomManager()
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "guest", "")
+	require.NoError(t, err)
+
+	rm.PlayerDisconnected("guest")
+
+	require.Eventually(t, func() bool {
+		_, inRoom := rm.GetUserRoom("guest")
+		return !inRoom
+	}, time.Second, 10*time.Millisecond)
+
+	_, present := playerConnected(room, "guest")
+	assert.False(t, present)
+}
+
+func TestGameServer_ResumeOverWebSocket(t *testing.T) {
+	gs, tokenSvc, srv := newReconnectTestServer(t, time.Minute)
+	rm := gs.GetRoomManager()
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "guest", "")
+	require.NoError(t, err)
+	resumeToken, _ := rm.ResumeToken(room.ID, "guest")
+	require.Eventually(t, func() bool { return room.LastEventSeq() >= 1 }, time.Second, 10*time.Millisecond)
+
+	accessToken, err := tokenSvc.CreateAccessToken("guest", "user")
+	require.NoError(t, err)
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+accessToken)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws/guest", header)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(map[string]interface{}{
+		"type": gameserver.MessageTypeResume,
+		"data": map[string]interface{}{"token": resumeToken, "lastSeq": 0},
+	}))
+
+	var received []gameserver.WebSocketMessage
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for len(received) == 0 || received[len(received)-1].Type != gameserver.MessageTypeResumed {
+		_, frame, err := conn.ReadMessage()
+		require.NoError(t, err)
+		for _, line := range bytes.Split(frame, []byte{'\n'}) {
+			var message gameserver.WebSocketMessage
+			require.NoError(t, json.Unmarshal(line, &message))
+			received = append(received, message)
+		}
+	}
+
+	var types []string
+	for _, message := range received {
+		types = append(types, message.Type)
+	}
+	assert.Contains(t, types, gameserver.RoomEventPlayerJoined)
+
+	resumed := received[len(received)-1]
+	assert.NotEmpty(t, resumed.Data["resumeToken"])
+	assert.NotEqual(t, resumeToken, resumed.Data["resumeToken"])
+	assert.NotNil(t, resumed.Data["room"])
+}
@@ -0,0 +1,219 @@
+// internal/gameserver/replay.go
+package gameserver
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+)
+
+// ErrReplayNotFound is returned when no replay was recorded for a room
+var ErrReplayNotFound = errors.New("replay not found")
+
+// maxReplayEntries caps the entries recorded per room so a runaway room cannot exhaust memory
+const maxReplayEntries = 50000
+
+// Kinds of replay entries
+const (
+	ReplayEntryEvent  = "event"  // Room broadcast, as sent to players
+	ReplayEntryAction = "action" // Raw player input, accepted or rejected
+	ReplayEntryServer = "server" // Server-side record never broadcast, e.g. the hidden session seed
+)
+
+// Server-side replay entry types
+const (
+	ReplayRoomCreated    = "room_created"
+	ReplaySessionStarted = "session_started"
+)
+
+// ReplayEntry is one step of a room replay
+type ReplayEntry struct {
+	OffsetMs  int64           `json:"offsetMs"` // Relative to the game start, negative before it
+	Kind      string          `json:"kind"`
+	Type      string          `json:"type"`
+	Username  string          `json:"username,omitempty"`
+	Data      json.RawMessage `json:"data"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// Replay is the full ordered record of a closed room
+type Replay struct {
+	RoomID       uuid.UUID      `json:"roomId"`
+	Name         string         `json:"name"`
+	GameType     string         `json:"gameType"`
+	HostUsername string         `json:"hostUsername"`
+	IsPrivate    bool           `json:"isPrivate"`
+	Players      []string       `json:"players"`
+	StartTime    *time.Time     `json:"startTime,omitempty"`
+	EndTime      *time.Time     `json:"endTime,omitempty"`
+	ClosedAt     time.Time      `json:"closedAt"`
+	Result       *RoomResult    `json:"result,omitempty"`
+	Truncated    bool           `json:"truncated"`
+	Entries      []*ReplayEntry `json:"entries"`
+}
+
+// HasPlayer reports whether a user played in the recorded room
+func (r *Replay) HasPlayer(username string) bool {
+	for _, player := range r.Players {
+		if player == username {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplayStore persists replays of closed rooms
+type ReplayStore interface {
+	SaveReplay(replay *Replay) error
+	GetReplay(roomID uuid.UUID) (*Replay, error)
+}
+
+// repositoryReplayStore stores replays in the match_replays table
+type repositoryReplayStore struct {
+	repo repository.MatchReplayRepository
+}
+
+// NewReplayStore creates a replay store backed by a match replay repository
+func NewReplayStore(repo repository.MatchReplayRepository) ReplayStore {
+	return &repositoryReplayStore{repo: repo}
+}
+
+// SaveReplay writes a replay to the repository
+func (s *repositoryReplayStore) SaveReplay(replay *Replay) error {
+	data, err := json.Marshal(replay)
+	if err != nil {
+		return fmt.Errorf("failed to encode replay: %w", err)
+	}
+
+	record := &repository.MatchReplay{
+		RoomID:       replay.RoomID,
+		GameType:     replay.GameType,
+		HostUsername: replay.HostUsername,
+		IsPrivate:    replay.IsPrivate,
+		Participants: replay.Players,
+		Replay:       data,
+		ClosedAt:     replay.ClosedAt,
+	}
+	if replay.StartTime != nil {
+		record.StartedAt = sql.NullTime{Time: *replay.StartTime, Valid: true}
+	}
+	if replay.EndTime != nil {
+		record.EndedAt = sql.NullTime{Time: *replay.EndTime, Valid: true}
+	}
+
+	return s.repo.CreateReplay(record)
+}
+
+// GetReplay loads a replay from the repository
+func (s *repositoryReplayStore) GetReplay(roomID uuid.UUID) (*Replay, error) {
+	record, err := s.repo.GetReplay(roomID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMatchReplayNotFound) {
+			return nil, ErrReplayNotFound
+		}
+		return nil, err
+	}
+
+	var replay Replay
+	if err := json.Unmarshal(record.Replay, &replay); err != nil {
+		return nil, fmt.Errorf("failed to decode replay: %w", err)
+	}
+	return &replay, nil
+}
+
+// GetReplay returns the recorded replay of a closed room
+func (rm *RoomManager) GetReplay(roomID uuid.UUID) (*Replay, error) {
+	if _, exists := rm.GetRoom(roomID); exists {
+		return nil, fmt.Errorf("%w: replay is available once the room closes", ErrInvalidRoomState)
+	}
+	if rm.replays == nil {
+		return nil, ErrReplayNotFound
+	}
+	return rm.replays.GetReplay(roomID)
+}
+
+// saveReplay persists a closed room's replay outside the manager lock
+func (rm *RoomManager) saveReplay(replay *Replay) {
+	if err := rm.replays.SaveReplay(replay); err != nil {
+		log.Printf("Failed to save replay for room %s: %v", replay.RoomID, err)
+	}
+}
+
+// recordReplay appends an entry to the room's replay. Data is encoded right
+// away so later changes to shared maps do not leak into the record.
+func (room *GameRoom) recordReplay(kind, entryType, username string, data interface{}, timestamp time.Time) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Failed to encode replay entry %s for room %s: %v", entryType, room.ID, err)
+		encoded = json.RawMessage("null")
+	}
+
+	room.replayMu.Lock()
+	defer room.replayMu.Unlock()
+
+	if len(room.replayLog) >= maxReplayEntries {
+		room.replayTruncated = true
+		return
+	}
+	room.replayLog = append(room.replayLog, &ReplayEntry{
+		Kind:      kind,
+		Type:      entryType,
+		Username:  username,
+		Data:      encoded,
+		Timestamp: timestamp,
+	})
+}
+
+// recordAction records a player's raw game input and whether the rules accepted it
+func (room *GameRoom) recordAction(username string, action map[string]interface{}, actionErr error, timestamp time.Time) {
+	data := map[string]interface{}{
+		"action":   action,
+		"accepted": actionErr == nil,
+	}
+	if actionErr != nil {
+		data["error"] = actionErr.Error()
+	}
+
+	actionType, _ := action["type"].(string)
+	room.recordReplay(ReplayEntryAction, actionType, username, data, timestamp)
+}
+
+// buildReplay assembles the room's replay with offsets relative to the game
+// start, or to the room creation when no game was started (assumes room lock is held)
+func (room *GameRoom) buildReplay(closedAt time.Time) *Replay {
+	base := room.CreatedAt
+	if room.StartTime != nil {
+		base = *room.StartTime
+	}
+
+	room.replayMu.Lock()
+	defer room.replayMu.Unlock()
+
+	entries := make([]*ReplayEntry, len(room.replayLog))
+	for i, entry := range room.replayLog {
+		copied := *entry
+		copied.OffsetMs = entry.Timestamp.Sub(base).Milliseconds()
+		entries[i] = &copied
+	}
+
+	return &Replay{
+		RoomID:       room.ID,
+		Name:         room.Name,
+		GameType:     string(room.GameType),
+		HostUsername: room.HostUsername,
+		IsPrivate:    room.IsPrivate,
+		Players:      append([]string(nil), room.participants...),
+		StartTime:    room.StartTime,
+		EndTime:      room.EndTime,
+		ClosedAt:     closedAt,
+		Result:       room.Result,
+		Truncated:    room.replayTruncated,
+		Entries:      entries,
+	}
+}
@@ -0,0 +1,109 @@
+// internal/gameserver/replay_test.go
+package gameserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryReplayStore struct {
+	mu      sync.Mutex
+	replays map[uuid.UUID]*gameserver.Replay
+}
+
+func (s *memoryReplayStore) SaveReplay(replay *gameserver.Replay) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replays[replay.RoomID] = replay
+	return nil
+}
+
+func (s *memoryReplayStore) GetReplay(roomID uuid.UUID) (*gameserver.Replay, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if replay, ok := s.replays[roomID]; ok {
+		return replay, nil
+	}
+	return nil, gameserver.ErrReplayNotFound
+}
+
+func TestGameServer_ReplayRecordedOnClose(t *testing.T) {
+	store := &memoryReplayStore{replays: make(map[uuid.UUID]*gameserver.Replay)}
+	gs, tokenSvc, _ := newSocketTestServer(t, nil, gameserver.WithReplayStore(store))
+	rm := gs.GetRoomManager()
+
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+	require.NoError(t, rm.ProcessGameAction(room.ID, "host", map[string]interface{}{"type": "click"}))
+	assert.ErrorIs(t, rm.ProcessGameAction(room.ID, "guest", map[string]interface{}{}), gameserver.ErrInvalidGameAction)
+
+	token, err := tokenSvc.CreateAccessToken("guest", "user")
+	require.NoError(t, err)
+	auth := map[string]string{"Authorization": "Bearer " + token}
+	path := "/api/v1/rooms/" + room.ID.String() + "/replay"
+
+	rec, envelope := doRequest(t, gs, "GET", path, "", auth)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "WRONG_STATE", envelope.Error.Code)
+
+	require.NoError(t, rm.EndGame(room.ID))
+	require.NoError(t, rm.LeaveRoom(room.ID, "guest"))
+	require.NoError(t, rm.LeaveRoom(room.ID, "host"))
+
+	require.Eventually(t, func() bool {
+		_, err := store.GetReplay(room.ID)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	rec, envelope = doRequest(t, gs, "GET", path, "", auth)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	raw, err := json.Marshal(envelope.Data)
+	require.NoError(t, err)
+	var replay gameserver.Replay
+	require.NoError(t, json.Unmarshal(raw, &replay))
+
+	assert.ElementsMatch(t, []string{"host", "guest"}, replay.Players)
+	require.NotNil(t, replay.Result)
+	assert.Equal(t, "host", replay.Result.Players[0].Username)
+
+	var types []string
+	var actions []*gameserver.ReplayEntry
+	for _, entry := range replay.Entries {
+		types = append(types, entry.Type)
+		if entry.Kind == gameserver.ReplayEntryAction {
+			actions = append(actions, entry)
+		}
+	}
+	assert.Equal(t, gameserver.ReplayRoomCreated, types[0])
+	assert.Equal(t, gameserver.RoomEventRoomClosed, types[len(types)-1])
+	assert.Contains(t, types, gameserver.ReplaySessionStarted)
+	assert.Contains(t, types, gameserver.RoomEventGameEnded)
+
+	// Offsets are relative to the game start, so pre-game entries are negative
+	assert.LessOrEqual(t, replay.Entries[0].OffsetMs, int64(0))
+	for _, entry := range replay.Entries {
+		if entry.Type == gameserver.RoomEventGameStarted {
+			assert.Equal(t, int64(0), entry.OffsetMs)
+		}
+	}
+
+	require.Len(t, actions, 2)
+	assert.Equal(t, "host", actions[0].Username)
+	assert.JSONEq(t, `{"action":{"type":"click"},"accepted":true}`, string(actions[0].Data))
+	assert.Equal(t, "guest", actions[1].Username)
+	assert.Contains(t, string(actions[1].Data), `"accepted":false`)
+
+	rec, envelope = doRequest(t, gs, "GET", "/api/v1/rooms/"+uuid.NewString()+"/replay", "", auth)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "REPLAY_NOT_FOUND", envelope.Error.Code)
+}
@@ -3,7 +3,9 @@ package gameserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"sync"
 	"time"
 
@@ -22,15 +24,33 @@ const (
 	RoomStateClosed     GameRoomState = "closed"     // Room closed
 )
 
+// Room errors returned by RoomManager
+var (
+	ErrRoomNotFound        = errors.New("room not found")
+	ErrRoomFull            = errors.New("room is full")
+	ErrIncorrectPassword   = errors.New("incorrect password")
+	ErrNotHost             = errors.New("only host can perform this action")
+	ErrInvalidRoomState    = errors.New("room is not in a valid state for this action")
+	ErrAlreadyInRoom       = errors.New("user is already in a room")
+	ErrPlayerNotInRoom     = errors.New("player not in room")
+	ErrUnsupportedGameType = errors.New("unsupported game type")
+)
+
 // Player represents a player in a game room
 type Player struct {
 	Username     string            `json:"username"`
 	IsReady      bool              `json:"isReady"`
 	IsHost       bool              `json:"isHost"`
+	Connected    bool              `json:"connected"`
+	DisconnectedAt *time.Time      `json:"disconnectedAt,omitempty"`
 	Score        int               `json:"score"`
 	LastAction   *time.Time        `json:"lastAction,omitempty"`
 	GameData     map[string]interface{} `json:"gameData"`
+	IsBot        bool                   `json:"isBot"`
+	BotDifficulty BotDifficulty         `json:"botDifficulty,omitempty"`
 	Connection   *WebSocketConnection   `json:"-"`
+	session      *minigame.GameState    `json:"-"` // Player's view of the room session
+	resumeToken  string                 `json:"-"`
 	mu           sync.RWMutex           `json:"-"`
 }
 
@@ -41,22 +61,50 @@ type GameRoom struct {
 	GameType        minigame.GameType        `json:"gameType"`
 	State           GameRoomState            `json:"state"`
 	Players         map[string]*Player       `json:"players"`
+	Spectators      map[string]*Spectator    `json:"spectators"`
+	AllowSpectators bool                     `json:"allowSpectators"`
+	SpectatorDelay  time.Duration            `json:"spectatorDelay"` // Broadcast delay for spectators
 	MaxPlayers      int                      `json:"maxPlayers"`
 	MinPlayers      int                      `json:"minPlayers"`
+	TickRate        int                      `json:"tickRate"` // Server ticks per second, 0 when driven by actions
+	AllowBots       bool                     `json:"allowBots"` // Bots take empty seats after the fill timeout
+	BotDifficulty   BotDifficulty            `json:"botDifficulty"`
 	HostUsername    string                   `json:"hostUsername"`
 	GameConfig      *minigame.GameConfig     `json:"gameConfig"`
 	GameSession     *minigame.GameState      `json:"gameSession,omitempty"`
+	Result          *RoomResult              `json:"result,omitempty"`
 	StartTime       *time.Time               `json:"startTime,omitempty"`
 	EndTime         *time.Time               `json:"endTime,omitempty"`
 	CreatedAt       time.Time                `json:"createdAt"`
 	LastActivity    time.Time                `json:"lastActivity"`
 	IsPrivate       bool                     `json:"isPrivate"`
-	Password        string                   `json:"-"` // Don't serialize password
+	JoinCode        string                   `json:"-"` // Short code that seats players without the password
+	passwordHash    []byte                   `json:"-"` // bcrypt hash, nil when the room has no password
+	bannedUsers     map[string]bool          `json:"-"` // Kicked players that may not come back
 	Settings        map[string]interface{}   `json:"settings"`
 	mu              sync.RWMutex             `json:"-"`
 	wsManager       *WebSocketManager        `json:"-"`
 	miniGameEngine  *minigame.MiniGameEngine `json:"-"`
 	eventChan       chan *GameRoomEvent      `json:"-"`
+	eventSeq        int64                    `json:"-"`
+	eventLog        []*WebSocketMessage      `json:"-"` // Recent broadcasts kept for resuming players
+	logMu           sync.Mutex               `json:"-"`
+	spectatorFeed   chan *WebSocketMessage   `json:"-"`
+	spectatorMu     sync.RWMutex             `json:"-"`
+	participants    []string                 `json:"-"` // Everyone who took a seat, for the replay
+	departed        map[string]int           `json:"-"` // Final scores of players who left the running game
+	replayLog       []*ReplayEntry           `json:"-"`
+	replayTruncated bool                     `json:"-"`
+	replayMu        sync.Mutex               `json:"-"`
+	pendingInputs   []queuedInput            `json:"-"` // Actions waiting for the next tick
+	tickCount       int64                    `json:"-"`
+	tickPlayers     map[string]interface{}   `json:"-"` // Player views sent with the last tick
+	pausedAt        *time.Time               `json:"-"` // Set while the game clock is frozen
+	pausedBy        string                   `json:"-"`
+	pausedTotal     time.Duration            `json:"-"` // Time spent paused, added to the session deadline
+	pausesUsed      map[string]int           `json:"-"`
+	abortVotes      map[string]bool          `json:"-"`
+	sessionWake     chan struct{}            `json:"-"` // Wakes the session timer after a pause or resume
 	ctx             context.Context          `json:"-"`
 	cancel          context.CancelFunc       `json:"-"`
 }
@@ -83,16 +131,36 @@ const (
 	RoomEventRoomClosed      = "room_closed"
 	RoomEventHostChanged     = "host_changed"
 	RoomEventSettingsChanged = "settings_changed"
+	RoomEventPlayerDisconnected = "player_disconnected"
+	RoomEventPlayerReconnected  = "player_reconnected"
+	RoomEventPlayerForfeited    = "player_forfeited"
 )
 
 // RoomManager manages all game rooms
 type RoomManager struct {
 	rooms         map[uuid.UUID]*GameRoom
 	userRooms     map[string]uuid.UUID    // username -> roomID
+	spectators    map[string]uuid.UUID    // username -> watched roomID
 	publicRooms   []uuid.UUID             // list of public rooms
 	mu            sync.RWMutex
 	wsManager     *WebSocketManager
 	miniGameEngine *minigame.MiniGameEngine
+	settlement    *Settlement
+	ratings       RatingStore
+	replays       ReplayStore
+	matches       MatchStore
+	backplane     Backplane
+	instanceID    string
+	reconnectGrace time.Duration
+	pausesPerPlayer int
+	botFillTimeout time.Duration
+	maxPause       time.Duration
+	joinCodes     map[string]uuid.UUID    // join code -> roomID
+	invites       map[uuid.UUID]*RoomInvite
+	friends       FriendLister            // Only friends can be invited into rooms
+	inviteChat    InviteMessenger         // Delivers invites to players without a game socket
+	gameTypes     *GameTypeRegistry                          // Late-join policy per game type
+	onSlotOpened  func(roomID uuid.UUID, gameType minigame.GameType) // Asks matchmaking to backfill a public room
 	ctx           context.Context
 	cancel        context.CancelFunc
 }
@@ -104,9 +172,16 @@ func NewRoomManager(ctx context.Context, wsManager *WebSocketManager, miniGameEn
 	rm := &RoomManager{
 		rooms:          make(map[uuid.UUID]*GameRoom),
 		userRooms:      make(map[string]uuid.UUID),
+		spectators:     make(map[string]uuid.UUID),
 		publicRooms:    make([]uuid.UUID, 0),
+		joinCodes:      make(map[string]uuid.UUID),
+		invites:        make(map[uuid.UUID]*RoomInvite),
 		wsManager:      wsManager,
 		miniGameEngine: miniGameEngine,
+		reconnectGrace: defaultReconnectGrace,
+		pausesPerPlayer: defaultPausesPerPlayer,
+		botFillTimeout: defaultBotFillTimeout,
+		maxPause:       defaultMaxPause,
 		ctx:            managerCtx,
 		cancel:         cancel,
 	}
@@ -119,26 +194,52 @@ func NewRoomManager(ctx context.Context, wsManager *WebSocketManager, miniGameEn
 
 // CreateRoom creates a new game room
 func (rm *RoomManager) CreateRoom(hostUsername string, gameType minigame.GameType, settings map[string]interface{}) (*GameRoom, error) {
+	// Hash the password before taking the manager lock, bcrypt is slow on purpose
+	password, _ := settings["password"].(string)
+	passwordHash, err := hashRoomPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim the room on the backplane before taking the manager lock, so the
+	// round trip does not hold up every other room. Other instances could not
+	// route to an unclaimed room, so it is not created.
+	roomID := uuid.New()
+	if err := rm.claimRoom(roomID); err != nil {
+		return nil, err
+	}
+
+	room, err := rm.createRoom(roomID, hostUsername, gameType, settings, passwordHash)
+	if err != nil {
+		if rm.backplane != nil {
+			rm.releaseRoom(roomID)
+		}
+		return nil, err
+	}
+	return room, nil
+}
+
+// createRoom sets up a room under an ID already claimed on the backplane
+func (rm *RoomManager) createRoom(roomID uuid.UUID, hostUsername string, gameType minigame.GameType, settings map[string]interface{}, passwordHash []byte) (*GameRoom, error) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
 	// Check if user is already in a room
-	if _, exists := rm.userRooms[hostUsername]; exists {
-		return nil, fmt.Errorf("user %s is already in a room", hostUsername)
+	if rm.isInRoom(hostUsername) {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInRoom, hostUsername)
 	}
 
 	// Get game config
 	gameConfigs := rm.miniGameEngine.ListGameTypes()
 	gameConfig, exists := gameConfigs[gameType]
 	if !exists {
-		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedGameType, gameType)
 	}
 
 	// Parse settings
 	maxPlayers := 2 // default
 	minPlayers := 2 // default
 	isPrivate := false
-	password := ""
 	roomName := fmt.Sprintf("%s's Room", hostUsername)
 
 	if settings != nil {
@@ -151,16 +252,15 @@ func (rm *RoomManager) CreateRoom(hostUsername string, gameType minigame.GameTyp
 		if v, ok := settings["isPrivate"].(bool); ok {
 			isPrivate = v
 		}
-		if v, ok := settings["password"].(string); ok {
-			password = v
-		}
 		if v, ok := settings["name"].(string); ok && v != "" {
 			roomName = v
 		}
 	}
+	allowSpectators, spectatorDelay := parseSpectatorSettings(settings)
+	tickRate := parseTickRate(settings, gameConfig)
+	allowBots, botDifficulty := parseBotSettings(settings, isPrivate)
 
 	roomCtx, roomCancel := context.WithCancel(rm.ctx)
-	roomID := uuid.New()
 
 	room := &GameRoom{
 		ID:              roomID,
@@ -168,34 +268,58 @@ func (rm *RoomManager) CreateRoom(hostUsername string, gameType minigame.GameTyp
 		GameType:        gameType,
 		State:           RoomStateWaiting,
 		Players:         make(map[string]*Player),
+		Spectators:      make(map[string]*Spectator),
+		bannedUsers:     make(map[string]bool),
+		sessionWake:     make(chan struct{}, 1),
+		AllowSpectators: allowSpectators,
+		SpectatorDelay:  spectatorDelay,
 		MaxPlayers:      maxPlayers,
 		MinPlayers:      minPlayers,
+		TickRate:        tickRate,
+		AllowBots:       allowBots,
+		BotDifficulty:   botDifficulty,
 		HostUsername:    hostUsername,
 		GameConfig:      gameConfig,
 		CreatedAt:       time.Now(),
 		LastActivity:    time.Now(),
 		IsPrivate:       isPrivate,
-		Password:        password,
-		Settings:        settings,
+		JoinCode:        rm.newJoinCode(),
+		passwordHash:    passwordHash,
+		Settings:        withoutPassword(settings),
 		wsManager:       rm.wsManager,
 		miniGameEngine:  rm.miniGameEngine,
 		eventChan:       make(chan *GameRoomEvent, 256),
+		spectatorFeed:   make(chan *WebSocketMessage, spectatorFeedSize),
 		ctx:             roomCtx,
 		cancel:          roomCancel,
 	}
 
 	// Add host as first player
 	hostPlayer := &Player{
-		Username: hostUsername,
-		IsReady:  false,
-		IsHost:   true,
-		Score:    0,
-		GameData: make(map[string]interface{}),
+		Username:    hostUsername,
+		IsReady:     false,
+		IsHost:      true,
+		Connected:   true,
+		Score:       0,
+		GameData:    make(map[string]interface{}),
+		resumeToken: newResumeToken(),
 	}
 
 	room.Players[hostUsername] = hostPlayer
+	room.participants = append(room.participants, hostUsername)
 	rm.rooms[roomID] = room
+
+	room.recordReplay(ReplayEntryServer, ReplayRoomCreated, hostUsername, map[string]interface{}{
+		"name":            roomName,
+		"gameType":        gameType,
+		"maxPlayers":      maxPlayers,
+		"minPlayers":      minPlayers,
+		"isPrivate":       isPrivate,
+		"allowSpectators": allowSpectators,
+		"tickRate":        tickRate,
+	}, room.CreatedAt)
 	rm.userRooms[hostUsername] = roomID
+	rm.joinCodes[room.JoinCode] = roomID
 
 	// Add to public rooms if not private
 	if !isPrivate {
@@ -204,65 +328,112 @@ func (rm *RoomManager) CreateRoom(hostUsername string, gameType minigame.GameTyp
 
 	// Start room event processor
 	go room.processEvents()
+	go room.runSpectatorFeed()
+	go rm.runBotFill(room)
+
+	rm.attachConnection(hostUsername, roomID)
+	rm.sendResumeToken(hostUsername, roomID, hostPlayer.resumeToken)
 
 	return room, nil
 }
 
 // JoinRoom adds a player to an existing room
 func (rm *RoomManager) JoinRoom(roomID uuid.UUID, username string, password string) (*GameRoom, error) {
+	passwordOK := rm.checkRoomPassword(roomID, password)
+
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
+	return rm.joinRoom(roomID, username, passwordOK)
+}
+
+// joinRoom seats a player. Private rooms only take players that gave the
+// password, a join code or an invite (assumes manager lock is held).
+func (rm *RoomManager) joinRoom(roomID uuid.UUID, username string, admitted bool) (*GameRoom, error) {
 	// Check if user is already in a room
-	if _, exists := rm.userRooms[username]; exists {
-		return nil, fmt.Errorf("user %s is already in a room", username)
+	if rm.isInRoom(username) {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInRoom, username)
 	}
 
 	room, exists := rm.rooms[roomID]
 	if !exists {
-		return nil, fmt.Errorf("room not found")
+		return nil, ErrRoomNotFound
 	}
 
 	room.mu.Lock()
 	defer room.mu.Unlock()
 
+	if room.bannedUsers[username] {
+		return nil, ErrBannedFromRoom
+	}
+
 	// Check room state
-	if room.State != RoomStateWaiting {
-		return nil, fmt.Errorf("room is not accepting new players")
+	if !rm.acceptsPlayers(room) {
+		return nil, fmt.Errorf("%w: room is not accepting new players", ErrInvalidRoomState)
 	}
 
 	// Check room capacity
-	if len(room.Players) >= room.MaxPlayers {
-		return nil, fmt.Errorf("room is full")
+	if len(room.Players) >= room.MaxPlayers && !room.hasBotSeat() {
+		return nil, ErrRoomFull
 	}
 
 	// Check password for private rooms
-	if room.IsPrivate && room.Password != password {
-		return nil, fmt.Errorf("incorrect password")
+	if room.IsPrivate && !admitted {
+		return nil, ErrIncorrectPassword
 	}
 
+	// Bots give up their seat to people before the game starts
+	if len(room.Players) >= room.MaxPlayers {
+		if err := rm.removeBot(room); err != nil {
+			return nil, err
+		}
+	}
+
+	// A forfeited player who joins again plays on with a new seat
+	delete(room.departed, username)
+
 	// Add player to room
 	player := &Player{
-		Username: username,
-		IsReady:  false,
-		IsHost:   false,
-		Score:    0,
-		GameData: make(map[string]interface{}),
+		Username:    username,
+		IsReady:     false,
+		IsHost:      false,
+		Connected:   true,
+		Score:       0,
+		GameData:    make(map[string]interface{}),
+		resumeToken: newResumeToken(),
 	}
 
 	room.Players[username] = player
+	room.participants = append(room.participants, username)
 	rm.userRooms[username] = roomID
 	room.LastActivity = time.Now()
 
+	switch room.State {
+	case RoomStateReady:
+		// The newcomer is not ready yet
+		room.State = RoomStateWaiting
+	case RoomStateInProgress:
+		room.joinSession(player, room.LastActivity)
+	}
+
 	// Emit player joined event
 	room.emitEvent(&GameRoomEvent{
 		Type:      RoomEventPlayerJoined,
 		RoomID:    roomID,
 		Username:  username,
-		Data:      map[string]interface{}{"player": player},
+		Data: map[string]interface{}{"player": map[string]interface{}{ // A copy: the event is sent after the lock is released
+			"username":  player.Username,
+			"isReady":   player.IsReady,
+			"isHost":    player.IsHost,
+			"connected": player.Connected,
+			"score":     player.Score,
+		}},
 		Timestamp: time.Now(),
 	})
 
+	rm.attachConnection(username, roomID)
+	rm.sendResumeToken(username, roomID, player.resumeToken)
+
 	return room, nil
 }
 
@@ -273,26 +444,46 @@ func (rm *RoomManager) LeaveRoom(roomID uuid.UUID, username string) error {
 
 	room, exists := rm.rooms[roomID]
 	if !exists {
-		return fmt.Errorf("room not found")
+		return ErrRoomNotFound
 	}
 
 	room.mu.Lock()
 	defer room.mu.Unlock()
 
+	return rm.removePlayer(room, username)
+}
+
+// removePlayer removes a player, handing over host and closing the room when
+// it becomes empty (assumes manager and room locks are held)
+func (rm *RoomManager) removePlayer(room *GameRoom, username string) error {
+	roomID := room.ID
+
 	// Check if player is in room
-	if _, exists := room.Players[username]; !exists {
-		return fmt.Errorf("player not in room")
+	player, exists := room.Players[username]
+	if !exists {
+		return ErrPlayerNotInRoom
+	}
+
+	// Players leaving a running game forfeit it and are still settled
+	if room.State == RoomStateInProgress && !player.IsBot {
+		player.mu.RLock()
+		room.departed[username] = player.Score
+		player.mu.RUnlock()
 	}
 
 	// Remove player
 	delete(room.Players, username)
 	delete(rm.userRooms, username)
 	room.LastActivity = time.Now()
+	rm.detachConnection(username, roomID)
 
 	// Handle host leaving
 	if room.HostUsername == username && len(room.Players) > 0 {
 		// Transfer host to another player
-		for newHostUsername := range room.Players {
+		for newHostUsername, candidate := range room.Players {
+			if candidate.IsBot {
+				continue
+			}
 			room.HostUsername = newHostUsername
 			room.Players[newHostUsername].IsHost = true
 
@@ -316,19 +507,55 @@ func (rm *RoomManager) LeaveRoom(roomID uuid.UUID, username string) error {
 		Timestamp: time.Now(),
 	})
 
-	// Close room if empty
-	if len(room.Players) == 0 {
+	// Close room if only bots are left
+	if room.humanCount() == 0 {
 		return rm.closeRoom(roomID)
 	}
 
+	// Public rooms ask matchmaking to fill the open seat
+	if !room.IsPrivate && rm.onSlotOpened != nil && rm.acceptsPlayers(room) {
+		go rm.onSlotOpened(roomID, room.GameType)
+	}
+
 	return nil
 }
 
+// acceptsPlayers reports whether new players may take a seat: always before
+// the game starts, and during it when the game type allows late joins
+// (assumes room lock is held)
+func (rm *RoomManager) acceptsPlayers(room *GameRoom) bool {
+	switch room.State {
+	case RoomStateWaiting, RoomStateReady:
+		return true
+	case RoomStateInProgress:
+		return rm.gameTypes != nil && rm.gameTypes.AllowsLateJoin(room.GameType)
+	default:
+		return false
+	}
+}
+
+// OpenSlots returns how many players a public room can still take, and false
+// when the room cannot be backfilled at all
+func (rm *RoomManager) OpenSlots(roomID uuid.UUID) (int, bool) {
+	room, exists := rm.GetRoom(roomID)
+	if !exists {
+		return 0, false
+	}
+
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+
+	if room.IsPrivate || !rm.acceptsPlayers(room) {
+		return 0, false
+	}
+	return room.openSeats(), true
+}
+
 // SetPlayerReady sets a player's ready state
 func (rm *RoomManager) SetPlayerReady(roomID uuid.UUID, username string, ready bool) error {
 	room, exists := rm.GetRoom(roomID)
 	if !exists {
-		return fmt.Errorf("room not found")
+		return ErrRoomNotFound
 	}
 
 	room.mu.Lock()
@@ -336,7 +563,11 @@ func (rm *RoomManager) SetPlayerReady(roomID uuid.UUID, username string, ready b
 
 	player, exists := room.Players[username]
 	if !exists {
-		return fmt.Errorf("player not in room")
+		return ErrPlayerNotInRoom
+	}
+
+	if room.State != RoomStateWaiting && room.State != RoomStateReady {
+		return ErrInvalidRoomState
 	}
 
 	player.mu.Lock()
@@ -375,6 +606,8 @@ func (rm *RoomManager) SetPlayerReady(roomID uuid.UUID, username string, ready b
 		if allReady {
 			room.State = RoomStateReady
 		}
+	} else if room.State == RoomStateReady && !ready {
+		room.State = RoomStateWaiting
 	}
 
 	return nil
@@ -384,7 +617,7 @@ func (rm *RoomManager) SetPlayerReady(roomID uuid.UUID, username string, ready b
 func (rm *RoomManager) StartGame(roomID uuid.UUID, hostUsername string) error {
 	room, exists := rm.GetRoom(roomID)
 	if !exists {
-		return fmt.Errorf("room not found")
+		return ErrRoomNotFound
 	}
 
 	room.mu.Lock()
@@ -392,40 +625,58 @@ func (rm *RoomManager) StartGame(roomID uuid.UUID, hostUsername string) error {
 
 	// Check if user is host
 	if room.HostUsername != hostUsername {
-		return fmt.Errorf("only host can start the game")
+		return ErrNotHost
 	}
 
 	// Check room state
 	if room.State != RoomStateReady {
-		return fmt.Errorf("room is not ready to start")
+		return fmt.Errorf("%w: room is not ready to start", ErrInvalidRoomState)
 	}
 
-	// Start game session
-	// TODO: Create multiplayer game session
-	// For now, we'll create individual sessions for each player
+	// Start the shared game session
 	now := time.Now()
+	if err := room.startSession(now); err != nil {
+		return err
+	}
+
 	room.State = RoomStateInProgress
 	room.StartTime = &now
-	room.LastActivity = time.Now()
+	room.LastActivity = now
 
-	// Initialize game data for each player
-	for _, player := range room.Players {
-		player.mu.Lock()
-		player.Score = 0
-		player.GameData = make(map[string]interface{})
-		// TODO: Initialize game-specific data based on game type
-		player.mu.Unlock()
+	// Keep the full session seed, including hidden values, so the game can be replayed exactly
+	players := make([]string, 0, len(room.Players))
+	for username := range room.Players {
+		players = append(players, username)
 	}
+	room.recordReplay(ReplayEntryServer, ReplaySessionStarted, hostUsername, map[string]interface{}{
+		"sessionId": room.GameSession.SessionID,
+		"players":   players,
+		"duration":  room.GameConfig.Duration.Milliseconds(),
+		"gameData":  room.GameSession.GameData,
+	}, now)
 
 	// Emit game started event
+	snapshot := room.sessionSnapshot(now)
+	room.tickPlayers, _ = snapshot["players"].(map[string]interface{})
 	room.emitEvent(&GameRoomEvent{
-		Type:      RoomEventGameStarted,
-		RoomID:    roomID,
-		Username:  hostUsername,
-		Data:      map[string]interface{}{"startTime": now},
-		Timestamp: time.Now(),
+		Type:     RoomEventGameStarted,
+		RoomID:   roomID,
+		Username: hostUsername,
+		Data: map[string]interface{}{
+			"startTime": now,
+			"duration":  room.GameConfig.Duration.Milliseconds(),
+			"tickRate":  room.TickRate,
+			"state":     snapshot,
+		},
+		Timestamp: now,
 	})
 
+	go rm.runSessionTimer(room, room.GameSession.SessionID, room.GameConfig.Duration)
+	rm.startBots(room)
+	if room.TickRate > 0 {
+		go rm.runTickLoop(room, room.GameSession.SessionID, room.TickRate)
+	}
+
 	return nil
 }
 
@@ -433,40 +684,70 @@ func (rm *RoomManager) StartGame(roomID uuid.UUID, hostUsername string) error {
 func (rm *RoomManager) ProcessGameAction(roomID uuid.UUID, username string, action map[string]interface{}) error {
 	room, exists := rm.GetRoom(roomID)
 	if !exists {
-		return fmt.Errorf("room not found")
+		return ErrRoomNotFound
 	}
 
-	room.mu.RLock()
-	defer room.mu.RUnlock()
+	room.mu.Lock()
+	defer room.mu.Unlock()
 
 	// Check room state
 	if room.State != RoomStateInProgress {
-		return fmt.Errorf("game is not in progress")
+		return fmt.Errorf("%w: game is not in progress", ErrInvalidRoomState)
 	}
 
 	player, exists := room.Players[username]
 	if !exists {
-		return fmt.Errorf("player not in room")
+		if room.isSpectator(username) {
+			return ErrSpectatorCannotAct
+		}
+		return ErrPlayerNotInRoom
+	}
+	if room.isPaused() {
+		return ErrGamePaused
 	}
 
-	// Update player's last action time
+	// Reject late actions even if the timer has not fired yet
 	now := time.Now()
-	player.mu.Lock()
-	player.LastAction = &now
-	player.mu.Unlock()
+	if !now.Before(room.sessionDeadline()) {
+		rm.finishGame(room, GameEndReasonTimeout)
+		return fmt.Errorf("%w: game time is over", ErrInvalidRoomState)
+	}
 
-	room.LastActivity = time.Now()
+	// Ticking rooms apply actions in batches on the next tick
+	if room.TickRate > 0 {
+		return room.queueInput(username, action, now)
+	}
+
+	state, err := room.applyAction(player, action, now)
+	room.recordAction(username, action, err, now)
+	if err != nil {
+		return err
+	}
+
+	room.LastActivity = now
+	room.GameSession.LastActivity = now
+	if state.CurrentScore > room.GameSession.CurrentScore {
+		room.GameSession.CurrentScore = state.CurrentScore
+	}
+
+	snapshot := room.sessionSnapshot(now)
+	snapshot["lastAction"] = map[string]interface{}{
+		"username": username,
+		"type":     action["type"],
+	}
 
-	// TODO: Process action based on game type
-	// For now, emit the action as an event
 	room.emitEvent(&GameRoomEvent{
-		Type:      RoomEventGameAction,
+		Type:      RoomEventGameStateUpdate,
 		RoomID:    roomID,
 		Username:  username,
-		Data:      action,
-		Timestamp: time.Now(),
+		Data:      snapshot,
+		Timestamp: now,
 	})
 
+	if room.allPlayersFinished() {
+		rm.finishGame(room, GameEndReasonCompleted)
+	}
+
 	return nil
 }
 
@@ -474,42 +755,105 @@ func (rm *RoomManager) ProcessGameAction(roomID uuid.UUID, username string, acti
 func (rm *RoomManager) EndGame(roomID uuid.UUID) error {
 	room, exists := rm.GetRoom(roomID)
 	if !exists {
-		return fmt.Errorf("room not found")
+		return ErrRoomNotFound
 	}
 
 	room.mu.Lock()
 	defer room.mu.Unlock()
 
 	if room.State != RoomStateInProgress {
-		return fmt.Errorf("game is not in progress")
+		return fmt.Errorf("%w: game is not in progress", ErrInvalidRoomState)
+	}
+
+	rm.finishGame(room, GameEndReasonManual)
+
+	return nil
+}
+
+// finishGame completes the room's session, announces the results and hands
+// them to the settlement exactly once (assumes room lock is held)
+func (rm *RoomManager) finishGame(room *GameRoom, reason string) {
+	if room.State != RoomStateInProgress || room.Result != nil {
+		return
 	}
 
 	now := time.Now()
 	room.State = RoomStateCompleted
 	room.EndTime = &now
-	room.LastActivity = time.Now()
+	room.LastActivity = now
+	room.pendingInputs = nil
+
+	if session := room.GameSession; session != nil {
+		session.EndTime = &now
+		session.Status = minigame.GameStatusCompleted
+	}
 
-	// TODO: Calculate final scores and award points
 	results := make(map[string]interface{})
-	for username, player := range room.Players {
-		player.mu.RLock()
-		results[username] = map[string]interface{}{
-			"score": player.Score,
-			"gameData": player.GameData,
+	for _, player := range room.Players {
+		player.mu.Lock()
+		if player.session != nil && player.session.Status == minigame.GameStatusInProgress {
+			player.session.Status = minigame.GameStatusCompleted
+			player.session.EndTime = &now
 		}
-		player.mu.RUnlock()
+		player.mu.Unlock()
+	}
+
+	result := room.buildResult(reason, now)
+	room.Result = result
+	for _, pr := range result.Players {
+		entry := map[string]interface{}{
+			"score":        pr.Score,
+			"placement":    pr.Placement,
+			"pointsEarned": pr.PointsEarned,
+			"isValid":      pr.IsValid,
+		}
+		if pr.Forfeited {
+			entry["forfeited"] = true
+		} else {
+			player := room.Players[pr.Username]
+			player.mu.RLock()
+			entry["gameData"] = copyGameData(player.GameData)
+			player.mu.RUnlock()
+		}
+		results[pr.Username] = entry
 	}
 
 	// Emit game ended event
 	room.emitEvent(&GameRoomEvent{
-		Type:      RoomEventGameEnded,
-		RoomID:    roomID,
-		Username:  "",
-		Data:      map[string]interface{}{"results": results, "endTime": now},
-		Timestamp: time.Now(),
+		Type:     RoomEventGameEnded,
+		RoomID:   room.ID,
+		Username: "",
+		Data: map[string]interface{}{
+			"results": results,
+			"reason":  reason,
+			"endTime": now,
+			"state":   room.sessionSnapshot(now),
+		},
+		Timestamp: now,
 	})
 
-	return nil
+	if rm.settlement != nil || rm.ratings != nil || rm.matches != nil {
+		go rm.settle(result)
+	}
+}
+
+// settle persists a finished game and updates ratings outside the room lock
+func (rm *RoomManager) settle(result *RoomResult) {
+	if rm.settlement != nil {
+		if err := rm.settlement.Settle(result); err != nil {
+			log.Printf("Failed to settle room %s: %v", result.RoomID, err)
+		}
+	}
+	if rm.matches != nil {
+		if err := rm.matches.RecordMatch(result); err != nil {
+			log.Printf("Failed to record match of room %s: %v", result.RoomID, err)
+		}
+	}
+	if rm.ratings != nil && result.EndReason != GameEndReasonAborted {
+		if err := rm.ratings.RecordResult(result); err != nil {
+			log.Printf("Failed to update ratings for room %s: %v", result.RoomID, err)
+		}
+	}
 }
 
 // GetRoom returns a room by ID
@@ -550,16 +894,56 @@ func (rm *RoomManager) ListPublicRooms() []*GameRoom {
 	return rooms
 }
 
-// closeRoom closes and removes a room
+// CountRooms returns how many rooms of a game type are waiting for players and in progress
+func (rm *RoomManager) CountRooms(gameType minigame.GameType) (waiting, inProgress int) {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	for _, room := range rm.rooms {
+		if room.GameType != gameType {
+			continue
+		}
+		room.mu.RLock()
+		switch room.State {
+		case RoomStateWaiting, RoomStateReady:
+			waiting++
+		case RoomStateInProgress:
+			inProgress++
+		}
+		room.mu.RUnlock()
+	}
+	return waiting, inProgress
+}
+
+// CountRoomsByState returns how many rooms are in each state
+func (rm *RoomManager) CountRoomsByState() map[GameRoomState]int {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	counts := make(map[GameRoomState]int)
+	for _, room := range rm.rooms {
+		room.mu.RLock()
+		counts[room.State]++
+		room.mu.RUnlock()
+	}
+	return counts
+}
+
+// closeRoom closes and removes a room and hands its replay to the replay
+// store (assumes manager and room locks are held)
 func (rm *RoomManager) closeRoom(roomID uuid.UUID) error {
 	room, exists := rm.rooms[roomID]
 	if !exists {
-		return fmt.Errorf("room not found")
+		return ErrRoomNotFound
 	}
 
 	// Remove all players from user rooms map
 	for username := range room.Players {
 		delete(rm.userRooms, username)
+		rm.detachConnection(username, roomID)
+	}
+	for _, username := range room.spectatorNames() {
+		delete(rm.spectators, username)
 	}
 
 	// Remove from public rooms if exists
@@ -570,22 +954,68 @@ func (rm *RoomManager) closeRoom(roomID uuid.UUID) error {
 		}
 	}
 
+	// Retire the join code and pending invites
+	delete(rm.joinCodes, room.JoinCode)
+	for inviteID, invite := range rm.invites {
+		if invite.RoomID == roomID {
+			delete(rm.invites, inviteID)
+		}
+	}
+
 	// Cancel room context and cleanup
 	room.cancel()
 	delete(rm.rooms, roomID)
 
 	// Emit room closed event
+	now := time.Now()
+	room.State = RoomStateClosed
 	room.emitEvent(&GameRoomEvent{
 		Type:      RoomEventRoomClosed,
 		RoomID:    roomID,
 		Username:  "",
 		Data:      map[string]interface{}{},
-		Timestamp: time.Now(),
+		Timestamp: now,
 	})
 
+	if rm.replays != nil {
+		go rm.saveReplay(room.buildReplay(now))
+	}
+	if rm.backplane != nil {
+		go rm.releaseRoom(roomID)
+	}
+
 	return nil
 }
 
+// isInRoom reports whether a user is playing or spectating in any room (assumes lock is held)
+func (rm *RoomManager) isInRoom(username string) bool {
+	if _, exists := rm.userRooms[username]; exists {
+		return true
+	}
+	_, exists := rm.spectators[username]
+	return exists
+}
+
+// attachConnection subscribes a user's WebSocket connection to room broadcasts
+func (rm *RoomManager) attachConnection(username string, roomID uuid.UUID) {
+	if rm.wsManager == nil {
+		return
+	}
+	if conn, exists := rm.wsManager.GetConnection(username); exists {
+		rm.wsManager.AddToRoom(conn, roomID)
+	}
+}
+
+// detachConnection unsubscribes a user's WebSocket connection from room broadcasts
+func (rm *RoomManager) detachConnection(username string, roomID uuid.UUID) {
+	if rm.wsManager == nil {
+		return
+	}
+	if conn, exists := rm.wsManager.GetConnection(username); exists {
+		rm.wsManager.RemoveFromRoom(conn, roomID)
+	}
+}
+
 // processEvents processes room events and sends WebSocket messages
 func (room *GameRoom) processEvents() {
 	for {
@@ -602,15 +1032,19 @@ func (room *GameRoom) processEvents() {
 				From:      event.Username,
 				RoomID:    &event.RoomID,
 			}
+			room.recordEvent(message)
 
 			// Send to all players in the room
 			room.wsManager.SendToRoom(event.RoomID, message)
+			room.forwardToSpectators(message)
 		}
 	}
 }
 
-// emitEvent emits an event to the room
+// emitEvent records an event in the room replay and emits it to the room
 func (room *GameRoom) emitEvent(event *GameRoomEvent) {
+	room.recordReplay(ReplayEntryEvent, event.Type, event.Username, event.Data, event.Timestamp)
+
 	select {
 	case room.eventChan <- event:
 	case <-room.ctx.Done():
@@ -642,14 +1076,14 @@ func (rm *RoomManager) cleanupInactiveRooms() {
 	inactiveThreshold := 30 * time.Minute
 
 	for roomID, room := range rm.rooms {
-		room.mu.RLock()
+		room.mu.Lock()
 		inactive := now.Sub(room.LastActivity) > inactiveThreshold
 		isEmpty := len(room.Players) == 0
-		room.mu.RUnlock()
 
 		if inactive || isEmpty {
 			rm.closeRoom(roomID)
 		}
+		room.mu.Unlock()
 	}
 }
 
@@ -665,25 +1099,36 @@ func (room *GameRoom) GetRoomStats() map[string]interface{} {
 			"score":      player.Score,
 			"isReady":    player.IsReady,
 			"isHost":     player.IsHost,
+			"connected":  player.Connected,
 			"lastAction": player.LastAction,
+			"isBot":      player.IsBot,
 		}
 		player.mu.RUnlock()
 	}
 
 	return map[string]interface{}{
-		"id":           room.ID,
-		"name":         room.Name,
-		"gameType":     room.GameType,
-		"state":        room.State,
-		"playerCount":  len(room.Players),
-		"maxPlayers":   room.MaxPlayers,
-		"minPlayers":   room.MinPlayers,
-		"isPrivate":    room.IsPrivate,
-		"createdAt":    room.CreatedAt,
-		"startTime":    room.StartTime,
-		"endTime":      room.EndTime,
-		"lastActivity": room.LastActivity,
-		"players":      playerStats,
+		"id":               room.ID,
+		"name":             room.Name,
+		"gameType":         room.GameType,
+		"state":            room.State,
+		"playerCount":      len(room.Players),
+		"spectatorCount":   room.SpectatorCount(),
+		"allowSpectators":  room.AllowSpectators,
+		"spectatorDelayMs": room.SpectatorDelay.Milliseconds(),
+		"maxPlayers":       room.MaxPlayers,
+		"minPlayers":       room.MinPlayers,
+		"tickRate":         room.TickRate,
+		"isPrivate":        room.IsPrivate,
+		"hasPassword":      len(room.passwordHash) > 0,
+		"paused":           room.isPaused(),
+		"allowBots":        room.AllowBots,
+		"botDifficulty":    room.BotDifficulty,
+		"botCount":         len(room.botNames()),
+		"createdAt":        room.CreatedAt,
+		"startTime":        room.StartTime,
+		"endTime":          room.EndTime,
+		"lastActivity":     room.LastActivity,
+		"players":          playerStats,
 	}
 }
 
@@ -694,7 +1139,9 @@ func (rm *RoomManager) Shutdown() {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
-	for roomID := range rm.rooms {
+	for roomID, room := range rm.rooms {
+		room.mu.Lock()
 		rm.closeRoom(roomID)
+		room.mu.Unlock()
 	}
 }
\ No newline at end of file
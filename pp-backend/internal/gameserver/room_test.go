@@ -0,0 +1,127 @@
+// internal/gameserver/room_test.go
+package gameserver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRoomManager(t *testing.T) *gameserver.RoomManager {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	wsManager := gameserver.NewWebSocketManager(ctx)
+	engine := minigame.NewMiniGameEngine(nil, nil)
+	return gameserver.NewRoomManager(ctx, wsManager, engine)
+}
+
+func TestRoomManager_JoinErrors(t *testing.T) {
+	rm := newTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, map[string]interface{}{
+		"maxPlayers": float64(2),
+		"isPrivate":  true,
+		"password":   "secret",
+	})
+	require.NoError(t, err)
+
+	_, err = rm.JoinRoom(room.ID, "guest", "wrong")
+	assert.ErrorIs(t, err, gameserver.ErrIncorrectPassword)
+
+	_, err = rm.JoinRoom(room.ID, "guest", "secret")
+	require.NoError(t, err)
+
+	_, err = rm.JoinRoom(room.ID, "third", "secret")
+	assert.ErrorIs(t, err, gameserver.ErrRoomFull)
+
+	_, err = rm.CreateRoom("guest", minigame.GameTypeClickSpeed, nil)
+	assert.ErrorIs(t, err, gameserver.ErrAlreadyInRoom)
+
+	_, err = rm.CreateRoom("fourth", minigame.GameType("unknown"), nil)
+	assert.ErrorIs(t, err, gameserver.ErrUnsupportedGameType)
+}
+
+func TestRoomManager_StartRequiresHostAndReadyState(t *testing.T) {
+	rm := newTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, nil)
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "guest", "")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, rm.StartGame(room.ID, "host"), gameserver.ErrInvalidRoomState)
+
+	require.NoError(t, rm.SetPlayerReady(room.ID, "host", true))
+	require.NoError(t, rm.SetPlayerReady(room.ID, "guest", true))
+
+	assert.ErrorIs(t, rm.StartGame(room.ID, "guest"), gameserver.ErrNotHost)
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+
+	assert.ErrorIs(t, rm.SetPlayerReady(room.ID, "guest", false), gameserver.ErrInvalidRoomState)
+}
+
+func TestRoomErrorCode(t *testing.T) {
+	cases := map[error]string{
+		gameserver.ErrRoomNotFound:      "ROOM_NOT_FOUND",
+		gameserver.ErrRoomFull:          "ROOM_FULL",
+		gameserver.ErrIncorrectPassword: "WRONG_PASSWORD",
+		gameserver.ErrNotHost:           "NOT_HOST",
+		gameserver.ErrInvalidRoomState:  "WRONG_STATE",
+	}
+	for err, want := range cases {
+		_, code := gameserver.RoomErrorCode(err)
+		assert.Equal(t, want, code)
+	}
+}
+
+func startTestGame(t *testing.T, rm *gameserver.RoomManager, gameType minigame.GameType) *gameserver.GameRoom {
+	t.Helper()
+	room, err := rm.CreateRoom("host", gameType, nil)
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "guest", "")
+	require.NoError(t, err)
+	require.NoError(t, rm.SetPlayerReady(room.ID, "host", true))
+	require.NoError(t, rm.SetPlayerReady(room.ID, "guest", true))
+	return room
+}
+
+func TestRoomManager_GameActionsUpdateScores(t *testing.T) {
+	rm := newTestRoomManager(t)
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+
+	click := map[string]interface{}{"type": "click"}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, rm.ProcessGameAction(room.ID, "host", click))
+	}
+	require.NoError(t, rm.ProcessGameAction(room.ID, "guest", click))
+
+	err := rm.ProcessGameAction(room.ID, "guest", map[string]interface{}{"type": "guess"})
+	assert.ErrorIs(t, err, gameserver.ErrInvalidGameAction)
+
+	players := room.GetRoomStats()["players"].(map[string]interface{})
+	assert.Equal(t, 3, players["host"].(map[string]interface{})["score"])
+	assert.Equal(t, 1, players["guest"].(map[string]interface{})["score"])
+	assert.NotNil(t, room.GameSession)
+}
+
+func TestRoomManager_GameEndsWhenDurationElapses(t *testing.T) {
+	rm := newTestRoomManager(t)
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+	room.GameConfig.Duration = 50 * time.Millisecond
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+
+	require.Eventually(t, func() bool {
+		return room.GetRoomStats()["state"] == gameserver.RoomStateCompleted
+	}, time.Second, 10*time.Millisecond)
+
+	err := rm.ProcessGameAction(room.ID, "host", map[string]interface{}{"type": "click"})
+	assert.ErrorIs(t, err, gameserver.ErrInvalidRoomState)
+}
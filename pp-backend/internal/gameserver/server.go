@@ -2,14 +2,21 @@
 package gameserver
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/pkg/response"
 )
 
 // GameServerConfig contains configuration for the game server
@@ -18,9 +25,18 @@ type GameServerConfig struct {
 	MaxConnections         int           `json:"maxConnections"`
 	MaxRooms               int           `json:"maxRooms"`
 	MaxPlayersPerRoom      int           `json:"maxPlayersPerRoom"`
-	ConnectionTimeout      time.Duration `json:"connectionTimeout"`
+	ConnectionTimeout      time.Duration `json:"connectionTimeout"` // Idle sockets are dropped after this long
+	MaxMessageSize         int64         `json:"maxMessageSize"`    // Largest client message in bytes
+	SendBufferSize         int           `json:"sendBufferSize"`    // Messages queued per socket before sends are dropped
 	RoomInactivityTimeout  time.Duration `json:"roomInactivityTimeout"`
 	MatchmakingTimeout     time.Duration `json:"matchmakingTimeout"`
+	ReconnectGracePeriod   time.Duration `json:"reconnectGracePeriod"`
+	PausesPerPlayer        int           `json:"pausesPerPlayer"`  // Pauses each player may call per match
+	MaxPauseDuration       time.Duration `json:"maxPauseDuration"` // Paused games resume on their own after this long
+	BotFillTimeout         time.Duration `json:"botFillTimeout"`   // Short-handed rooms get bots after waiting this long
+	MatchAcceptTimeout     time.Duration `json:"matchAcceptTimeout"`  // How long matched players have to accept
+	MatchDeclinePenalty    time.Duration `json:"matchDeclinePenalty"` // Queue ban for players who do not accept
+	InstanceID             string        `json:"instanceId"` // Name of this instance on the backplane
 	EnableCORS             bool          `json:"enableCORS"`
 	AllowedOrigins         []string      `json:"allowedOrigins"`
 	EnableMetrics          bool          `json:"enableMetrics"`
@@ -48,12 +64,19 @@ type GameServer struct {
 	wsManager      *WebSocketManager
 	roomManager    *RoomManager
 	matchmaking    *MatchmakingService
+	gameTypes      *GameTypeRegistry
 	eventBus       *EventBus
 	eventProcessor *EventProcessor
 	miniGameEngine *minigame.MiniGameEngine
+	authenticator  *Authenticator
+	backplane      Backplane
 	httpServer     *http.Server
 	router         *mux.Router
 	stats          *GameServerStats
+	metrics        *serverMetrics
+	matchStatsAt   time.Time  // When the match history aggregates were last loaded
+	matchStatsMu   sync.Mutex // Serializes match history reloads
+	chat           *roomChat
 	mu             sync.RWMutex
 	ctx            context.Context
 	cancel         context.CancelFunc
@@ -61,18 +84,124 @@ type GameServer struct {
 	isRunning      bool
 }
 
+// Option customizes a GameServer
+type Option func(*GameServer)
+
+// WithAuthenticator sets the authenticator used for WebSocket and API requests
+func WithAuthenticator(authenticator *Authenticator) Option {
+	return func(gs *GameServer) {
+		gs.authenticator = authenticator
+	}
+}
+
+// WithSettlement persists finished room games and pays out rewards
+func WithSettlement(settlement *Settlement) Option {
+	return func(gs *GameServer) {
+		gs.roomManager.settlement = settlement
+	}
+}
+
+// WithRatings rates players after every finished room and matches them by rating
+func WithRatings(ratings RatingStore) Option {
+	return func(gs *GameServer) {
+		gs.roomManager.ratings = ratings
+		gs.matchmaking.ratings = ratings
+	}
+}
+
+// WithFriends lets players invite their friends into matchmaking parties and rooms
+func WithFriends(friends FriendLister) Option {
+	return func(gs *GameServer) {
+		gs.matchmaking.friends = friends
+		gs.roomManager.friends = friends
+	}
+}
+
+// WithChatFilter replaces the profanity filter applied to room chat
+func WithChatFilter(filter ChatFilter) Option {
+	return func(gs *GameServer) {
+		gs.chat.filter = filter
+	}
+}
+
+// WithBlockList hides room chat from players who have blocked the sender
+func WithBlockList(blocks BlockLister) Option {
+	return func(gs *GameServer) {
+		gs.chat.blocks = blocks
+	}
+}
+
+// WithInviteChat sends room invites as chat messages to friends who are not
+// connected to the game server
+func WithInviteChat(messenger InviteMessenger) Option {
+	return func(gs *GameServer) {
+		gs.roomManager.inviteChat = messenger
+	}
+}
+
+// WithReplayStore records every closed room's replay in the given store
+func WithReplayStore(store ReplayStore) Option {
+	return func(gs *GameServer) {
+		gs.roomManager.replays = store
+	}
+}
+
+// WithMatchStore records every finished room game and computes the game statistics from them
+func WithMatchStore(store MatchStore) Option {
+	return func(gs *GameServer) {
+		gs.roomManager.matches = store
+	}
+}
+
+// WithBackplane lets several game server instances serve players of the same room
+func WithBackplane(backplane Backplane) Option {
+	return func(gs *GameServer) {
+		gs.backplane = backplane
+	}
+}
+
 // NewGameServer creates a new game server instance
-func NewGameServer(config *GameServerConfig, miniGameEngine *minigame.MiniGameEngine) *GameServer {
+func NewGameServer(config *GameServerConfig, miniGameEngine *minigame.MiniGameEngine, opts ...Option) *GameServer {
 	if config == nil {
 		config = GetDefaultConfig()
 	}
+	if config.InstanceID == "" {
+		config.InstanceID = newInstanceID()
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Create components
-	wsManager := NewWebSocketManager(ctx)
+	wsManager := NewWebSocketManagerWithConfig(ctx, config.PoolConfig())
 	roomManager := NewRoomManager(ctx, wsManager, miniGameEngine)
-	matchmaking := NewMatchmakingService(ctx, wsManager, roomManager)
+	if config.ReconnectGracePeriod > 0 {
+		roomManager.reconnectGrace = config.ReconnectGracePeriod
+	}
+	if config.PausesPerPlayer > 0 {
+		roomManager.pausesPerPlayer = config.PausesPerPlayer
+	}
+	if config.MaxPauseDuration > 0 {
+		roomManager.maxPause = config.MaxPauseDuration
+	}
+	if config.BotFillTimeout > 0 {
+		roomManager.botFillTimeout = config.BotFillTimeout
+	}
+	gameTypes := NewGameTypeRegistry(miniGameEngine)
+	matchmaking := NewMatchmakingService(ctx, wsManager, roomManager, gameTypes)
+	if config.MatchAcceptTimeout > 0 {
+		matchmaking.acceptTimeout = config.MatchAcceptTimeout
+	}
+	if config.MatchDeclinePenalty > 0 {
+		matchmaking.declinePenalty = config.MatchDeclinePenalty
+	}
+
+	// Seats freed in public rooms are offered to queued players
+	roomManager.gameTypes = gameTypes
+	roomManager.onSlotOpened = func(roomID uuid.UUID, gameType minigame.GameType) {
+		if err := matchmaking.RequestBackfill(roomID); err != nil && !errors.Is(err, ErrNoOpenSlots) && !errors.Is(err, ErrRoomNotFound) {
+			fmt.Printf("⚠️ Failed to request backfill for %s room %s: %v\n", gameType, roomID, err)
+		}
+	}
 	eventBus := NewEventBus(ctx, wsManager)
 	eventProcessor := NewEventProcessor(eventBus, roomManager, matchmaking, miniGameEngine, wsManager)
 
@@ -89,16 +218,49 @@ func NewGameServer(config *GameServerConfig, miniGameEngine *minigame.MiniGameEn
 		wsManager:      wsManager,
 		roomManager:    roomManager,
 		matchmaking:    matchmaking,
+		gameTypes:      gameTypes,
 		eventBus:       eventBus,
 		eventProcessor: eventProcessor,
 		miniGameEngine: miniGameEngine,
 		stats:          stats,
+		chat:           newRoomChat(),
 		ctx:            ctx,
 		cancel:         cancel,
 		startTime:      time.Now(),
 		isRunning:      false,
 	}
 
+	for _, opt := range opts {
+		opt(server)
+	}
+
+	server.metrics = newServerMetrics(server)
+	matchmaking.waitHistogram = server.metrics.matchWait
+
+	if server.backplane != nil {
+		roomManager.backplane = server.backplane
+		roomManager.instanceID = config.InstanceID
+		if err := wsManager.UseBackplane(server.backplane, config.InstanceID); err != nil {
+			fmt.Printf("⚠️ Failed to subscribe to game server backplane: %v\n", err)
+		}
+	}
+
+	// Route client messages that need the room manager
+	wsManager.HandleMessage(MessageTypeGameAction, server.routeToOwner(server.handleGameActionMessage))
+	wsManager.HandleMessage(MessageTypeResume, server.routeToOwner(server.handleResumeMessage))
+	wsManager.HandleMessage(MessageTypeJoinRoom, server.routeToOwner(server.handleJoinRoomMessage))
+	wsManager.HandleMessage(MessageTypeLeaveRoom, server.routeToOwner(server.handleLeaveRoomMessage))
+	wsManager.HandleMessage(MessageTypePauseGame, server.routeToOwner(server.handlePauseMessage))
+	wsManager.HandleMessage(MessageTypeResumeGame, server.routeToOwner(server.handlePauseMessage))
+	wsManager.HandleMessage(MessageTypeVoteAbort, server.routeToOwner(server.handlePauseMessage))
+	wsManager.HandleMessage(MessageTypeRoomHost, server.routeToOwner(server.handleRoomHostMessage))
+	wsManager.HandleMessage(MessageTypeRoomChat, server.limitChat(server.routeToOwner(server.handleRoomChatMessage)))
+	wsManager.HandleMessage(MessageTypeRoomEmote, server.limitChat(server.routeToOwner(server.handleRoomEmoteMessage)))
+	wsManager.HandleMessage(MessageTypeInvite, server.handleInviteMessage)
+	wsManager.OnDisconnect(server.handleDisconnect)
+	wsManager.OnDisconnect(server.chat.forget)
+	server.registerMatchmakingHandlers()
+
 	// Set up HTTP router
 	server.setupRouter()
 
@@ -113,8 +275,16 @@ func GetDefaultConfig() *GameServerConfig {
 		MaxRooms:               100,
 		MaxPlayersPerRoom:      8,
 		ConnectionTimeout:      5 * time.Minute,
+		MaxMessageSize:         8192,
+		SendBufferSize:         256,
 		RoomInactivityTimeout:  30 * time.Minute,
 		MatchmakingTimeout:     5 * time.Minute,
+		ReconnectGracePeriod:   30 * time.Second,
+		PausesPerPlayer:        defaultPausesPerPlayer,
+		MaxPauseDuration:       defaultMaxPause,
+		BotFillTimeout:         defaultBotFillTimeout,
+		MatchAcceptTimeout:     defaultMatchAcceptTimeout,
+		MatchDeclinePenalty:    defaultMatchDeclinePenalty,
 		EnableCORS:             true,
 		AllowedOrigins:         []string{"*"}, // Configure properly for production
 		EnableMetrics:          true,
@@ -123,12 +293,30 @@ func GetDefaultConfig() *GameServerConfig {
 	}
 }
 
+// PoolConfig derives the WebSocket connection pool settings from the server
+// config, keeping pool defaults for settings left at zero
+func (c *GameServerConfig) PoolConfig() *PoolConfig {
+	pool := DefaultPoolConfig()
+	if c.MaxConnections > 0 {
+		pool.MaxConnections = c.MaxConnections
+	}
+	if c.ConnectionTimeout > 0 {
+		pool.ReadTimeout = c.ConnectionTimeout
+	}
+	if c.MaxMessageSize > 0 {
+		pool.MaxMessageSize = c.MaxMessageSize
+	}
+	if c.SendBufferSize > 0 {
+		pool.SendBufferSize = c.SendBufferSize
+	}
+	return pool
+}
+
 // Start starts the game server
 func (gs *GameServer) Start() error {
 	gs.mu.Lock()
-	defer gs.mu.Unlock()
-
 	if gs.isRunning {
+		gs.mu.Unlock()
 		return fmt.Errorf("server is already running")
 	}
 
@@ -144,12 +332,11 @@ func (gs *GameServer) Start() error {
 	// Start statistics update routine
 	go gs.updateStatsRoutine()
 
-	// Start metrics collection if enabled
-	if gs.config.EnableMetrics {
-		go gs.metricsRoutine()
-	}
-
 	gs.isRunning = true
+	httpServer := gs.httpServer
+
+	// The lock is released before serving, handlers and Stop take it too
+	gs.mu.Unlock()
 
 	// Publish server start event
 	gs.eventBus.PublishEvent(CreateEvent(EventTypeSystemError, "game_server", map[string]interface{}{
@@ -159,8 +346,10 @@ func (gs *GameServer) Start() error {
 
 	// Start HTTP server (this blocks)
 	fmt.Printf("🎮 Game Server starting on port %d\n", gs.config.Port)
-	if err := gs.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		gs.mu.Lock()
 		gs.isRunning = false
+		gs.mu.Unlock()
 		return fmt.Errorf("failed to start server: %w", err)
 	}
 
@@ -199,6 +388,11 @@ func (gs *GameServer) Stop() error {
 	gs.roomManager.Shutdown()
 	gs.wsManager.Shutdown()
 	gs.eventBus.Shutdown()
+	if gs.backplane != nil {
+		if err := gs.backplane.Close(); err != nil {
+			fmt.Printf("❌ Error closing game server backplane: %v\n", err)
+		}
+	}
 
 	gs.isRunning = false
 	fmt.Println("✅ Game Server shutdown complete")
@@ -225,24 +419,43 @@ func (gs *GameServer) setupRouter() {
 
 	// Metrics endpoint
 	if gs.config.EnableMetrics {
-		gs.router.HandleFunc("/metrics", gs.handleMetrics).Methods("GET")
+		gs.router.Handle("/metrics", gs.metrics.handler()).Methods("GET")
 	}
 
+	// Matching WebSocket endpoint (registered before /ws/{username} so it is not shadowed)
+	gs.router.HandleFunc("/ws/matching", gs.handleMatchingWebSocket).Methods("GET")
+
 	// WebSocket endpoint
 	gs.router.HandleFunc("/ws/{username}", gs.handleWebSocket).Methods("GET")
 
 	// Game API endpoints
 	api := gs.router.PathPrefix("/api/v1").Subrouter()
 
+	// WebSocket handshake tickets
+	api.HandleFunc("/auth/ws-ticket", gs.handleIssueTicket).Methods("POST")
+
 	// Room management
 	api.HandleFunc("/rooms", gs.handleListRooms).Methods("GET")
 	api.HandleFunc("/rooms", gs.handleCreateRoom).Methods("POST")
 	api.HandleFunc("/rooms/{roomId}", gs.handleGetRoom).Methods("GET")
+	api.HandleFunc("/rooms/{roomId}", gs.handleUpdateRoom).Methods("PATCH")
 	api.HandleFunc("/rooms/{roomId}/join", gs.handleJoinRoom).Methods("POST")
 	api.HandleFunc("/rooms/{roomId}/leave", gs.handleLeaveRoom).Methods("POST")
 	api.HandleFunc("/rooms/{roomId}/ready", gs.handleSetReady).Methods("POST")
 	api.HandleFunc("/rooms/{roomId}/start", gs.handleStartGame).Methods("POST")
 	api.HandleFunc("/rooms/{roomId}/action", gs.handleGameAction).Methods("POST")
+	api.HandleFunc("/rooms/{roomId}/pause", gs.handlePauseGame).Methods("POST")
+	api.HandleFunc("/rooms/{roomId}/resume", gs.handleResumeGame).Methods("POST")
+	api.HandleFunc("/rooms/{roomId}/abort", gs.handleVoteAbort).Methods("POST")
+	api.HandleFunc("/rooms/{roomId}/replay", gs.handleGetReplay).Methods("GET")
+	api.HandleFunc("/rooms/{roomId}/backfill", gs.handleRequestBackfill).Methods("POST")
+	api.HandleFunc("/rooms/{roomId}/kick", gs.handleKickPlayer).Methods("POST")
+	api.HandleFunc("/rooms/{roomId}/host", gs.handleTransferHost).Methods("POST")
+	api.HandleFunc("/rooms/{roomId}/invites", gs.handleCreateInvite).Methods("POST")
+	api.HandleFunc("/rooms/code/{code}/join", gs.handleJoinRoomByCode).Methods("POST")
+	api.HandleFunc("/invites", gs.handleListInvites).Methods("GET")
+	api.HandleFunc("/invites/{inviteId}/accept", gs.handleAcceptInvite).Methods("POST")
+	api.HandleFunc("/invites/{inviteId}/decline", gs.handleDeclineInvite).Methods("POST")
 
 	// Matchmaking
 	api.HandleFunc("/matchmaking/join", gs.handleJoinMatchmaking).Methods("POST")
@@ -250,9 +463,6 @@ func (gs *GameServer) setupRouter() {
 	api.HandleFunc("/matchmaking/status/{username}", gs.handleMatchmakingStatus).Methods("GET")
 	api.HandleFunc("/matchmaking/queue/{gameType}", gs.handleQueueStatus).Methods("GET")
 
-	// New Matching System WebSocket endpoints
-	gs.router.HandleFunc("/ws/matching", gs.handleMatchingWebSocket).Methods("GET")
-
 	// Game types and configurations
 	api.HandleFunc("/games/types", gs.handleListGameTypes).Methods("GET")
 
@@ -285,31 +495,45 @@ func (gs *GameServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 
 	if !gs.isRunning {
 		health["status"] = "unhealthy"
-		w.WriteHeader(http.StatusServiceUnavailable)
+		gs.writeJSONWithStatus(w, http.StatusServiceUnavailable, health)
+		return
 	}
 
 	gs.writeJSONResponse(w, health)
 }
 
-func (gs *GameServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
-	gs.updateStats()
-	gs.writeJSONResponse(w, gs.stats)
-}
-
 func (gs *GameServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	username := vars["username"]
+	requested := vars["username"]
 
-	if username == "" {
-		http.Error(w, "Username is required", http.StatusBadRequest)
+	if requested == "" {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Username is required")
 		return
 	}
 
-	// TODO: Add authentication middleware to validate user
-	// For now, we'll accept any username
+	username, err := gs.authenticate(r)
+	if err != nil {
+		gs.writeAuthError(w, err)
+		return
+	}
+
+	// The path segment is kept for compatibility but must match the token subject
+	if requested != username {
+		gs.writeError(w, http.StatusForbidden, "FORBIDDEN", "Username does not match credentials")
+		return
+	}
 
+	gs.acceptWebSocket(w, r, username)
+}
+
+// acceptWebSocket upgrades an authenticated request to the user's game socket
+func (gs *GameServer) acceptWebSocket(w http.ResponseWriter, r *http.Request, username string) {
 	if err := gs.wsManager.HandleWebSocket(w, r, username); err != nil {
-		http.Error(w, fmt.Sprintf("WebSocket error: %v", err), http.StatusInternalServerError)
+		if errors.Is(err, ErrConnectionLimit) {
+			gs.writeError(w, http.StatusServiceUnavailable, "SERVER_FULL", "Game server is at its connection limit")
+			return
+		}
+		gs.writeError(w, http.StatusInternalServerError, "WEBSOCKET_ERROR", fmt.Sprintf("WebSocket error: %v", err))
 		return
 	}
 
@@ -321,13 +545,49 @@ func (gs *GameServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	}))
 }
 
+func (gs *GameServer) handleIssueTicket(w http.ResponseWriter, r *http.Request) {
+	if gs.authenticator == nil {
+		gs.writeError(w, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Authentication is not configured")
+		return
+	}
+
+	token := bearerToken(r)
+	if token == "" {
+		gs.writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", ErrMissingCredentials.Error())
+		return
+	}
+
+	username, err := gs.authenticator.AuthenticateToken(token)
+	if err != nil {
+		gs.writeAuthError(w, err)
+		return
+	}
+
+	ticket, expiresAt, err := gs.authenticator.IssueTicket(username)
+	if err != nil {
+		gs.writeError(w, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Failed to issue ticket")
+		return
+	}
+
+	gs.writeJSONResponse(w, map[string]interface{}{
+		"ticket":    ticket,
+		"username":  username,
+		"expiresAt": expiresAt,
+	})
+}
+
 func (gs *GameServer) handleListRooms(w http.ResponseWriter, r *http.Request) {
 	rooms := gs.roomManager.ListPublicRooms()
+	spectatableOnly := r.URL.Query().Get("spectatable") == "true"
 
 	// Convert to response format
-	roomList := make([]map[string]interface{}, len(rooms))
-	for i, room := range rooms {
-		roomList[i] = room.GetRoomStats()
+	roomList := make([]map[string]interface{}, 0, len(rooms))
+	for _, room := range rooms {
+		stats := room.GetRoomStats()
+		if spectatableOnly && stats["allowSpectators"] != true {
+			continue
+		}
+		roomList = append(roomList, stats)
 	}
 
 	gs.writeJSONResponse(w, map[string]interface{}{
@@ -337,58 +597,461 @@ func (gs *GameServer) handleListRooms(w http.ResponseWriter, r *http.Request) {
 }
 
 func (gs *GameServer) handleCreateRoom(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement room creation from HTTP request
-	// Parse request body for room settings
-	// Validate user authentication
-	// Create room and return room info
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		GameType minigame.GameType      `json:"gameType"`
+		Settings map[string]interface{} `json:"settings"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+	if req.GameType == "" {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "gameType is required")
+		return
+	}
+
+	room, err := gs.roomManager.CreateRoom(username, req.GameType, req.Settings)
+	if err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
 
-	http.Error(w, "Not implemented", http.StatusNotImplemented)
+	gs.writeJSONWithStatus(w, http.StatusCreated, gs.playerRoomStats(room, username))
 }
 
 func (gs *GameServer) handleGetRoom(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement get room info
-	// Parse room ID from URL
-	// Return room details
+	if _, ok := gs.requireUser(w, r); !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	room, exists := gs.roomManager.GetRoom(roomID)
+	if !exists {
+		gs.writeRoomError(w, ErrRoomNotFound)
+		return
+	}
 
-	http.Error(w, "Not implemented", http.StatusNotImplemented)
+	gs.writeJSONResponse(w, room.GetRoomStats())
 }
 
 func (gs *GameServer) handleJoinRoom(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement join room from HTTP request
-	// Parse room ID and user info
-	// Join room and return status
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Password string `json:"password"`
+		Spectate bool   `json:"spectate"`
+	}
+	if !gs.decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	stats, err := gs.joinRoom(roomID, username, req.Password, req.Spectate)
+	if err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
 
-	http.Error(w, "Not implemented", http.StatusNotImplemented)
+	gs.writeJSONResponse(w, stats)
 }
 
 func (gs *GameServer) handleLeaveRoom(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement leave room from HTTP request
-	http.Error(w, "Not implemented", http.StatusNotImplemented)
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := gs.leaveRoom(roomID, username); err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	gs.writeJSONResponse(w, map[string]interface{}{
+		"roomId": roomID,
+		"left":   true,
+	})
 }
 
 func (gs *GameServer) handleSetReady(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement set ready state from HTTP request
-	http.Error(w, "Not implemented", http.StatusNotImplemented)
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	req := struct {
+		Ready *bool `json:"ready"`
+	}{}
+	if !gs.decodeOptionalBody(w, r, &req) {
+		return
+	}
+	ready := true
+	if req.Ready != nil {
+		ready = *req.Ready
+	}
+
+	if err := gs.roomManager.SetPlayerReady(roomID, username, ready); err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	room, exists := gs.roomManager.GetRoom(roomID)
+	if !exists {
+		gs.writeRoomError(w, ErrRoomNotFound)
+		return
+	}
+
+	gs.writeJSONResponse(w, room.GetRoomStats())
 }
 
 func (gs *GameServer) handleStartGame(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement start game from HTTP request
-	http.Error(w, "Not implemented", http.StatusNotImplemented)
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := gs.roomManager.StartGame(roomID, username); err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	room, exists := gs.roomManager.GetRoom(roomID)
+	if !exists {
+		gs.writeRoomError(w, ErrRoomNotFound)
+		return
+	}
+
+	gs.writeJSONResponse(w, room.GetRoomStats())
+}
+
+func (gs *GameServer) handleRequestBackfill(w http.ResponseWriter, r *http.Request) {
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	room, exists := gs.roomManager.GetRoom(roomID)
+	if !exists {
+		gs.writeRoomError(w, ErrRoomNotFound)
+		return
+	}
+	room.mu.RLock()
+	isHost := room.HostUsername == username
+	room.mu.RUnlock()
+	if !isHost {
+		gs.writeRoomError(w, ErrNotHost)
+		return
+	}
+
+	if err := gs.matchmaking.RequestBackfill(roomID); err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	gs.writeJSONResponse(w, room.GetRoomStats())
 }
 
 func (gs *GameServer) handleGameAction(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement game action from HTTP request
-	http.Error(w, "Not implemented", http.StatusNotImplemented)
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	var action map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&action); err != nil || action == nil {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid action body")
+		return
+	}
+
+	if err := gs.roomManager.ProcessGameAction(roomID, username, action); err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	gs.writeJSONWithStatus(w, http.StatusAccepted, map[string]interface{}{
+		"roomId":   roomID,
+		"accepted": true,
+	})
+}
+
+// handleGetReplay returns the recorded replay of a closed room. Replays of
+// private rooms are only available to their players.
+func (gs *GameServer) handleGetReplay(w http.ResponseWriter, r *http.Request) {
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	roomID, ok := gs.parseRoomID(w, r)
+	if !ok {
+		return
+	}
+
+	replay, err := gs.roomManager.GetReplay(roomID)
+	if err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+	if replay.IsPrivate && !replay.HasPlayer(username) {
+		gs.writeRoomError(w, ErrPlayerNotInRoom)
+		return
+	}
+
+	gs.writeJSONResponse(w, replay)
+}
+
+// handleResumeMessage restores a dropped player's seat. Missed room events after
+// lastSeq are replayed first, followed by a "resumed" snapshot of the room.
+func (gs *GameServer) handleResumeMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	token, _ := message.Data["token"].(string)
+	lastSeq, _ := message.Data["lastSeq"].(float64)
+
+	room, resumeToken, err := gs.roomManager.ResumePlayer(conn.Username, token)
+	if err != nil {
+		return err
+	}
+
+	events, truncated := room.EventsSince(int64(lastSeq))
+	for _, event := range events {
+		gs.wsManager.sendToConnection(conn, event)
+	}
+
+	gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+		Type: MessageTypeResumed,
+		Data: map[string]interface{}{
+			"room":        room.GetRoomStats(),
+			"state":       room.StateSnapshot(),
+			"resumeToken": resumeToken,
+			"lastSeq":     room.LastEventSeq(),
+			"replayed":    len(events),
+			"truncated":   truncated,
+		},
+		Timestamp: time.Now(),
+		RoomID:    &room.ID,
+	})
+
+	return nil
+}
+
+// handleDisconnect starts the reconnect grace window for a dropped player
+func (gs *GameServer) handleDisconnect(conn *WebSocketConnection) {
+	if gs.forwardDisconnect(conn) {
+		return
+	}
+	gs.roomManager.PlayerDisconnected(conn.Username)
+	gs.roomManager.SpectatorDisconnected(conn.Username)
+
+	gs.eventBus.PublishEvent(CreateUserEvent(EventTypeDisconnect, conn.Username, map[string]interface{}{
+		"connectionId": conn.ID,
+		"timestamp":    time.Now(),
+	}))
+}
+
+// joinRoom joins a room as a player or spectator and returns the caller's view of it
+func (gs *GameServer) joinRoom(roomID uuid.UUID, username, password string, spectate bool) (map[string]interface{}, error) {
+	if spectate {
+		room, err := gs.roomManager.SpectateRoom(roomID, username, password)
+		if err != nil {
+			return nil, err
+		}
+		stats := room.GetRoomStats()
+		stats["role"] = "spectator"
+		return stats, nil
+	}
+
+	room, err := gs.roomManager.JoinRoom(roomID, username, password)
+	if err != nil {
+		return nil, err
+	}
+	stats := gs.playerRoomStats(room, username)
+	stats["role"] = "player"
+	return stats, nil
+}
+
+// leaveRoom removes the caller from a room they play or watch
+func (gs *GameServer) leaveRoom(roomID uuid.UUID, username string) error {
+	err := gs.roomManager.LeaveRoom(roomID, username)
+	if errors.Is(err, ErrPlayerNotInRoom) {
+		if gs.roomManager.StopSpectating(roomID, username) == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// handleJoinRoomMessage joins a room over the game socket.
+// Data: {"roomId": "...", "password": "...", "spectate": false} or {"code": "..."}
+func (gs *GameServer) handleJoinRoomMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	if code, _ := message.Data["code"].(string); code != "" {
+		room, err := gs.roomManager.JoinRoomByCode(code, conn.Username)
+		if err != nil {
+			return err
+		}
+		stats := gs.playerRoomStats(room, conn.Username)
+		stats["role"] = "player"
+		gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+			Type:      MessageTypeRoomJoined,
+			Data:      stats,
+			Timestamp: time.Now(),
+			RoomID:    &room.ID,
+		})
+		return nil
+	}
+
+	roomIDStr, _ := message.Data["roomId"].(string)
+	roomID, err := uuid.Parse(roomIDStr)
+	if err != nil {
+		return ErrRoomNotFound
+	}
+	password, _ := message.Data["password"].(string)
+	spectate, _ := message.Data["spectate"].(bool)
+
+	stats, err := gs.joinRoom(roomID, conn.Username, password, spectate)
+	if err != nil {
+		return err
+	}
+
+	gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+		Type:      MessageTypeRoomJoined,
+		Data:      stats,
+		Timestamp: time.Now(),
+		RoomID:    &roomID,
+	})
+	return nil
+}
+
+// handleLeaveRoomMessage leaves the room the sender plays or watches
+func (gs *GameServer) handleLeaveRoomMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	room, exists := gs.roomManager.GetUserRoom(conn.Username)
+	if !exists {
+		room, exists = gs.roomManager.GetSpectatingRoom(conn.Username)
+	}
+	if !exists {
+		return ErrPlayerNotInRoom
+	}
+
+	if err := gs.leaveRoom(room.ID, conn.Username); err != nil {
+		return err
+	}
+
+	gs.wsManager.sendToConnection(conn, &WebSocketMessage{
+		Type:      MessageTypeRoomLeft,
+		Data:      map[string]interface{}{"roomId": room.ID},
+		Timestamp: time.Now(),
+		RoomID:    &room.ID,
+	})
+	return nil
+}
+
+// playerRoomStats returns room stats including the caller's resume token
+func (gs *GameServer) playerRoomStats(room *GameRoom, username string) map[string]interface{} {
+	stats := room.GetRoomStats()
+	if token, ok := gs.roomManager.ResumeToken(room.ID, username); ok {
+		stats["resumeToken"] = token
+		stats["joinCode"] = room.JoinCode
+	}
+	return stats
+}
+
+// handleGameActionMessage forwards a game_action WebSocket message to the sender's room.
+// The message data carries the action itself: {"type": "click", "data": {...}}.
+func (gs *GameServer) handleGameActionMessage(conn *WebSocketConnection, message *WebSocketMessage) error {
+	room, exists := gs.roomManager.GetUserRoom(conn.Username)
+	if !exists {
+		if _, spectating := gs.roomManager.GetSpectatingRoom(conn.Username); spectating {
+			return ErrSpectatorCannotAct
+		}
+		return ErrPlayerNotInRoom
+	}
+	if message.RoomID != nil && *message.RoomID != room.ID {
+		return ErrPlayerNotInRoom
+	}
+	if message.Data == nil {
+		return fmt.Errorf("%w: missing action", ErrInvalidGameAction)
+	}
+
+	return gs.roomManager.ProcessGameAction(room.ID, conn.Username, message.Data)
 }
 
 func (gs *GameServer) handleJoinMatchmaking(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement join matchmaking from HTTP request
-	http.Error(w, "Not implemented", http.StatusNotImplemented)
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		GameType    minigame.GameType      `json:"gameType"`
+		Preferences map[string]interface{} `json:"preferences"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	// Match notifications are delivered over the user's game socket
+	request, err := gs.matchmaking.JoinMatchmaking(username, req.GameType, req.Preferences)
+	if err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	gs.writeJSONWithStatus(w, http.StatusAccepted, request)
 }
 
 func (gs *GameServer) handleLeaveMatchmaking(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement leave matchmaking from HTTP request
-	http.Error(w, "Not implemented", http.StatusNotImplemented)
+	username, ok := gs.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := gs.matchmaking.LeaveMatchmaking(username); err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	gs.writeJSONResponse(w, map[string]interface{}{
+		"left": true,
+	})
 }
 
 func (gs *GameServer) handleMatchmakingStatus(w http.ResponseWriter, r *http.Request) {
@@ -397,7 +1060,7 @@ func (gs *GameServer) handleMatchmakingStatus(w http.ResponseWriter, r *http.Req
 
 	status, err := gs.matchmaking.GetMatchmakingStatus(username)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		gs.writeRoomError(w, err)
 		return
 	}
 
@@ -407,13 +1070,13 @@ func (gs *GameServer) handleMatchmakingStatus(w http.ResponseWriter, r *http.Req
 func (gs *GameServer) handleListGameTypes(w http.ResponseWriter, r *http.Request) {
 	gameTypes := gs.miniGameEngine.ListGameTypes()
 	gs.writeJSONResponse(w, map[string]interface{}{
-		"gameTypes": gameTypes,
+		"gameTypes":   gameTypes,
+		"matchmaking": gs.gameTypes.List(),
 	})
 }
 
 func (gs *GameServer) handleStats(w http.ResponseWriter, r *http.Request) {
-	gs.updateStats()
-	gs.writeJSONResponse(w, gs.stats)
+	gs.writeJSONResponse(w, gs.GetStats())
 }
 
 func (gs *GameServer) handlePoolStats(w http.ResponseWriter, r *http.Request) {
@@ -437,24 +1100,173 @@ func (gs *GameServer) handleQueueStatus(w http.ResponseWriter, r *http.Request)
 	gameType := vars["gameType"]
 
 	if gameType == "" {
-		http.Error(w, "Game type is required", http.StatusBadRequest)
+		gs.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Game type is required")
 		return
 	}
 
-	// TODO: Connect to new matching system
-	// For now, return basic queue info
-	gs.writeJSONResponse(w, map[string]interface{}{
-		"game_type": gameType,
-		"queue_length": 0,
-		"estimated_wait": 30,
-		"active_rooms": 0,
-	})
+	status, err := gs.matchmaking.GetQueueStatus(minigame.GameType(gameType))
+	if err != nil {
+		gs.writeRoomError(w, err)
+		return
+	}
+
+	gs.writeJSONResponse(w, status)
 }
 
+// handleMatchingWebSocket opens the same game socket as /ws/{username} for
+// clients that only know their token; the user comes from the credentials
 func (gs *GameServer) handleMatchingWebSocket(w http.ResponseWriter, r *http.Request) {
-	// Create new matching handler instance
-	matchingHandler := NewMatchingWebSocketHandler()
-	matchingHandler.HandleWebSocket(w, r)
+	username, err := gs.authenticate(r)
+	if err != nil {
+		gs.writeAuthError(w, err)
+		return
+	}
+
+	gs.acceptWebSocket(w, r, username)
+}
+
+// requireUser authenticates the request and writes an error response on failure
+func (gs *GameServer) requireUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	username, err := gs.authenticate(r)
+	if err != nil {
+		gs.writeAuthError(w, err)
+		return "", false
+	}
+	return username, true
+}
+
+// writeAuthError writes an authentication failure
+func (gs *GameServer) writeAuthError(w http.ResponseWriter, err error) {
+	status := authStatus(err)
+	code := "UNAUTHORIZED"
+	if status == http.StatusForbidden {
+		code = "FORBIDDEN"
+	}
+	gs.writeError(w, status, code, err.Error())
+}
+
+// parseRoomID reads the roomId path variable
+func (gs *GameServer) parseRoomID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	roomID, err := uuid.Parse(mux.Vars(r)["roomId"])
+	if err != nil {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_ROOM_ID", "Invalid room ID")
+		return uuid.Nil, false
+	}
+	return roomID, true
+}
+
+// decodeOptionalBody decodes a JSON body if one was sent
+func (gs *GameServer) decodeOptionalBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+		gs.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return false
+	}
+	return true
+}
+
+// writeRoomError maps room manager errors to HTTP status codes and error codes
+func (gs *GameServer) writeRoomError(w http.ResponseWriter, err error) {
+	status, code := RoomErrorCode(err)
+	gs.writeError(w, status, code, err.Error())
+}
+
+// RoomErrorCode maps a room manager error to an HTTP status and a stable error code
+func RoomErrorCode(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrRoomNotFound):
+		return http.StatusNotFound, "ROOM_NOT_FOUND"
+	case errors.Is(err, ErrRoomFull):
+		return http.StatusConflict, "ROOM_FULL"
+	case errors.Is(err, ErrIncorrectPassword):
+		return http.StatusForbidden, "WRONG_PASSWORD"
+	case errors.Is(err, ErrNotHost):
+		return http.StatusForbidden, "NOT_HOST"
+	case errors.Is(err, ErrInvalidRoomState):
+		return http.StatusConflict, "WRONG_STATE"
+	case errors.Is(err, ErrAlreadyInRoom):
+		return http.StatusConflict, "ALREADY_IN_ROOM"
+	case errors.Is(err, ErrPlayerNotInRoom):
+		return http.StatusForbidden, "NOT_IN_ROOM"
+	case errors.Is(err, ErrUnsupportedGameType):
+		return http.StatusBadRequest, "UNSUPPORTED_GAME_TYPE"
+	case errors.Is(err, ErrInvalidGameAction):
+		return http.StatusBadRequest, "INVALID_ACTION"
+	case errors.Is(err, ErrInvalidResumeToken):
+		return http.StatusForbidden, "INVALID_RESUME_TOKEN"
+	case errors.Is(err, ErrSpectatorsNotAllowed):
+		return http.StatusForbidden, "SPECTATORS_NOT_ALLOWED"
+	case errors.Is(err, ErrSpectatorCannotAct):
+		return http.StatusForbidden, "SPECTATOR_CANNOT_ACT"
+	case errors.Is(err, ErrNotSpectating):
+		return http.StatusForbidden, "NOT_IN_ROOM"
+	case errors.Is(err, ErrReplayNotFound):
+		return http.StatusNotFound, "REPLAY_NOT_FOUND"
+	case errors.Is(err, ErrInputQueueFull):
+		return http.StatusTooManyRequests, "INPUT_QUEUE_FULL"
+	case errors.Is(err, ErrTooManyInputs):
+		return http.StatusTooManyRequests, "TOO_MANY_INPUTS"
+	case errors.Is(err, ErrAlreadyInQueue):
+		return http.StatusConflict, "ALREADY_IN_QUEUE"
+	case errors.Is(err, ErrNotInQueue):
+		return http.StatusNotFound, "NOT_IN_QUEUE"
+	case errors.Is(err, ErrMatchmakingCooldown):
+		return http.StatusTooManyRequests, "MATCHMAKING_COOLDOWN"
+	case errors.Is(err, ErrMatchmakingPenalty):
+		return http.StatusTooManyRequests, "MATCHMAKING_PENALTY"
+	case errors.Is(err, ErrMatchProposalNotFound):
+		return http.StatusNotFound, "MATCH_NOT_FOUND"
+	case errors.Is(err, ErrNoOpenSlots):
+		return http.StatusConflict, "NO_OPEN_SLOTS"
+	case errors.Is(err, ErrNotConnected):
+		return http.StatusConflict, "NOT_CONNECTED"
+	case errors.Is(err, ErrPlatformNotSupported):
+		return http.StatusBadRequest, "PLATFORM_NOT_SUPPORTED"
+	case errors.Is(err, ErrInvalidMatchmakingRequest):
+		return http.StatusBadRequest, "INVALID_REQUEST"
+	case errors.Is(err, ErrPartyNotFound):
+		return http.StatusNotFound, "PARTY_NOT_FOUND"
+	case errors.Is(err, ErrNotPartyLeader):
+		return http.StatusForbidden, "NOT_PARTY_LEADER"
+	case errors.Is(err, ErrAlreadyInParty):
+		return http.StatusConflict, "ALREADY_IN_PARTY"
+	case errors.Is(err, ErrNotFriends):
+		return http.StatusForbidden, "NOT_FRIENDS"
+	case errors.Is(err, ErrPartyFull):
+		return http.StatusConflict, "PARTY_FULL"
+	case errors.Is(err, ErrPartyInviteNotFound):
+		return http.StatusNotFound, "PARTY_INVITE_NOT_FOUND"
+	case errors.Is(err, ErrJoinCodeNotFound):
+		return http.StatusNotFound, "JOIN_CODE_NOT_FOUND"
+	case errors.Is(err, ErrRoomInviteNotFound):
+		return http.StatusNotFound, "INVITE_NOT_FOUND"
+	case errors.Is(err, ErrInvalidRoomPassword):
+		return http.StatusBadRequest, "INVALID_PASSWORD"
+	case errors.Is(err, ErrGamePaused):
+		return http.StatusConflict, "GAME_PAUSED"
+	case errors.Is(err, ErrNoPausesLeft):
+		return http.StatusConflict, "NO_PAUSES_LEFT"
+	case errors.Is(err, ErrAlreadyVoted):
+		return http.StatusConflict, "ALREADY_VOTED"
+	case errors.Is(err, ErrBannedFromRoom):
+		return http.StatusForbidden, "BANNED_FROM_ROOM"
+	case errors.Is(err, ErrInvalidRoomSettings):
+		return http.StatusBadRequest, "INVALID_SETTINGS"
+	case errors.Is(err, ErrInvalidChatMessage):
+		return http.StatusBadRequest, "INVALID_CHAT_MESSAGE"
+	case errors.Is(err, ErrChatRateLimited):
+		return http.StatusTooManyRequests, "CHAT_RATE_LIMITED"
+	default:
+		return http.StatusInternalServerError, "INTERNAL_SERVER_ERROR"
+	}
+}
+
+// authenticate resolves the caller of a request. Requests are rejected when
+// no authenticator is configured.
+func (gs *GameServer) authenticate(r *http.Request) (string, error) {
+	if gs.authenticator == nil {
+		return "", ErrMissingCredentials
+	}
+	return gs.authenticator.AuthenticateRequest(r)
 }
 
 // Middleware
@@ -462,7 +1274,7 @@ func (gs *GameServer) handleMatchingWebSocket(w http.ResponseWriter, r *http.Req
 func (gs *GameServer) corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*") // Configure properly for production
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 
@@ -503,25 +1315,110 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Hijack lets WebSocket upgrades pass through the logging middleware
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	rw.statusCode = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+// writeError writes an error envelope in the pkg/response format
+func (gs *GameServer) writeError(w http.ResponseWriter, status int, code, message string) {
+	gs.writeEnvelope(w, status, response.APIResponse{
+		Success: false,
+		Error: &response.Error{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
+
+// writeJSONWithStatus writes a success envelope with a custom status code
+func (gs *GameServer) writeJSONWithStatus(w http.ResponseWriter, status int, data interface{}) {
+	gs.writeEnvelope(w, status, response.APIResponse{
+		Success: status < http.StatusBadRequest,
+		Data:    data,
+	})
+}
+
+// writeJSONResponse writes a 200 success envelope
 func (gs *GameServer) writeJSONResponse(w http.ResponseWriter, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
+	gs.writeJSONWithStatus(w, http.StatusOK, data)
+}
+
+// writeEnvelope encodes the envelope before writing any headers so encoding
+// failures can still be reported as a well-formed 500 response
+func (gs *GameServer) writeEnvelope(w http.ResponseWriter, status int, envelope response.APIResponse) {
+	body, err := gs.encodeJSON(envelope)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body, _ = gs.encodeJSON(response.APIResponse{
+			Success: false,
+			Error: &response.Error{
+				Code:    "INTERNAL_SERVER_ERROR",
+				Message: "Failed to encode response",
+			},
+		})
+	}
 
-	// TODO: Use proper JSON encoding with error handling
-	// For now, we'll use a simple approach
-	if err := gs.encodeJSON(w, data); err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		fmt.Printf("❌ Failed to write response: %v\n", err)
 	}
 }
 
-func (gs *GameServer) encodeJSON(w http.ResponseWriter, data interface{}) error {
-	// TODO: Implement proper JSON encoding
-	// This is a placeholder
-	fmt.Fprintf(w, `{"status": "ok", "data": %v}`, data)
-	return nil
+func (gs *GameServer) encodeJSON(data interface{}) ([]byte, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return append(body, '\n'), nil
 }
 
 // Statistics and monitoring
 
+// matchStatsTTL is how long the match history aggregates are served from memory.
+// They scan the whole history, so stats requests must not reload them each time.
+const matchStatsTTL = 30 * time.Second
+
+// refreshMatchStats reloads the match history aggregates once they are older
+// than maxAge. While another reload runs the current aggregates are kept.
+func (gs *GameServer) refreshMatchStats(maxAge time.Duration) {
+	if gs.roomManager.matches == nil {
+		return
+	}
+
+	if !gs.matchStatsMu.TryLock() {
+		return
+	}
+	defer gs.matchStatsMu.Unlock()
+
+	if !gs.matchStatsAt.IsZero() && time.Since(gs.matchStatsAt) < maxAge {
+		return
+	}
+	// A failing store is retried after the TTL, not on every request
+	gs.matchStatsAt = time.Now()
+
+	matchStats, err := gs.roomManager.matches.MatchStats()
+	if err != nil {
+		fmt.Printf("⚠️ Failed to load match stats: %v\n", err)
+		return
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	gs.stats.TotalGamesPlayed = matchStats.TotalMatches
+	gs.stats.TotalPlayersServed = matchStats.TotalPlayers
+	gs.stats.AverageGameDuration = matchStats.AverageDuration.Seconds()
+	gs.stats.PopularGameTypes = matchStats.GameTypes
+}
+
+// updateStats refreshes the statistics kept in memory
 func (gs *GameServer) updateStats() {
 	gs.mu.Lock()
 	defer gs.mu.Unlock()
@@ -542,42 +1439,26 @@ func (gs *GameServer) updateStats() {
 
 	// TODO: Add more detailed statistics
 	// - Memory usage
-	// - Game type popularity
-	// - Average game duration
 	// - Player metrics
 }
 
 func (gs *GameServer) updateStatsRoutine() {
-	ticker := time.NewTicker(30 * time.Second)
+	ticker := time.NewTicker(matchStatsTTL)
 	defer ticker.Stop()
 
+	gs.refreshMatchStats(0)
+	gs.updateStats()
 	for {
 		select {
 		case <-gs.ctx.Done():
 			return
 		case <-ticker.C:
+			gs.refreshMatchStats(0)
 			gs.updateStats()
 		}
 	}
 }
 
-func (gs *GameServer) metricsRoutine() {
-	ticker := time.NewTicker(60 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-gs.ctx.Done():
-			return
-		case <-ticker.C:
-			// TODO: Collect and send metrics to monitoring system
-			// - Prometheus metrics
-			// - Custom application metrics
-			// - Performance metrics
-		}
-	}
-}
-
 // Public API methods
 
 // GetConfig returns the server configuration
@@ -585,10 +1466,18 @@ func (gs *GameServer) GetConfig() *GameServerConfig {
 	return gs.config
 }
 
-// GetStats returns current server statistics
+// GetStats returns a snapshot of the current server statistics. Match history
+// aggregates are reloaded by the stats routine, and here only once they are
+// older than matchStatsTTL.
 func (gs *GameServer) GetStats() *GameServerStats {
+	gs.refreshMatchStats(matchStatsTTL)
 	gs.updateStats()
-	return gs.stats
+
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	snapshot := *gs.stats
+	return &snapshot
 }
 
 // IsRunning returns whether the server is currently running
@@ -598,6 +1487,11 @@ func (gs *GameServer) IsRunning() bool {
 	return gs.isRunning
 }
 
+// Handler returns the HTTP handler serving the game server routes
+func (gs *GameServer) Handler() http.Handler {
+	return gs.router
+}
+
 // GetWebSocketManager returns the WebSocket manager
 func (gs *GameServer) GetWebSocketManager() *WebSocketManager {
 	return gs.wsManager
@@ -0,0 +1,105 @@
+// internal/gameserver/server_test.go
+package gameserver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGameServer(t *testing.T, opts ...gameserver.Option) *gameserver.GameServer {
+	t.Helper()
+	return gameserver.NewGameServer(nil, minigame.NewMiniGameEngine(nil, nil), opts...)
+}
+
+func doRequest(t *testing.T, gs *gameserver.GameServer, method, path, body string, headers map[string]string) (*httptest.ResponseRecorder, response.APIResponse) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	gs.Handler().ServeHTTP(rec, req)
+
+	var envelope response.APIResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope), rec.Body.String())
+	return rec, envelope
+}
+
+func TestGameServer_ListRoomsEnvelope(t *testing.T) {
+	gs := newTestGameServer(t)
+
+	rec, envelope := doRequest(t, gs, "GET", "/api/v1/rooms", "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.True(t, envelope.Success)
+	assert.Nil(t, envelope.Error)
+
+	data, ok := envelope.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(0), data["total"])
+}
+
+func TestGameServer_HealthReportsUnavailableWhenStopped(t *testing.T) {
+	gs := newTestGameServer(t)
+
+	rec, envelope := doRequest(t, gs, "GET", "/health", "", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.False(t, envelope.Success)
+}
+
+func TestGameServer_StartServesHealthAndStats(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	config := gameserver.GetDefaultConfig()
+	config.Port = listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	gs := gameserver.NewGameServer(config, minigame.NewMiniGameEngine(nil, nil))
+	started := make(chan error, 1)
+	go func() { started <- gs.Start() }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", config.Port)
+	client := &http.Client{Timeout: time.Second}
+	require.Eventually(t, func() bool {
+		resp, err := client.Get(baseURL + "/health")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+
+	resp, err := client.Get(baseURL + "/api/v1/stats")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, gs.Stop())
+	select {
+	case err := <-started:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return after Stop")
+	}
+}
+
+func TestGameServer_RoomAPIRequiresAuth(t *testing.T) {
+	gs := newTestGameServer(t)
+
+	rec, envelope := doRequest(t, gs, "POST", "/api/v1/rooms", `{"gameType":"click_speed"}`, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.NotNil(t, envelope.Error)
+	assert.Equal(t, "UNAUTHORIZED", envelope.Error.Code)
+}
@@ -0,0 +1,262 @@
+// internal/gameserver/session.go
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+)
+
+// ErrInvalidGameAction is returned when an action is rejected by the game rules
+var ErrInvalidGameAction = errors.New("invalid game action")
+
+// Reasons recorded when a room's game session ends
+const (
+	GameEndReasonTimeout   = "timeout"
+	GameEndReasonCompleted = "completed"
+	GameEndReasonManual    = "manual"
+)
+
+// hiddenGameData lists game data keys that must not reach clients while a game is running
+var hiddenGameData = map[string]bool{
+	"targetNumber": true,
+}
+
+// startSession creates the shared, server-owned session for a room and a
+// per-player state seeded from it (assumes room lock is held)
+func (room *GameRoom) startSession(now time.Time) error {
+	session, err := room.miniGameEngine.NewGameState(room.GameType, room.HostUsername)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUnsupportedGameType, room.GameType)
+	}
+	session.StartTime = now
+	session.LastActivity = now
+	room.GameSession = session
+	room.departed = make(map[string]int)
+	room.resetPauses()
+
+	for username, player := range room.Players {
+		// Every player starts from the same game data so shared values such
+		// as the number to guess are identical across the room
+		state := &minigame.GameState{
+			SessionID:      session.SessionID,
+			GameType:       session.GameType,
+			PlayerUsername: username,
+			StartTime:      now,
+			GameData:       copyGameData(session.GameData),
+			Status:         minigame.GameStatusInProgress,
+			LastActivity:   now,
+		}
+
+		player.mu.Lock()
+		player.Score = 0
+		player.session = state
+		player.GameData = state.GameData
+		player.mu.Unlock()
+	}
+
+	return nil
+}
+
+// joinSession gives a player who joins a game in progress the same starting
+// game data everyone else started from (assumes room lock is held)
+func (room *GameRoom) joinSession(player *Player, now time.Time) {
+	session := room.GameSession
+	if session == nil {
+		return
+	}
+
+	state := &minigame.GameState{
+		SessionID:      session.SessionID,
+		GameType:       session.GameType,
+		PlayerUsername: player.Username,
+		StartTime:      now,
+		GameData:       copyGameData(session.GameData),
+		Status:         minigame.GameStatusInProgress,
+		LastActivity:   now,
+	}
+
+	player.mu.Lock()
+	player.session = state
+	player.GameData = state.GameData
+	player.mu.Unlock()
+}
+
+// applyAction runs a player's action through the game rules and returns the
+// updated player state (assumes room lock is held)
+func (room *GameRoom) applyAction(player *Player, action map[string]interface{}, now time.Time) (*minigame.GameState, error) {
+	actionType, _ := action["type"].(string)
+	if actionType == "" {
+		return nil, fmt.Errorf("%w: missing action type", ErrInvalidGameAction)
+	}
+	data, _ := action["data"].(map[string]interface{})
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+
+	player.mu.Lock()
+	defer player.mu.Unlock()
+
+	state := player.session
+	if state == nil {
+		return nil, fmt.Errorf("%w: no active session for player", ErrInvalidRoomState)
+	}
+	if state.Status != minigame.GameStatusInProgress {
+		return nil, fmt.Errorf("%w: player has already finished", ErrInvalidRoomState)
+	}
+
+	state.LastActivity = now
+	if _, err := room.miniGameEngine.ApplyAction(state, minigame.GameAction{
+		Type:      actionType,
+		Data:      data,
+		Timestamp: now,
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGameAction, err)
+	}
+
+	if state.Status == minigame.GameStatusCompleted {
+		state.EndTime = &now
+	}
+	player.Score = state.CurrentScore
+	player.LastAction = &now
+
+	return state, nil
+}
+
+// sessionDeadline returns when the current session runs out of time, pushed
+// back by the time spent paused (assumes room lock is held)
+func (room *GameRoom) sessionDeadline() time.Time {
+	if room.GameSession == nil || room.GameConfig == nil {
+		return time.Time{}
+	}
+	return room.GameSession.StartTime.Add(room.GameConfig.Duration + room.pausedTotal)
+}
+
+// allPlayersFinished reports whether every player's state has completed (assumes room lock is held)
+func (room *GameRoom) allPlayersFinished() bool {
+	if len(room.Players) == 0 {
+		return false
+	}
+	for _, player := range room.Players {
+		player.mu.RLock()
+		finished := player.session != nil && player.session.Status == minigame.GameStatusCompleted
+		player.mu.RUnlock()
+		if !finished {
+			return false
+		}
+	}
+	return true
+}
+
+// sessionSnapshot builds an authoritative view of the session safe to hand
+// to the event processor (assumes room lock is held)
+func (room *GameRoom) sessionSnapshot(now time.Time) map[string]interface{} {
+	players := make(map[string]interface{}, len(room.Players))
+	leader, topScore := "", -1
+	for username, player := range room.Players {
+		player.mu.RLock()
+		status := minigame.GameStatusWaiting
+		if player.session != nil {
+			status = player.session.Status
+		}
+		players[username] = map[string]interface{}{
+			"score":    player.Score,
+			"status":   status,
+			"gameData": visibleGameData(player.GameData),
+		}
+		if player.Score > topScore {
+			leader, topScore = username, player.Score
+		}
+		player.mu.RUnlock()
+	}
+
+	snapshot := map[string]interface{}{
+		"players": players,
+		"leader":  leader,
+	}
+
+	if session := room.GameSession; session != nil {
+		// The clock stands still while paused
+		if room.isPaused() {
+			now = *room.pausedAt
+		}
+		remaining := room.sessionDeadline().Sub(now)
+		if remaining < 0 || session.Status != minigame.GameStatusInProgress {
+			remaining = 0
+		}
+		snapshot["sessionId"] = session.SessionID
+		snapshot["gameType"] = session.GameType
+		snapshot["status"] = session.Status
+		snapshot["startTime"] = session.StartTime
+		snapshot["endsAt"] = room.sessionDeadline()
+		snapshot["remainingMs"] = remaining.Milliseconds()
+		snapshot["paused"] = room.isPaused()
+	}
+
+	return snapshot
+}
+
+// runSessionTimer ends the session once GameConfig.Duration of unpaused play
+// has elapsed and resumes pauses that run out
+func (rm *RoomManager) runSessionTimer(room *GameRoom, sessionID uuid.UUID, duration time.Duration) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-room.ctx.Done():
+			return
+		case <-timer.C:
+		case <-room.sessionWake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+
+		room.mu.Lock()
+		if room.State != RoomStateInProgress || room.GameSession == nil || room.GameSession.SessionID != sessionID {
+			room.mu.Unlock()
+			return
+		}
+
+		now := time.Now()
+		if room.isPaused() && rm.sessionCheckIn(room, now) <= 0 {
+			rm.resumeGame(room, "", now)
+		}
+		wait := rm.sessionCheckIn(room, now)
+		if wait <= 0 && !room.isPaused() {
+			rm.finishGame(room, GameEndReasonTimeout)
+			room.mu.Unlock()
+			return
+		}
+		room.mu.Unlock()
+
+		timer.Reset(wait)
+	}
+}
+
+// visibleGameData returns a copy of game data without server-only keys
+func visibleGameData(data map[string]interface{}) map[string]interface{} {
+	visible := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		if !hiddenGameData[k] {
+			visible[k] = v
+		}
+	}
+	return visible
+}
+
+// copyGameData returns a shallow copy of game-specific data
+func copyGameData(data map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		copied[k] = v
+	}
+	return copied
+}
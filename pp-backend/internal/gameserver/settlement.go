@@ -0,0 +1,246 @@
+// internal/gameserver/settlement.go
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+)
+
+// GameRecorder stores per-player game sessions and scores
+type GameRecorder interface {
+	GetGameByName(name string) (*repository.Game, error)
+	CreateGame(name, description string) (*repository.Game, error)
+	CreateGameSession(gameID uuid.UUID, playerUsername string) (*repository.GameSession, error)
+	EndGameSession(sessionID uuid.UUID, playerUsername string, score int) (*repository.GameSession, error)
+}
+
+// LeaderboardRecorder records results on the mini game leaderboards
+type LeaderboardRecorder interface {
+	RecordResult(gameType, username string, score, points, durationSeconds int) (bool, error)
+}
+
+// PointsAwarder pays out points earned in games
+type PointsAwarder interface {
+	AddPoints(userUsername string, amount int, description string) (*repository.PointTransaction, error)
+}
+
+// PlayerResult is one player's outcome of a finished room game
+type PlayerResult struct {
+	Username     string `json:"username"`
+	Placement    int    `json:"placement"`
+	Score        int    `json:"score"`
+	PointsEarned int    `json:"pointsEarned"`
+	IsValid      bool   `json:"isValid"`
+	Reason       string `json:"reason,omitempty"`
+	IsBot        bool   `json:"isBot,omitempty"`
+	Forfeited    bool   `json:"forfeited,omitempty"` // Left before the game ended
+}
+
+// RoomResult is the outcome of a finished room game
+type RoomResult struct {
+	RoomID    uuid.UUID         `json:"roomId"`
+	SessionID uuid.UUID         `json:"sessionId"`
+	GameType  minigame.GameType `json:"gameType"`
+	StartTime time.Time         `json:"startTime"`
+	EndTime   time.Time         `json:"endTime"`
+	EndReason string            `json:"endReason"`
+	Players   []*PlayerResult   `json:"players"`
+}
+
+// Duration returns how long the game ran
+func (r *RoomResult) Duration() time.Duration {
+	return r.EndTime.Sub(r.StartTime)
+}
+
+// Settlement persists finished room games and pays out rewards
+type Settlement struct {
+	games       GameRecorder
+	leaderboard LeaderboardRecorder
+	points      PointsAwarder
+	gameIDs     map[minigame.GameType]uuid.UUID
+	mu          sync.Mutex
+}
+
+// NewSettlement creates a settlement backed by the given stores. Any of them may be nil.
+func NewSettlement(games GameRecorder, leaderboard LeaderboardRecorder, points PointsAwarder) *Settlement {
+	return &Settlement{
+		games:       games,
+		leaderboard: leaderboard,
+		points:      points,
+		gameIDs:     make(map[minigame.GameType]uuid.UUID),
+	}
+}
+
+// Settle writes game_sessions/game_scores rows, records leaderboard entries
+// and awards points for every player of a finished room game
+func (s *Settlement) Settle(result *RoomResult) error {
+	var errs []error
+
+	gameID, err := s.gameID(result.GameType)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	durationSeconds := int(result.Duration().Seconds())
+	for _, player := range result.Players {
+		if player.IsBot {
+			continue
+		}
+		if s.games != nil && gameID != uuid.Nil {
+			if err := s.recordSession(gameID, player); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if !player.IsValid {
+			continue
+		}
+
+		if s.leaderboard != nil {
+			if _, err := s.leaderboard.RecordResult(string(result.GameType), player.Username, player.Score, player.PointsEarned, durationSeconds); err != nil {
+				errs = append(errs, fmt.Errorf("failed to record leaderboard result for %s: %w", player.Username, err))
+			}
+		}
+
+		if s.points != nil && player.PointsEarned > 0 {
+			description := fmt.Sprintf("%s multiplayer game - Score: %d, Place: %d", result.GameType, player.Score, player.Placement)
+			if _, err := s.points.AddPoints(player.Username, player.PointsEarned, description); err != nil {
+				errs = append(errs, fmt.Errorf("failed to award points to %s: %w", player.Username, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// recordSession stores a completed game session and its score for one player
+func (s *Settlement) recordSession(gameID uuid.UUID, player *PlayerResult) error {
+	session, err := s.games.CreateGameSession(gameID, player.Username)
+	if err != nil {
+		return fmt.Errorf("failed to create game session for %s: %w", player.Username, err)
+	}
+	if _, err := s.games.EndGameSession(session.ID, player.Username, player.Score); err != nil {
+		return fmt.Errorf("failed to end game session for %s: %w", player.Username, err)
+	}
+	return nil
+}
+
+// gameID resolves the games row for a mini game type, creating it on first use
+func (s *Settlement) gameID(gameType minigame.GameType) (uuid.UUID, error) {
+	if s.games == nil {
+		return uuid.Nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, exists := s.gameIDs[gameType]; exists {
+		return id, nil
+	}
+
+	game, err := s.games.GetGameByName(string(gameType))
+	if err != nil {
+		game, err = s.games.CreateGame(string(gameType), fmt.Sprintf("Multiplayer %s mini game", gameType))
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to resolve game %s: %w", gameType, err)
+		}
+	}
+
+	s.gameIDs[gameType] = game.ID
+	return game.ID, nil
+}
+
+// rankPlayers orders results by score and assigns placements; tied scores share a placement
+func rankPlayers(players []*PlayerResult) {
+	sort.SliceStable(players, func(i, j int) bool {
+		if players[i].Score != players[j].Score {
+			return players[i].Score > players[j].Score
+		}
+		return players[i].Username < players[j].Username
+	})
+
+	for i, player := range players {
+		if i > 0 && player.Score == players[i-1].Score {
+			player.Placement = players[i-1].Placement
+		} else {
+			player.Placement = i + 1
+		}
+	}
+}
+
+// buildResult computes placements and rewards for a finished room (assumes room lock is held)
+func (room *GameRoom) buildResult(reason string, endTime time.Time) *RoomResult {
+	result := &RoomResult{
+		RoomID:    room.ID,
+		GameType:  room.GameType,
+		EndTime:   endTime,
+		EndReason: reason,
+		Players:   make([]*PlayerResult, 0, len(room.Players)),
+	}
+	if room.GameSession != nil {
+		result.SessionID = room.GameSession.SessionID
+		result.StartTime = room.GameSession.StartTime
+	} else if room.StartTime != nil {
+		result.StartTime = *room.StartTime
+	}
+
+	for username, player := range room.Players {
+		player.mu.RLock()
+		result.Players = append(result.Players, &PlayerResult{Username: username, Score: player.Score, IsBot: player.IsBot})
+		player.mu.RUnlock()
+	}
+	rankPlayers(result.Players)
+
+	// Players who left are ranked after everyone who stayed
+	forfeited := make([]*PlayerResult, 0, len(room.departed))
+	for username, score := range room.departed {
+		forfeited = append(forfeited, &PlayerResult{Username: username, Score: score, Forfeited: true})
+	}
+	rankPlayers(forfeited)
+	for _, player := range forfeited {
+		player.Placement += len(result.Players)
+	}
+	result.Players = append(result.Players, forfeited...)
+
+	if reason == GameEndReasonAborted {
+		for _, player := range result.Players {
+			player.Reason = "game aborted"
+		}
+		return result
+	}
+
+	for _, player := range result.Players {
+		// Bots take part in the ranking but never earn anything
+		if player.IsBot {
+			player.Reason = "bot"
+			continue
+		}
+		if player.Forfeited {
+			player.Reason = "forfeited"
+			continue
+		}
+		reward, err := room.miniGameEngine.CalculateReward(&minigame.GameResult{
+			SessionID:      result.SessionID,
+			PlayerUsername: player.Username,
+			GameType:       result.GameType,
+			FinalScore:     player.Score,
+			Duration:       result.Duration(),
+		})
+		if err != nil {
+			player.Reason = err.Error()
+			continue
+		}
+		player.PointsEarned = reward.PointsEarned
+		player.IsValid = reward.IsValid
+		player.Reason = reward.Reason
+	}
+
+	return result
+}
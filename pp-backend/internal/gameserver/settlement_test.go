@@ -0,0 +1,192 @@
+// internal/gameserver/settlement_test.go
+package gameserver_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingStores struct {
+	mu       sync.Mutex
+	games    map[string]*repository.Game
+	scores   map[string]int
+	points   map[string]int
+	entries  int
+	sessions int
+}
+
+func newRecordingStores() *recordingStores {
+	return &recordingStores{
+		games:  make(map[string]*repository.Game),
+		scores: make(map[string]int),
+		points: make(map[string]int),
+	}
+}
+
+func (s *recordingStores) GetGameByName(name string) (*repository.Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if game, ok := s.games[name]; ok {
+		return game, nil
+	}
+	return nil, repository.ErrGameNotFound
+}
+
+func (s *recordingStores) CreateGame(name, description string) (*repository.Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	game := &repository.Game{ID: uuid.New(), Name: name}
+	s.games[name] = game
+	return game, nil
+}
+
+func (s *recordingStores) CreateGameSession(gameID uuid.UUID, playerUsername string) (*repository.GameSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions++
+	return &repository.GameSession{ID: uuid.New(), GameID: gameID, PlayerUsername: playerUsername}, nil
+}
+
+func (s *recordingStores) EndGameSession(sessionID uuid.UUID, playerUsername string, score int) (*repository.GameSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scores[playerUsername] = score
+	return &repository.GameSession{ID: sessionID, PlayerUsername: playerUsername, Status: "completed"}, nil
+}
+
+func (s *recordingStores) RecordResult(gameType, username string, score, points, durationSeconds int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries++
+	return true, nil
+}
+
+func (s *recordingStores) AddPoints(userUsername string, amount int, description string) (*repository.PointTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.points[userUsername] += amount
+	return &repository.PointTransaction{}, nil
+}
+
+func (s *recordingStores) counts() (sessions, entries, payouts int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessions, s.entries, len(s.points)
+}
+
+func TestRoomManager_EndGameSettlesOnce(t *testing.T) {
+	stores := newRecordingStores()
+	gs := gameserver.NewGameServer(nil, minigame.NewMiniGameEngine(nil, nil),
+		gameserver.WithSettlement(gameserver.NewSettlement(stores, stores, stores)),
+	)
+	rm := gs.GetRoomManager()
+
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+
+	click := map[string]interface{}{"type": "click"}
+	for i := 0; i < 12; i++ {
+		require.NoError(t, rm.ProcessGameAction(room.ID, "host", click))
+	}
+	for i := 0; i < 11; i++ {
+		require.NoError(t, rm.ProcessGameAction(room.ID, "guest", click))
+	}
+
+	require.NoError(t, rm.EndGame(room.ID))
+	assert.ErrorIs(t, rm.EndGame(room.ID), gameserver.ErrInvalidRoomState)
+
+	require.Eventually(t, func() bool {
+		sessions, entries, payouts := stores.counts()
+		return sessions == 2 && entries == 2 && payouts == 2
+	}, time.Second, 10*time.Millisecond)
+
+	require.NotNil(t, room.Result)
+	assert.Equal(t, "host", room.Result.Players[0].Username)
+	assert.Equal(t, 1, room.Result.Players[0].Placement)
+	assert.Equal(t, 2, room.Result.Players[1].Placement)
+
+	stores.mu.Lock()
+	defer stores.mu.Unlock()
+	assert.Equal(t, 12, stores.scores["host"])
+	assert.Equal(t, room.Result.Players[0].PointsEarned, stores.points["host"])
+	assert.Len(t, stores.games, 1)
+}
+
+func TestRoomManager_SettlesPlayersWhoLeftMidGame(t *testing.T) {
+	stores := newRecordingStores()
+	gs := gameserver.NewGameServer(nil, minigame.NewMiniGameEngine(nil, nil),
+		gameserver.WithSettlement(gameserver.NewSettlement(stores, stores, stores)),
+	)
+	rm := gs.GetRoomManager()
+
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+
+	click := map[string]interface{}{"type": "click"}
+	require.NoError(t, rm.ProcessGameAction(room.ID, "host", click))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, rm.ProcessGameAction(room.ID, "guest", click))
+	}
+
+	// The leader quits and still ranks behind everyone who stayed
+	require.NoError(t, rm.LeaveRoom(room.ID, "guest"))
+	require.NoError(t, rm.EndGame(room.ID))
+
+	require.NotNil(t, room.Result)
+	require.Len(t, room.Result.Players, 2)
+	assert.Equal(t, "host", room.Result.Players[0].Username)
+	assert.Equal(t, 1, room.Result.Players[0].Placement)
+	guest := room.Result.Players[1]
+	assert.Equal(t, "guest", guest.Username)
+	assert.Equal(t, 2, guest.Placement)
+	assert.Equal(t, 5, guest.Score)
+	assert.True(t, guest.Forfeited)
+	assert.False(t, guest.IsValid)
+	assert.Zero(t, guest.PointsEarned)
+
+	require.Eventually(t, func() bool {
+		sessions, _, _ := stores.counts()
+		return sessions == 2
+	}, time.Second, 10*time.Millisecond)
+	stores.mu.Lock()
+	defer stores.mu.Unlock()
+	assert.Equal(t, 5, stores.scores["guest"])
+	assert.NotContains(t, stores.points, "guest")
+}
+
+func TestSettlement_UnverifiedGamesEarnNothing(t *testing.T) {
+	engine := minigame.NewMiniGameEngine(nil, nil)
+
+	for _, gameType := range []minigame.GameType{minigame.GameTypePaintBattle, minigame.GameTypePhysicsJump} {
+		reward, err := engine.CalculateReward(&minigame.GameResult{PlayerUsername: "host", GameType: gameType, FinalScore: 50})
+		require.NoError(t, err)
+		assert.False(t, reward.IsValid, gameType)
+		assert.Zero(t, reward.PointsEarned, gameType)
+	}
+}
+
+func TestSettlement_SkipsInvalidScores(t *testing.T) {
+	stores := newRecordingStores()
+	settlement := gameserver.NewSettlement(stores, stores, stores)
+
+	err := settlement.Settle(&gameserver.RoomResult{
+		GameType: minigame.GameTypeClickSpeed,
+		Players: []*gameserver.PlayerResult{
+			{Username: "idle", Placement: 1, Score: 0, IsValid: false},
+		},
+	})
+	require.NoError(t, err)
+
+	sessions, entries, payouts := stores.counts()
+	assert.Equal(t, 1, sessions)
+	assert.Zero(t, entries)
+	assert.Zero(t, payouts)
+}
@@ -0,0 +1,229 @@
+// internal/gameserver/spectator.go
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Spectator errors returned by RoomManager
+var (
+	ErrSpectatorsNotAllowed = errors.New("spectators are not allowed in this room")
+	ErrSpectatorCannotAct   = errors.New("spectators cannot perform game actions")
+	ErrNotSpectating        = errors.New("user is not spectating this room")
+)
+
+const (
+	// maxSpectatorDelay caps the broadcast delay a host can configure
+	maxSpectatorDelay = 5 * time.Minute
+
+	// spectatorFeedSize is the number of room broadcasts buffered for spectators
+	spectatorFeedSize = 1024
+)
+
+// Room event types for spectators
+const (
+	RoomEventSpectatorJoined = "spectator_joined"
+	RoomEventSpectatorLeft   = "spectator_left"
+)
+
+// Spectator represents a user watching a game room
+type Spectator struct {
+	Username string    `json:"username"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+// parseSpectatorSettings reads spectator options from room settings
+func parseSpectatorSettings(settings map[string]interface{}) (allow bool, delay time.Duration) {
+	allow = true
+	if settings == nil {
+		return allow, 0
+	}
+	if v, ok := settings["allowSpectators"].(bool); ok {
+		allow = v
+	}
+	if v, ok := settings["spectatorDelay"].(float64); ok && v > 0 {
+		delay = time.Duration(v * float64(time.Second))
+		if delay > maxSpectatorDelay {
+			delay = maxSpectatorDelay
+		}
+	}
+	return allow, delay
+}
+
+// SpectateRoom adds a user to a room as a spectator
+func (rm *RoomManager) SpectateRoom(roomID uuid.UUID, username string, password string) (*GameRoom, error) {
+	passwordOK := rm.checkRoomPassword(roomID, password)
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if _, exists := rm.userRooms[username]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInRoom, username)
+	}
+	if _, exists := rm.spectators[username]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyInRoom, username)
+	}
+
+	room, exists := rm.rooms[roomID]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.bannedUsers[username] {
+		return nil, ErrBannedFromRoom
+	}
+	if !room.AllowSpectators {
+		return nil, ErrSpectatorsNotAllowed
+	}
+	if room.State == RoomStateClosed {
+		return nil, fmt.Errorf("%w: room is closed", ErrInvalidRoomState)
+	}
+	if room.IsPrivate && !passwordOK {
+		return nil, ErrIncorrectPassword
+	}
+
+	now := time.Now()
+	room.spectatorMu.Lock()
+	room.Spectators[username] = &Spectator{Username: username, JoinedAt: now}
+	count := len(room.Spectators)
+	room.spectatorMu.Unlock()
+
+	rm.spectators[username] = roomID
+	room.LastActivity = now
+
+	room.emitEvent(&GameRoomEvent{
+		Type:      RoomEventSpectatorJoined,
+		RoomID:    roomID,
+		Username:  username,
+		Data:      map[string]interface{}{"spectatorCount": count},
+		Timestamp: now,
+	})
+
+	return room, nil
+}
+
+// StopSpectating removes a spectator from a room
+func (rm *RoomManager) StopSpectating(roomID uuid.UUID, username string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if current, exists := rm.spectators[username]; !exists || current != roomID {
+		return ErrNotSpectating
+	}
+	delete(rm.spectators, username)
+
+	room, exists := rm.rooms[roomID]
+	if !exists {
+		return nil
+	}
+
+	room.spectatorMu.Lock()
+	delete(room.Spectators, username)
+	count := len(room.Spectators)
+	room.spectatorMu.Unlock()
+
+	room.emitEvent(&GameRoomEvent{
+		Type:      RoomEventSpectatorLeft,
+		RoomID:    roomID,
+		Username:  username,
+		Data:      map[string]interface{}{"spectatorCount": count},
+		Timestamp: time.Now(),
+	})
+
+	return nil
+}
+
+// GetSpectatingRoom returns the room a user is currently watching
+func (rm *RoomManager) GetSpectatingRoom(username string) (*GameRoom, bool) {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	roomID, exists := rm.spectators[username]
+	if !exists {
+		return nil, false
+	}
+
+	room, exists := rm.rooms[roomID]
+	return room, exists
+}
+
+// SpectatorDisconnected removes a spectator whose connection dropped
+func (rm *RoomManager) SpectatorDisconnected(username string) {
+	if room, exists := rm.GetSpectatingRoom(username); exists {
+		rm.StopSpectating(room.ID, username)
+	}
+}
+
+// SpectatorCount returns the number of users watching the room
+func (room *GameRoom) SpectatorCount() int {
+	room.spectatorMu.RLock()
+	defer room.spectatorMu.RUnlock()
+
+	return len(room.Spectators)
+}
+
+// isSpectator reports whether a user is watching the room
+func (room *GameRoom) isSpectator(username string) bool {
+	room.spectatorMu.RLock()
+	defer room.spectatorMu.RUnlock()
+
+	_, exists := room.Spectators[username]
+	return exists
+}
+
+// spectatorNames returns the usernames of all spectators
+func (room *GameRoom) spectatorNames() []string {
+	room.spectatorMu.RLock()
+	defer room.spectatorMu.RUnlock()
+
+	names := make([]string, 0, len(room.Spectators))
+	for username := range room.Spectators {
+		names = append(names, username)
+	}
+	return names
+}
+
+// forwardToSpectators queues a room broadcast for spectators. Spectator
+// delivery is best effort, so broadcasts are dropped when the feed is full.
+func (room *GameRoom) forwardToSpectators(message *WebSocketMessage) {
+	select {
+	case room.spectatorFeed <- message:
+	default:
+	}
+}
+
+// runSpectatorFeed delivers room broadcasts to spectators in order, holding
+// each one back until the room's spectator delay has passed
+func (room *GameRoom) runSpectatorFeed() {
+	for {
+		select {
+		case <-room.ctx.Done():
+			return
+
+		case message := <-room.spectatorFeed:
+			if wait := time.Until(message.Timestamp.Add(room.SpectatorDelay)); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-room.ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+
+			if room.wsManager == nil {
+				continue
+			}
+			for _, username := range room.spectatorNames() {
+				room.wsManager.SendToUser(username, message)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// internal/gameserver/spectator_test.go
+package gameserver_test
+
+import (
+	"testing"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomManager_SpectatorsAreSeparateFromPlayers(t *testing.T) {
+	rm := newTestRoomManager(t)
+	room := startTestGame(t, rm, minigame.GameTypeClickSpeed)
+
+	_, err := rm.SpectateRoom(room.ID, "viewer", "")
+	require.NoError(t, err)
+
+	stats := room.GetRoomStats()
+	assert.Equal(t, 2, stats["playerCount"])
+	assert.Equal(t, 1, stats["spectatorCount"])
+
+	_, err = rm.JoinRoom(room.ID, "viewer", "")
+	assert.ErrorIs(t, err, gameserver.ErrAlreadyInRoom)
+
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+	err = rm.ProcessGameAction(room.ID, "viewer", map[string]interface{}{"type": "click"})
+	assert.ErrorIs(t, err, gameserver.ErrSpectatorCannotAct)
+
+	require.NoError(t, rm.StopSpectating(room.ID, "viewer"))
+	assert.Equal(t, 0, room.SpectatorCount())
+	assert.ErrorIs(t, rm.StopSpectating(room.ID, "viewer"), gameserver.ErrNotSpectating)
+}
+
+func TestRoomManager_SpectatorSettings(t *testing.T) {
+	rm := newTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, map[string]interface{}{
+		"allowSpectators": false,
+	})
+	require.NoError(t, err)
+
+	_, err = rm.SpectateRoom(room.ID, "viewer", "")
+	assert.ErrorIs(t, err, gameserver.ErrSpectatorsNotAllowed)
+
+	delayed, err := rm.CreateRoom("streamer", minigame.GameTypeClickSpeed, map[string]interface{}{
+		"spectatorDelay": float64(10),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), delayed.GetRoomStats()["spectatorDelayMs"])
+}
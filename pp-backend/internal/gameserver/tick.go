@@ -0,0 +1,213 @@
+// internal/gameserver/tick.go
+package gameserver
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+)
+
+// ErrInputQueueFull is returned when a ticking room has more queued inputs than it processes per tick
+var ErrInputQueueFull = errors.New("input queue is full")
+
+// ErrTooManyInputs is returned when a player sends more inputs than one tick applies
+var ErrTooManyInputs = errors.New("too many inputs for one tick")
+
+const (
+	// maxTickRate caps the ticks per second a room can run at
+	maxTickRate = 60
+
+	// maxQueuedInputs is the number of inputs a room buffers between two ticks
+	maxQueuedInputs = 512
+
+	// maxInputsPerTick is the number of inputs one player may queue between two ticks
+	maxInputsPerTick = 8
+)
+
+// RoomEventGameTick carries the changes applied by one server tick
+const RoomEventGameTick = "game_tick"
+
+// queuedInput is a player action waiting for the next tick
+type queuedInput struct {
+	username   string
+	action     map[string]interface{}
+	receivedAt time.Time
+}
+
+// parseTickRate reads the room's tick rate from its settings, falling back to
+// the game type's default. Zero means the room only advances on actions.
+func parseTickRate(settings map[string]interface{}, config *minigame.GameConfig) int {
+	rate := 0
+	if config != nil {
+		rate = config.TickRate
+	}
+	if settings != nil {
+		if v, ok := settings["tickRate"].(float64); ok && v >= 0 {
+			rate = int(v)
+		}
+	}
+	if rate > maxTickRate {
+		rate = maxTickRate
+	}
+	return rate
+}
+
+// queueInput buffers a player action for the next tick (assumes room lock is held)
+func (room *GameRoom) queueInput(username string, action map[string]interface{}, now time.Time) error {
+	if len(room.pendingInputs) >= maxQueuedInputs {
+		return ErrInputQueueFull
+	}
+
+	// A flooding player only loses their own extra inputs
+	queued := 0
+	for _, input := range room.pendingInputs {
+		if input.username == username {
+			queued++
+		}
+	}
+	if queued >= maxInputsPerTick {
+		return ErrTooManyInputs
+	}
+	room.pendingInputs = append(room.pendingInputs, queuedInput{
+		username:   username,
+		action:     action,
+		receivedAt: now,
+	})
+	room.LastActivity = now
+	return nil
+}
+
+// runTickLoop advances a ticking room at its fixed rate until the session ends
+// or the room context is cancelled
+func (rm *RoomManager) runTickLoop(room *GameRoom, sessionID uuid.UUID, rate int) {
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-room.ctx.Done():
+			return
+
+		case now := <-ticker.C:
+			if !rm.tick(room, sessionID, now) {
+				return
+			}
+		}
+	}
+}
+
+// tick applies the inputs queued since the last tick in arrival order and
+// broadcasts what changed. It reports whether the session is still running.
+func (rm *RoomManager) tick(room *GameRoom, sessionID uuid.UUID, now time.Time) bool {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.State != RoomStateInProgress || room.GameSession == nil || room.GameSession.SessionID != sessionID {
+		return false
+	}
+
+	// A paused game keeps its queued inputs until it resumes
+	if room.isPaused() {
+		return true
+	}
+
+	inputs := room.pendingInputs
+	room.pendingInputs = nil
+	room.tickCount++
+
+	applied := make([]map[string]interface{}, 0, len(inputs))
+	for _, input := range inputs {
+		player, exists := room.Players[input.username]
+		if !exists {
+			continue
+		}
+
+		state, err := room.applyAction(player, input.action, now)
+		room.recordAction(input.username, input.action, err, input.receivedAt)
+		if err != nil {
+			rm.rejectInput(input.username, err)
+			continue
+		}
+
+		if state.CurrentScore > room.GameSession.CurrentScore {
+			room.GameSession.CurrentScore = state.CurrentScore
+		}
+		applied = append(applied, map[string]interface{}{
+			"username": input.username,
+			"type":     input.action["type"],
+		})
+	}
+	if len(applied) > 0 {
+		room.LastActivity = now
+		room.GameSession.LastActivity = now
+	}
+
+	if delta := room.tickDelta(room.sessionSnapshot(now)); delta != nil {
+		delta["tick"] = room.tickCount
+		delta["inputs"] = applied
+		room.emitEvent(&GameRoomEvent{
+			Type:      RoomEventGameTick,
+			RoomID:    room.ID,
+			Data:      delta,
+			Timestamp: now,
+		})
+	}
+
+	if !now.Before(room.sessionDeadline()) {
+		rm.finishGame(room, GameEndReasonTimeout)
+		return false
+	}
+	if room.allPlayersFinished() {
+		rm.finishGame(room, GameEndReasonCompleted)
+		return false
+	}
+
+	return true
+}
+
+// tickDelta compares a snapshot with the last broadcast one and returns only the
+// players that changed, or nil when nothing did (assumes room lock is held)
+func (room *GameRoom) tickDelta(snapshot map[string]interface{}) map[string]interface{} {
+	players, _ := snapshot["players"].(map[string]interface{})
+
+	changed := make(map[string]interface{})
+	for username, view := range players {
+		if previous, exists := room.tickPlayers[username]; !exists || !reflect.DeepEqual(previous, view) {
+			changed[username] = view
+		}
+	}
+	var removed []string
+	for username := range room.tickPlayers {
+		if _, exists := players[username]; !exists {
+			removed = append(removed, username)
+		}
+	}
+
+	room.tickPlayers = players
+	if len(changed) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	delta := map[string]interface{}{
+		"players":     changed,
+		"leader":      snapshot["leader"],
+		"remainingMs": snapshot["remainingMs"],
+	}
+	if len(removed) > 0 {
+		delta["removed"] = removed
+	}
+	return delta
+}
+
+// rejectInput tells a player that a queued action was refused by the game rules
+func (rm *RoomManager) rejectInput(username string, err error) {
+	if rm.wsManager == nil {
+		return
+	}
+	if conn, exists := rm.wsManager.GetConnection(username); exists {
+		rm.wsManager.sendError(conn, MessageTypeGameAction, err)
+	}
+}
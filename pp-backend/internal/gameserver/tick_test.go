@@ -0,0 +1,81 @@
+// internal/gameserver/tick_test.go
+package gameserver_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomManager_TickLoopBatchesInputs(t *testing.T) {
+	rm := newTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, map[string]interface{}{"tickRate": float64(20)})
+	require.NoError(t, err)
+	assert.Equal(t, 20, room.GetRoomStats()["tickRate"])
+	_, err = rm.JoinRoom(room.ID, "guest", "")
+	require.NoError(t, err)
+	require.NoError(t, rm.SetPlayerReady(room.ID, "host", true))
+	require.NoError(t, rm.SetPlayerReady(room.ID, "guest", true))
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+
+	click := map[string]interface{}{"type": "click"}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, rm.ProcessGameAction(room.ID, "host", click))
+	}
+
+	score := func(username string) int {
+		players := room.GetRoomStats()["players"].(map[string]interface{})
+		return players[username].(map[string]interface{})["score"].(int)
+	}
+	require.Eventually(t, func() bool { return score("host") == 3 }, time.Second, 10*time.Millisecond)
+
+	var tick *gameserver.WebSocketMessage
+	require.Eventually(t, func() bool {
+		events, _ := room.EventsSince(0)
+		for _, event := range events {
+			if event.Type == gameserver.RoomEventGameTick {
+				tick = event
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+
+	// Only players whose state changed are part of the delta
+	players := tick.Data["players"].(map[string]interface{})
+	assert.Contains(t, players, "host")
+	assert.NotContains(t, players, "guest")
+	assert.NotEmpty(t, tick.Data["inputs"])
+
+	require.NoError(t, rm.LeaveRoom(room.ID, "guest"))
+	require.NoError(t, rm.LeaveRoom(room.ID, "host"))
+	_, exists := rm.GetRoom(room.ID)
+	assert.False(t, exists)
+}
+
+func TestRoomManager_TickLoopLimitsInputsPerPlayer(t *testing.T) {
+	rm := newTestRoomManager(t)
+
+	room, err := rm.CreateRoom("host", minigame.GameTypeClickSpeed, map[string]interface{}{"tickRate": float64(1)})
+	require.NoError(t, err)
+	_, err = rm.JoinRoom(room.ID, "guest", "")
+	require.NoError(t, err)
+	require.NoError(t, rm.SetPlayerReady(room.ID, "host", true))
+	require.NoError(t, rm.SetPlayerReady(room.ID, "guest", true))
+	require.NoError(t, rm.StartGame(room.ID, "host"))
+
+	click := map[string]interface{}{"type": "click"}
+	var flooded error
+	for i := 0; i < 20 && flooded == nil; i++ {
+		flooded = rm.ProcessGameAction(room.ID, "host", click)
+	}
+	assert.ErrorIs(t, flooded, gameserver.ErrTooManyInputs)
+
+	// The flood does not cost other players their inputs
+	require.NoError(t, rm.ProcessGameAction(room.ID, "guest", click))
+}
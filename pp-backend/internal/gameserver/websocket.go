@@ -4,6 +4,7 @@ package gameserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/google/uuid"
+	"golang.org/x/time/rate"
 )
 
 // WebSocketConnection represents a single WebSocket connection
@@ -20,10 +22,13 @@ type WebSocketConnection struct {
 	Conn          *websocket.Conn        `json:"-"`
 	Send          chan []byte            `json:"-"`
 	RoomID        *uuid.UUID             `json:"roomId,omitempty"`
+	Platform      string                 `json:"platform,omitempty"` // Client platform from the platform query parameter
 	LastActivity  time.Time              `json:"lastActivity"`
 	IsAlive       bool                   `json:"isAlive"`
 	Context       context.Context        `json:"-"`
 	Cancel        context.CancelFunc     `json:"-"`
+	remote        bool                   `json:"-"` // Socket held by another instance; sends go over the backplane
+	chatLimiter   *rate.Limiter          `json:"-"` // Created on the first chat message
 	mu            sync.RWMutex           `json:"-"`
 }
 
@@ -35,27 +40,36 @@ type WebSocketMessage struct {
 	From      string                 `json:"from,omitempty"`
 	To        string                 `json:"to,omitempty"`
 	RoomID    *uuid.UUID             `json:"roomId,omitempty"`
+	Seq       int64                  `json:"seq,omitempty"` // Room event sequence number
 }
 
 // WebSocketManager manages all WebSocket connections
 type WebSocketManager struct {
-	connections    map[uuid.UUID]*WebSocketConnection
-	userConnections map[string]*WebSocketConnection // username -> connection
-	roomConnections map[uuid.UUID][]*WebSocketConnection // roomID -> connections
-	register       chan *WebSocketConnection
-	unregister     chan *WebSocketConnection
-	broadcast      chan *WebSocketMessage
-	roomBroadcast  chan *WebSocketMessage
-	upgrader       websocket.Upgrader
+	pool           *ConnectionPool
+	handlers       map[string]MessageHandler
+	onDisconnect   []ConnectionHandler
+	backplane      Backplane
+	instanceID     string
+	remoteRooms    map[string]uuid.UUID // username -> room hosted by another instance
+	remoteMembers  map[uuid.UUID]map[string]bool // hosted room -> players connected to other instances
 	mu             sync.RWMutex
 	ctx            context.Context
 	cancel         context.CancelFunc
 }
 
+// MessageHandler handles an incoming client message of a registered type.
+// A returned error is reported back to the sender as an error message.
+type MessageHandler func(conn *WebSocketConnection, message *WebSocketMessage) error
+
+// ConnectionHandler is notified about connection lifecycle changes
+type ConnectionHandler func(conn *WebSocketConnection)
+
 // Message types for WebSocket communication
 const (
 	MessageTypeJoinRoom       = "join_room"
 	MessageTypeLeaveRoom      = "leave_room"
+	MessageTypeRoomJoined     = "room_joined"
+	MessageTypeRoomLeft       = "room_left"
 	MessageTypeGameAction     = "game_action"
 	MessageTypeGameState      = "game_state"
 	MessageTypeGameStart      = "game_start"
@@ -70,108 +84,47 @@ const (
 	MessageTypeMatchCancelled = "match_cancelled"
 )
 
-// NewWebSocketManager creates a new WebSocket manager
+// NewWebSocketManager creates a new WebSocket manager with the default pool configuration
 func NewWebSocketManager(ctx context.Context) *WebSocketManager {
+	return NewWebSocketManagerWithConfig(ctx, nil)
+}
+
+// NewWebSocketManagerWithConfig creates a new WebSocket manager whose connections
+// are held in a pool configured by config (nil for the defaults)
+func NewWebSocketManagerWithConfig(ctx context.Context, config *PoolConfig) *WebSocketManager {
 	managerCtx, cancel := context.WithCancel(ctx)
 
 	manager := &WebSocketManager{
-		connections:     make(map[uuid.UUID]*WebSocketConnection),
-		userConnections: make(map[string]*WebSocketConnection),
-		roomConnections: make(map[uuid.UUID][]*WebSocketConnection),
-		register:        make(chan *WebSocketConnection, 256),
-		unregister:      make(chan *WebSocketConnection, 256),
-		broadcast:       make(chan *WebSocketMessage, 1024),
-		roomBroadcast:   make(chan *WebSocketMessage, 1024),
-		upgrader: websocket.Upgrader{
-			ReadBufferSize:  1024,
-			WriteBufferSize: 1024,
-			CheckOrigin: func(r *http.Request) bool {
-				// TODO: Implement proper origin checking for production
-				return true
-			},
-		},
-		ctx:    managerCtx,
-		cancel: cancel,
+		pool:          NewConnectionPool(config),
+		handlers:      make(map[string]MessageHandler),
+		remoteRooms:   make(map[string]uuid.UUID),
+		remoteMembers: make(map[uuid.UUID]map[string]bool),
+		ctx:           managerCtx,
+		cancel:        cancel,
 	}
+	manager.pool.onRegister = manager.registerConnection
+	manager.pool.onUnregister = manager.unregisterConnection
+	manager.pool.onMessage = manager.receive
 
-	// Start the manager goroutine
-	go manager.run()
+	// Start the pool goroutine
+	manager.pool.Start(managerCtx)
 
 	return manager
 }
 
-// run manages the WebSocket connections lifecycle
-func (m *WebSocketManager) run() {
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-m.ctx.Done():
-			return
-
-		case conn := <-m.register:
-			m.registerConnection(conn)
-
-		case conn := <-m.unregister:
-			m.unregisterConnection(conn)
-
-		case message := <-m.broadcast:
-			m.broadcastToAll(message)
-
-		case message := <-m.roomBroadcast:
-			m.broadcastToRoom(message)
-
-		case <-ticker.C:
-			// Cleanup dead connections
-			m.cleanupDeadConnections()
-		}
-	}
-}
-
 // HandleWebSocket upgrades HTTP connection to WebSocket
 func (m *WebSocketManager) HandleWebSocket(w http.ResponseWriter, r *http.Request, username string) error {
-	conn, err := m.upgrader.Upgrade(w, r, nil)
-	if err != nil {
+	if _, err := m.pool.Upgrade(w, r, m.ctx, username); err != nil {
+		if errors.Is(err, ErrConnectionLimit) {
+			return err
+		}
 		return fmt.Errorf("failed to upgrade connection: %w", err)
 	}
-
-	ctx, cancel := context.WithCancel(m.ctx)
-	wsConn := &WebSocketConnection{
-		ID:           uuid.New(),
-		Username:     username,
-		Conn:         conn,
-		Send:         make(chan []byte, 256),
-		LastActivity: time.Now(),
-		IsAlive:      true,
-		Context:      ctx,
-		Cancel:       cancel,
-	}
-
-	// Register the connection
-	m.register <- wsConn
-
-	// Start goroutines for reading and writing
-	go m.writePump(wsConn)
-	go m.readPump(wsConn)
-
 	return nil
 }
 
-// registerConnection adds a new connection to the manager
+// registerConnection greets a connection the pool just registered
 func (m *WebSocketManager) registerConnection(conn *WebSocketConnection) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	// Check if user already has a connection and close the old one
-	if existingConn, exists := m.userConnections[conn.Username]; exists {
-		m.closeConnection(existingConn)
-	}
-
-	m.connections[conn.ID] = conn
-	m.userConnections[conn.Username] = conn
-
-	// Send welcome message
 	welcomeMsg := &WebSocketMessage{
 		Type: "connected",
 		Data: map[string]interface{}{
@@ -183,121 +136,51 @@ func (m *WebSocketManager) registerConnection(conn *WebSocketConnection) {
 	m.sendToConnection(conn, welcomeMsg)
 }
 
-// unregisterConnection removes a connection from the manager
+// unregisterConnection notifies the disconnect handlers about a connection the
+// pool dropped. Connections replaced by a newer one of the same user are not reported.
 func (m *WebSocketManager) unregisterConnection(conn *WebSocketConnection) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	if _, exists := m.connections[conn.ID]; exists {
-		// Remove from room if connected
-		if conn.RoomID != nil {
-			m.removeFromRoom(conn, *conn.RoomID)
-		}
+	m.mu.RLock()
+	handlers := m.onDisconnect
+	m.mu.RUnlock()
 
-		delete(m.connections, conn.ID)
-		delete(m.userConnections, conn.Username)
-		m.closeConnection(conn)
+	for _, handler := range handlers {
+		handler(conn)
 	}
 }
 
-// readPump handles reading messages from WebSocket
-func (m *WebSocketManager) readPump(conn *WebSocketConnection) {
-	defer func() {
-		m.unregister <- conn
-	}()
-
-	// Set read deadline and pong handler
-	conn.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-	conn.Conn.SetPongHandler(func(string) error {
-		conn.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-		conn.mu.Lock()
-		conn.LastActivity = time.Now()
-		conn.mu.Unlock()
-		return nil
-	})
+// OnDisconnect registers a handler called after a user's connection is unregistered
+func (m *WebSocketManager) OnDisconnect(handler ConnectionHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	for {
-		select {
-		case <-conn.Context.Done():
-			return
-		default:
-			_, messageBytes, err := conn.Conn.ReadMessage()
-			if err != nil {
-				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-					// Log unexpected close error
-				}
-				return
-			}
-
-			var message WebSocketMessage
-			if err := json.Unmarshal(messageBytes, &message); err != nil {
-				// Send error message back to client
-				errorMsg := &WebSocketMessage{
-					Type: MessageTypeError,
-					Data: map[string]interface{}{
-						"error": "Invalid message format",
-					},
-					Timestamp: time.Now(),
-				}
-				m.sendToConnection(conn, errorMsg)
-				continue
-			}
-
-			// Update last activity
-			conn.mu.Lock()
-			conn.LastActivity = time.Now()
-			conn.mu.Unlock()
-
-			// Process the message
-			m.processMessage(conn, &message)
-		}
-	}
+	m.onDisconnect = append(m.onDisconnect, handler)
 }
 
-// writePump handles writing messages to WebSocket
-func (m *WebSocketManager) writePump(conn *WebSocketConnection) {
-	ticker := time.NewTicker(54 * time.Second)
-	defer func() {
-		ticker.Stop()
-		conn.Conn.Close()
-	}()
-
-	for {
-		select {
-		case <-conn.Context.Done():
-			return
-
-		case message, ok := <-conn.Send:
-			conn.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if !ok {
-				conn.Conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
-
-			w, err := conn.Conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return
-			}
-			w.Write(message)
-
-			// Add queued messages to current message
-			n := len(conn.Send)
-			for i := 0; i < n; i++ {
-				w.Write([]byte{'\n'})
-				w.Write(<-conn.Send)
-			}
-
-			if err := w.Close(); err != nil {
-				return
-			}
-
-		case <-ticker.C:
-			conn.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := conn.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return
-			}
+// receive decodes a client message read by the pool and processes it
+func (m *WebSocketManager) receive(conn *WebSocketConnection, data []byte) {
+	var message WebSocketMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		// Send error message back to client
+		errorMsg := &WebSocketMessage{
+			Type: MessageTypeError,
+			Data: map[string]interface{}{
+				"error": "Invalid message format",
+			},
+			Timestamp: time.Now(),
 		}
+		m.sendToConnection(conn, errorMsg)
+		return
 	}
+
+	m.processMessage(conn, &message)
+}
+
+// HandleMessage registers a handler for a client message type
+func (m *WebSocketManager) HandleMessage(messageType string, handler MessageHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.handlers[messageType] = handler
 }
 
 // processMessage processes incoming WebSocket messages
@@ -305,6 +188,17 @@ func (m *WebSocketManager) processMessage(conn *WebSocketConnection, message *We
 	message.From = conn.Username
 	message.Timestamp = time.Now()
 
+	m.mu.RLock()
+	handler, exists := m.handlers[message.Type]
+	m.mu.RUnlock()
+
+	if exists {
+		if err := handler(conn, message); err != nil {
+			m.sendError(conn, message.Type, err)
+		}
+		return
+	}
+
 	switch message.Type {
 	case MessageTypePing:
 		// Respond with pong
@@ -315,22 +209,6 @@ func (m *WebSocketManager) processMessage(conn *WebSocketConnection, message *We
 		}
 		m.sendToConnection(conn, pongMsg)
 
-	case MessageTypeJoinRoom:
-		// TODO: Implement room joining logic
-		// This should be handled by the RoomManager
-
-	case MessageTypeLeaveRoom:
-		// TODO: Implement room leaving logic
-		// This should be handled by the RoomManager
-
-	case MessageTypeGameAction:
-		// TODO: Forward game actions to the appropriate game room
-		// This should be handled by the GameServer
-
-	case MessageTypeMatchmaking:
-		// TODO: Forward matchmaking requests to MatchmakingService
-		// This should be handled by the MatchmakingService
-
 	default:
 		// Unknown message type
 		errorMsg := &WebSocketMessage{
@@ -344,166 +222,95 @@ func (m *WebSocketManager) processMessage(conn *WebSocketConnection, message *We
 	}
 }
 
+// sendError reports a failed client request back to the sender
+func (m *WebSocketManager) sendError(conn *WebSocketConnection, requestType string, err error) {
+	_, code := RoomErrorCode(err)
+	m.sendToConnection(conn, &WebSocketMessage{
+		Type: MessageTypeError,
+		Data: map[string]interface{}{
+			"error":       err.Error(),
+			"code":        code,
+			"requestType": requestType,
+		},
+		Timestamp: time.Now(),
+	})
+}
+
 // sendToConnection sends a message to a specific connection
 func (m *WebSocketManager) sendToConnection(conn *WebSocketConnection, message *WebSocketMessage) {
-	messageBytes, err := json.Marshal(message)
-	if err != nil {
+	if conn.remote {
+		m.publishToUser(conn.Username, message)
 		return
 	}
 
-	select {
-	case conn.Send <- messageBytes:
-	case <-conn.Context.Done():
-	default:
-		// Channel is full, close connection
-		m.unregister <- conn
-	}
-}
-
-// broadcastToAll broadcasts a message to all connected clients
-func (m *WebSocketManager) broadcastToAll(message *WebSocketMessage) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	messageBytes, err := json.Marshal(message)
+	data, err := encodeMessage(message)
 	if err != nil {
 		return
 	}
-
-	for _, conn := range m.connections {
-		select {
-		case conn.Send <- messageBytes:
-		case <-conn.Context.Done():
-		default:
-			m.unregister <- conn
-		}
-	}
+	m.pool.Send(conn, data)
 }
 
-// broadcastToRoom broadcasts a message to all clients in a specific room
+// broadcastToRoom queues a message for the local connections in its room
 func (m *WebSocketManager) broadcastToRoom(message *WebSocketMessage) {
 	if message.RoomID == nil {
 		return
 	}
 
-	m.mu.RLock()
-	roomConnections, exists := m.roomConnections[*message.RoomID]
-	m.mu.RUnlock()
-
-	if !exists {
-		return
-	}
-
-	messageBytes, err := json.Marshal(message)
+	data, err := encodeMessage(message)
 	if err != nil {
 		return
 	}
-
-	for _, conn := range roomConnections {
-		select {
-		case conn.Send <- messageBytes:
-		case <-conn.Context.Done():
-		default:
-			m.unregister <- conn
-		}
-	}
+	m.pool.BroadcastToRoom(m.ctx, RoomMessage{RoomID: *message.RoomID, Data: data})
 }
 
 // AddToRoom adds a connection to a room
 func (m *WebSocketManager) AddToRoom(conn *WebSocketConnection, roomID uuid.UUID) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	// Remove from previous room if exists
-	if conn.RoomID != nil {
-		m.removeFromRoom(conn, *conn.RoomID)
-	}
-
-	conn.RoomID = &roomID
-	m.roomConnections[roomID] = append(m.roomConnections[roomID], conn)
+	m.pool.AddToRoom(conn, roomID)
 }
 
 // RemoveFromRoom removes a connection from a room
 func (m *WebSocketManager) RemoveFromRoom(conn *WebSocketConnection, roomID uuid.UUID) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	m.removeFromRoom(conn, roomID)
-}
-
-// removeFromRoom internal method to remove from room (assumes lock is held)
-func (m *WebSocketManager) removeFromRoom(conn *WebSocketConnection, roomID uuid.UUID) {
-	connections := m.roomConnections[roomID]
-	for i, c := range connections {
-		if c.ID == conn.ID {
-			m.roomConnections[roomID] = append(connections[:i], connections[i+1:]...)
-			break
-		}
-	}
-
-	// Clean up empty room
-	if len(m.roomConnections[roomID]) == 0 {
-		delete(m.roomConnections, roomID)
-	}
-
-	conn.RoomID = nil
+	m.pool.RemoveFromRoom(conn, roomID)
 }
 
 // GetConnection returns a connection by username
 func (m *WebSocketManager) GetConnection(username string) (*WebSocketConnection, bool) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	conn, exists := m.userConnections[username]
-	return conn, exists
+	return m.pool.GetConnection(username)
 }
 
 // GetRoomConnections returns all connections in a room
 func (m *WebSocketManager) GetRoomConnections(roomID uuid.UUID) []*WebSocketConnection {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	return m.pool.RoomConnections(roomID)
+}
 
-	connections := m.roomConnections[roomID]
-	result := make([]*WebSocketConnection, len(connections))
-	copy(result, connections)
-	return result
+// touch records activity on the connection
+func (conn *WebSocketConnection) touch() {
+	conn.mu.Lock()
+	conn.LastActivity = time.Now()
+	conn.mu.Unlock()
 }
 
-// closeConnection closes a WebSocket connection
-func (m *WebSocketManager) closeConnection(conn *WebSocketConnection) {
+// close stops the connection's pumps; the write pump then closes the socket.
+// The send channel stays open so late senders never panic.
+func (conn *WebSocketConnection) close() {
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 
 	if conn.IsAlive {
 		conn.IsAlive = false
 		conn.Cancel()
-		close(conn.Send)
-		conn.Conn.Close()
 	}
 }
 
-// cleanupDeadConnections removes inactive connections
-func (m *WebSocketManager) cleanupDeadConnections() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	now := time.Now()
-	for id, conn := range m.connections {
-		conn.mu.RLock()
-		inactive := now.Sub(conn.LastActivity) > 5*time.Minute
-		conn.mu.RUnlock()
-
-		if inactive {
-			m.unregister <- conn
-			delete(m.connections, id)
-		}
-	}
-}
-
-// SendToUser sends a message to a specific user
+// SendToUser sends a message to a specific user. Users connected to another
+// instance are reached through the backplane.
 func (m *WebSocketManager) SendToUser(username string, message *WebSocketMessage) error {
 	conn, exists := m.GetConnection(username)
 	if !exists {
+		if m.backplane != nil {
+			m.publishToUser(username, message)
+			return nil
+		}
 		return fmt.Errorf("user %s not connected", username)
 	}
 
@@ -511,45 +318,56 @@ func (m *WebSocketManager) SendToUser(username string, message *WebSocketMessage
 	return nil
 }
 
-// SendToRoom sends a message to all users in a room
+// SendToRoom sends a message to all users in a room, including those
+// connected to other instances. Rooms without remote players stay off the backplane.
 func (m *WebSocketManager) SendToRoom(roomID uuid.UUID, message *WebSocketMessage) {
 	message.RoomID = &roomID
-	select {
-	case m.roomBroadcast <- message:
-	case <-m.ctx.Done():
+	m.broadcastToRoom(message)
+	if m.hasRemoteMembers(roomID) {
+		m.publish(&BackplaneMessage{Kind: BackplaneRoom, RoomID: &roomID, Message: message})
 	}
+	m.syncRemoteMembers(roomID, message)
 }
 
 // Broadcast sends a message to all connected users
 func (m *WebSocketManager) Broadcast(message *WebSocketMessage) {
-	select {
-	case m.broadcast <- message:
-	case <-m.ctx.Done():
+	data, err := encodeMessage(message)
+	if err != nil {
+		return
 	}
+	m.pool.Broadcast(m.ctx, data)
 }
 
 // GetActiveConnectionsCount returns the number of active connections
 func (m *WebSocketManager) GetActiveConnectionsCount() int {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	return len(m.connections)
+	return int(m.pool.GetConnectionCount())
+}
+
+// MessageStats counts the messages a WebSocket manager handled since it started.
+// Dropped also counts messages lost to failed socket writes.
+type MessageStats struct {
+	Received int64 `json:"received"`
+	Sent     int64 `json:"sent"`
+	Dropped  int64 `json:"dropped"`
+}
+
+// GetMessageStats returns the message counters
+func (m *WebSocketManager) GetMessageStats() MessageStats {
+	metrics := m.pool.GetMetrics()
+	return MessageStats{
+		Received: metrics["received_messages"],
+		Sent:     metrics["sent_messages"],
+		Dropped:  metrics["failed_messages"],
+	}
 }
 
 // GetRoomConnectionsCount returns the number of connections in a room
 func (m *WebSocketManager) GetRoomConnectionsCount(roomID uuid.UUID) int {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	return len(m.roomConnections[roomID])
+	return len(m.pool.RoomConnections(roomID))
 }
 
 // Shutdown gracefully shuts down the WebSocket manager
 func (m *WebSocketManager) Shutdown() {
 	m.cancel()
-
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	for _, conn := range m.connections {
-		m.closeConnection(conn)
-	}
+	m.pool.shutdown()
 }
\ No newline at end of file
@@ -0,0 +1,247 @@
+// internal/gameserver/websocket_bench_test.go
+package gameserver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
+)
+
+// roomBroadcaster is the part of a socket manager a room broadcast goes through
+type roomBroadcaster interface {
+	serve(w http.ResponseWriter, r *http.Request, username string) error
+	seat(username string, roomID uuid.UUID) bool
+	sendToRoom(roomID uuid.UUID, message *gameserver.WebSocketMessage)
+}
+
+// BenchmarkWebSocketManager_SendToRoom measures how long a room broadcast takes
+// until every player's socket has read it, and what it allocates on the way.
+// The legacy variant is the manager's broadcast path before the connection
+// pool, kept as the baseline to compare against.
+func BenchmarkWebSocketManager_SendToRoom(b *testing.B) {
+	managers := []struct {
+		name string
+		new  func(ctx context.Context) roomBroadcaster
+	}{
+		{"pool", func(ctx context.Context) roomBroadcaster {
+			return &poolBroadcaster{gameserver.NewWebSocketManager(ctx)}
+		}},
+		{"legacy", func(ctx context.Context) roomBroadcaster { return newLegacyBroadcaster(ctx) }},
+	}
+
+	for _, manager := range managers {
+		for _, players := range []int{2, 8, 64} {
+			b.Run(fmt.Sprintf("%s/players=%d", manager.name, players), func(b *testing.B) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				benchmarkSendToRoom(b, manager.new(ctx), players)
+			})
+		}
+	}
+}
+
+func benchmarkSendToRoom(b *testing.B, manager roomBroadcaster, players int) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := manager.serve(w, r, r.URL.Query().Get("username")); err != nil {
+			b.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	roomID := uuid.New()
+	received := make(chan int, players)
+	for i := 0; i < players; i++ {
+		username := fmt.Sprintf("player-%d", i)
+		url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/?username=" + username
+		client, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer client.Close()
+
+		// Wait for the welcome message, then seat the player
+		if _, _, err := client.ReadMessage(); err != nil {
+			b.Fatal(err)
+		}
+		if !manager.seat(username, roomID) {
+			b.Fatalf("%s is not registered", username)
+		}
+
+		go func() {
+			for {
+				_, frame, err := client.ReadMessage()
+				if err != nil {
+					return
+				}
+				received <- bytes.Count(frame, []byte{'\n'}) + 1
+			}
+		}()
+	}
+
+	data := map[string]interface{}{
+		"tick":     0,
+		"scores":   map[string]int{"player-0": 120, "player-1": 80},
+		"timeLeft": 42.5,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		manager.sendToRoom(roomID, &gameserver.WebSocketMessage{
+			Type:      gameserver.MessageTypeGameState,
+			Data:      data,
+			Timestamp: time.Now(),
+		})
+		for delivered := 0; delivered < players; {
+			select {
+			case n := <-received:
+				delivered += n
+			case <-time.After(5 * time.Second):
+				b.Fatalf("broadcast %d reached %d of %d players", i, delivered, players)
+			}
+		}
+	}
+}
+
+// poolBroadcaster benchmarks the pool-backed WebSocketManager
+type poolBroadcaster struct {
+	wsManager *gameserver.WebSocketManager
+}
+
+func (p *poolBroadcaster) serve(w http.ResponseWriter, r *http.Request, username string) error {
+	return p.wsManager.HandleWebSocket(w, r, username)
+}
+
+func (p *poolBroadcaster) seat(username string, roomID uuid.UUID) bool {
+	conn, ok := p.wsManager.GetConnection(username)
+	if ok {
+		p.wsManager.AddToRoom(conn, roomID)
+	}
+	return ok
+}
+
+func (p *poolBroadcaster) sendToRoom(roomID uuid.UUID, message *gameserver.WebSocketMessage) {
+	p.wsManager.SendToRoom(roomID, message)
+}
+
+// legacyBroadcaster is the manager's broadcast path before the connection
+// pool: room members in mutex guarded slices, broadcasts encoded on one run loop
+type legacyBroadcaster struct {
+	ctx             context.Context
+	upgrader        websocket.Upgrader
+	roomBroadcast   chan *gameserver.WebSocketMessage
+	connections     map[string]*legacyConnection
+	roomConnections map[uuid.UUID][]*legacyConnection
+	mu              sync.RWMutex
+}
+
+type legacyConnection struct {
+	conn *websocket.Conn
+	send chan []byte
+}
+
+func newLegacyBroadcaster(ctx context.Context) *legacyBroadcaster {
+	l := &legacyBroadcaster{
+		ctx:             ctx,
+		roomBroadcast:   make(chan *gameserver.WebSocketMessage, 1024),
+		connections:     make(map[string]*legacyConnection),
+		roomConnections: make(map[uuid.UUID][]*legacyConnection),
+	}
+	go l.run()
+	return l
+}
+
+func (l *legacyBroadcaster) run() {
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case message := <-l.roomBroadcast:
+			l.mu.RLock()
+			roomConnections := l.roomConnections[*message.RoomID]
+			l.mu.RUnlock()
+
+			messageBytes, err := json.Marshal(message)
+			if err != nil {
+				continue
+			}
+			for _, conn := range roomConnections {
+				select {
+				case conn.send <- messageBytes:
+				default:
+				}
+			}
+		}
+	}
+}
+
+func (l *legacyBroadcaster) serve(w http.ResponseWriter, r *http.Request, username string) error {
+	wsConn, err := l.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+	conn := &legacyConnection{conn: wsConn, send: make(chan []byte, 256)}
+	l.mu.Lock()
+	l.connections[username] = conn
+	l.mu.Unlock()
+
+	welcome, _ := json.Marshal(&gameserver.WebSocketMessage{Type: "connected", Timestamp: time.Now()})
+	conn.send <- welcome
+	go l.writePump(conn)
+	return nil
+}
+
+// writePump batches queued messages into one frame, like the manager did
+func (l *legacyBroadcaster) writePump(conn *legacyConnection) {
+	defer conn.conn.Close()
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case message := <-conn.send:
+			conn.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			w, err := conn.conn.NextWriter(websocket.TextMessage)
+			if err != nil {
+				return
+			}
+			w.Write(message)
+			n := len(conn.send)
+			for i := 0; i < n; i++ {
+				w.Write([]byte{'\n'})
+				w.Write(<-conn.send)
+			}
+			if err := w.Close(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (l *legacyBroadcaster) seat(username string, roomID uuid.UUID) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	conn, ok := l.connections[username]
+	if ok {
+		l.roomConnections[roomID] = append(l.roomConnections[roomID], conn)
+	}
+	return ok
+}
+
+func (l *legacyBroadcaster) sendToRoom(roomID uuid.UUID, message *gameserver.WebSocketMessage) {
+	message.RoomID = &roomID
+	select {
+	case l.roomBroadcast <- message:
+	case <-l.ctx.Done():
+	}
+}
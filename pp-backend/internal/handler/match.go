@@ -0,0 +1,94 @@
+// backend/internal/handler/match.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MatchHandler handles match history requests.
+type MatchHandler struct {
+	matchService service.MatchService
+}
+
+// NewMatchHandler creates a new MatchHandler.
+func NewMatchHandler(ms service.MatchService) *MatchHandler {
+	return &MatchHandler{matchService: ms}
+}
+
+// GetUserMatches handles retrieving a player's multiplayer match history.
+// @Summary      Get player match history
+// @Description  Retrieves the finished multiplayer matches a player took part in, newest first, with every participant's outcome.
+// @Tags         Game
+// @Produce      json
+// @Param        username path string true "Player username"
+// @Param        limit query int false "Number of matches to return (default 20, max 100)"
+// @Param        offset query int false "Number of matches to skip (default 0)"
+// @Success      200 {object} MatchHistoryResponse
+// @Failure      400 {object} Response
+// @Failure      404 {object} Response
+// @Failure      500 {object} Response
+// @Security     BearerAuth
+// @Router       /users/{username}/matches [get]
+func (h *MatchHandler) GetUserMatches(c *gin.Context) {
+	username := c.Param("username")
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "invalid limit parameter")
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "invalid offset parameter")
+		return
+	}
+
+	matches, err := h.matchService.ListUserMatches(username, limit, offset)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			respondError(c, http.StatusNotFound, "user not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "failed to get matches")
+		return
+	}
+
+	resp := MatchHistoryResponse{
+		Username: username,
+		Matches:  make([]MatchResponse, len(matches)),
+	}
+	for i, match := range matches {
+		resp.Matches[i] = toMatchResponse(match)
+	}
+	respondJSON(c, http.StatusOK, resp)
+}
+
+func toMatchResponse(match *repository.Match) MatchResponse {
+	resp := MatchResponse{
+		ID:              match.ID,
+		RoomID:          match.RoomID,
+		GameType:        match.GameType,
+		EndReason:       match.EndReason,
+		StartedAt:       match.StartedAt,
+		EndedAt:         match.EndedAt,
+		DurationSeconds: float64(match.DurationMs) / 1000,
+		Participants:    make([]MatchParticipantResponse, len(match.Participants)),
+	}
+	for i, participant := range match.Participants {
+		resp.Participants[i] = MatchParticipantResponse{
+			Username:     participant.Username,
+			Placement:    participant.Placement,
+			Score:        participant.Score,
+			PointsEarned: participant.PointsEarned,
+			IsValid:      participant.IsValid,
+		}
+	}
+	return resp
+}
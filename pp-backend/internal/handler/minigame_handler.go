@@ -495,6 +495,10 @@ func (h *MiniGameHandler) getGameTypeName(gameType minigame.GameType) string {
 		return "Word Scramble"
 	case minigame.GameTypePuzzle:
 		return "Puzzle Challenge"
+	case minigame.GameTypePaintBattle:
+		return "Paint Battle"
+	case minigame.GameTypePhysicsJump:
+		return "Physics Jump"
 	default:
 		return string(gameType)
 	}
@@ -512,6 +516,10 @@ func (h *MiniGameHandler) getGameTypeDescription(gameType minigame.GameType) str
 		return "Unscramble words to earn points."
 	case minigame.GameTypePuzzle:
 		return "Solve challenging puzzles to earn maximum points."
+	case minigame.GameTypePaintBattle:
+		return "Paint the board in your color before time runs out."
+	case minigame.GameTypePhysicsJump:
+		return "Jump from platform to platform and climb as high as you can."
 	default:
 		return "Play this exciting mini game!"
 	}
@@ -529,6 +537,10 @@ func (h *MiniGameHandler) getGameInstructions(gameType minigame.GameType) string
 		return "Unscramble the given words. Faster solving = bonus points!"
 	case minigame.GameTypePuzzle:
 		return "Solve the puzzle by arranging pieces correctly. Complexity = higher rewards!"
+	case minigame.GameTypePaintBattle:
+		return "Paint up to 5 cells per stroke. Every painted cell is 1 point!"
+	case minigame.GameTypePhysicsJump:
+		return "Land on the next platform to climb. Falling sends you back to the bottom, but your best height counts!"
 	default:
 		return "Follow the game rules and have fun!"
 	}
@@ -0,0 +1,88 @@
+// backend/internal/handler/rating.go
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RatingHandler handles skill rating requests.
+type RatingHandler struct {
+	ratingService service.RatingService
+}
+
+// NewRatingHandler creates a new RatingHandler.
+func NewRatingHandler(rs service.RatingService) *RatingHandler {
+	return &RatingHandler{ratingService: rs}
+}
+
+// GetUserRatings handles retrieving a player's ratings and rating history.
+// @Summary      Get player ratings
+// @Description  Retrieves a player's skill rating for every game type they played, and their most recent rating changes.
+// @Tags         Game
+// @Produce      json
+// @Param        username path string true "Player username"
+// @Param        limit query int false "Number of rating changes to return (default 20, max 100)"
+// @Success      200 {object} RatingProfileResponse
+// @Failure      400 {object} Response
+// @Failure      404 {object} Response
+// @Failure      500 {object} Response
+// @Security     BearerAuth
+// @Router       /users/{username}/ratings [get]
+func (h *RatingHandler) GetUserRatings(c *gin.Context) {
+	username := c.Param("username")
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "invalid limit parameter")
+		return
+	}
+
+	profile, err := h.ratingService.GetProfile(username, limit)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			respondError(c, http.StatusNotFound, "user not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "failed to get ratings")
+		return
+	}
+
+	respondJSON(c, http.StatusOK, toRatingProfileResponse(profile))
+}
+
+func toRatingProfileResponse(profile *service.RatingProfile) RatingProfileResponse {
+	resp := RatingProfileResponse{
+		Username: profile.Username,
+		Ratings:  make([]PlayerRatingResponse, len(profile.Ratings)),
+		History:  make([]RatingChangeResponse, len(profile.History)),
+	}
+	for i, rating := range profile.Ratings {
+		resp.Ratings[i] = PlayerRatingResponse{
+			GameType:    rating.GameType,
+			Rating:      int(math.Round(rating.Rating)),
+			Deviation:   int(math.Round(rating.Deviation)),
+			GamesPlayed: rating.GamesPlayed,
+			UpdatedAt:   rating.UpdatedAt,
+		}
+	}
+	for i, change := range profile.History {
+		resp.History[i] = RatingChangeResponse{
+			GameType:     change.GameType,
+			RoomID:       change.RoomID,
+			Placement:    change.Placement,
+			Opponents:    change.Opponents,
+			RatingBefore: int(math.Round(change.RatingBefore)),
+			RatingAfter:  int(math.Round(change.RatingAfter)),
+			CreatedAt:    change.CreatedAt,
+		}
+	}
+	return resp
+}
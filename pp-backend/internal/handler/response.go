@@ -56,6 +56,60 @@ type GameResponseScore struct {
 	RecordedAt    time.Time `json:"recorded_at"`
 }
 
+// PlayerRatingResponse is the API response structure for a player's rating in one game type
+type PlayerRatingResponse struct {
+	GameType    string    `json:"game_type"`
+	Rating      int       `json:"rating"`
+	Deviation   int       `json:"deviation"`
+	GamesPlayed int       `json:"games_played"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// RatingChangeResponse is the API response structure for one rating change
+type RatingChangeResponse struct {
+	GameType     string    `json:"game_type"`
+	RoomID       uuid.UUID `json:"room_id"`
+	Placement    int       `json:"placement"`
+	Opponents    int       `json:"opponents"`
+	RatingBefore int       `json:"rating_before"`
+	RatingAfter  int       `json:"rating_after"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RatingProfileResponse is the API response structure for a player's ratings and rating history
+type RatingProfileResponse struct {
+	Username string                 `json:"username"`
+	Ratings  []PlayerRatingResponse `json:"ratings"`
+	History  []RatingChangeResponse `json:"history"`
+}
+
+// MatchParticipantResponse is the API response structure for one player's outcome of a match
+type MatchParticipantResponse struct {
+	Username     string `json:"username"`
+	Placement    int    `json:"placement"`
+	Score        int    `json:"score"`
+	PointsEarned int    `json:"points_earned"`
+	IsValid      bool   `json:"is_valid"`
+}
+
+// MatchResponse is the API response structure for a finished multiplayer match
+type MatchResponse struct {
+	ID              uuid.UUID                  `json:"id"`
+	RoomID          uuid.UUID                  `json:"room_id"`
+	GameType        string                     `json:"game_type"`
+	EndReason       string                     `json:"end_reason"`
+	StartedAt       time.Time                  `json:"started_at"`
+	EndedAt         time.Time                  `json:"ended_at"`
+	DurationSeconds float64                    `json:"duration_seconds"`
+	Participants    []MatchParticipantResponse `json:"participants"`
+}
+
+// MatchHistoryResponse is the API response structure for a player's match history
+type MatchHistoryResponse struct {
+	Username string          `json:"username"`
+	Matches  []MatchResponse `json:"matches"`
+}
+
 // ItemResponse is the API response structure for items
 type ItemResponse struct {
 	ID          uuid.UUID `json:"id"`
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_match_replays_participants;
+DROP INDEX IF EXISTS idx_match_replays_closed_at;
+DROP TABLE IF EXISTS match_replays;
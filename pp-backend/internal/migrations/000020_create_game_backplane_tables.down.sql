@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_game_backplane_messages_created_at;
+DROP TABLE IF EXISTS game_backplane_messages;
+DROP INDEX IF EXISTS idx_game_room_owners_instance;
+DROP TABLE IF EXISTS game_room_owners;
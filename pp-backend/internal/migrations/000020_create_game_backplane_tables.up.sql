@@ -0,0 +1,20 @@
+-- Create tables for the multi-instance game server backplane
+
+CREATE TABLE IF NOT EXISTS game_room_owners (
+    room_id UUID PRIMARY KEY,
+    instance_id VARCHAR(255) NOT NULL,
+    claimed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_room_owners_instance
+    ON game_room_owners (instance_id);
+
+-- Payloads too large for a NOTIFY are parked here and referenced by id
+CREATE TABLE IF NOT EXISTS game_backplane_messages (
+    id BIGSERIAL PRIMARY KEY,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_backplane_messages_created_at
+    ON game_backplane_messages (created_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_player_rating_history_username;
+DROP TABLE IF EXISTS player_rating_history;
+DROP INDEX IF EXISTS idx_player_ratings_game_type_rating;
+DROP TABLE IF EXISTS player_ratings;
@@ -0,0 +1,31 @@
+-- Create tables for per game type skill ratings (Glicko-2) and their history
+
+CREATE TABLE IF NOT EXISTS player_ratings (
+    username VARCHAR(255) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    game_type VARCHAR(64) NOT NULL,
+    rating DOUBLE PRECISION NOT NULL,
+    deviation DOUBLE PRECISION NOT NULL,
+    volatility DOUBLE PRECISION NOT NULL,
+    games_played INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (username, game_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_ratings_game_type_rating
+    ON player_ratings (game_type, rating DESC);
+
+CREATE TABLE IF NOT EXISTS player_rating_history (
+    id BIGSERIAL PRIMARY KEY,
+    username VARCHAR(255) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    game_type VARCHAR(64) NOT NULL,
+    room_id UUID NOT NULL,
+    placement INT NOT NULL,
+    opponents INT NOT NULL,
+    rating_before DOUBLE PRECISION NOT NULL,
+    rating_after DOUBLE PRECISION NOT NULL,
+    deviation_after DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_rating_history_username
+    ON player_rating_history (username, created_at DESC);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_match_participants_username;
+DROP TABLE IF EXISTS match_participants;
+DROP INDEX IF EXISTS idx_matches_ended_at;
+DROP INDEX IF EXISTS idx_matches_game_type;
+DROP TABLE IF EXISTS matches;
@@ -0,0 +1,34 @@
+-- Create tables for the history of finished multiplayer room games
+
+CREATE TABLE IF NOT EXISTS matches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    room_id UUID NOT NULL,
+    session_id UUID NOT NULL,
+    game_type VARCHAR(64) NOT NULL,
+    end_reason VARCHAR(64) NOT NULL,
+    player_count INT NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_matches_game_type
+    ON matches (game_type);
+
+CREATE INDEX IF NOT EXISTS idx_matches_ended_at
+    ON matches (ended_at DESC);
+
+-- Usernames are kept without a foreign key so history survives deleted accounts
+CREATE TABLE IF NOT EXISTS match_participants (
+    match_id UUID NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    username VARCHAR(255) NOT NULL,
+    placement INT NOT NULL,
+    score INT NOT NULL,
+    points_earned INT NOT NULL DEFAULT 0,
+    is_valid BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (match_id, username)
+);
+
+CREATE INDEX IF NOT EXISTS idx_match_participants_username
+    ON match_participants (username);
@@ -0,0 +1,20 @@
+-- Create table for recorded multiplayer room replays
+
+CREATE TABLE IF NOT EXISTS match_replays (
+    room_id UUID PRIMARY KEY,
+    game_type VARCHAR(64) NOT NULL,
+    host_username VARCHAR(255) NOT NULL,
+    is_private BOOLEAN NOT NULL DEFAULT FALSE,
+    participants TEXT[] NOT NULL DEFAULT '{}',
+    replay JSONB NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    closed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_match_replays_closed_at
+    ON match_replays (closed_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_match_replays_participants
+    ON match_replays USING GIN (participants);
@@ -19,6 +19,8 @@ const (
 	GameTypeNumberGuess  GameType = "number_guess"
 	GameTypeWordScramble GameType = "word_scramble"
 	GameTypePuzzle       GameType = "puzzle"
+	GameTypePaintBattle  GameType = "paint_battle"
+	GameTypePhysicsJump  GameType = "physics_jump"
 )
 
 // GameConfig holds configuration for each game type
@@ -30,6 +32,8 @@ type GameConfig struct {
 	MinValidScore  int           `json:"minValidScore"`  // Minimum valid score (anti-cheat)
 	MaxValidScore  int           `json:"maxValidScore"`  // Maximum valid score (anti-cheat)
 	Difficulty     int           `json:"difficulty"`     // 1-5 difficulty level
+	TickRate       int           `json:"tickRate"`       // Server ticks per second for real-time games, 0 = action driven
+	Unverified     bool          `json:"unverified"`     // Scores are reported by the client and earn no points
 }
 
 // GameState represents the current state of a game session
@@ -151,6 +155,30 @@ func (e *MiniGameEngine) initializeDefaultConfigs() {
 		MaxValidScore:  55,
 		Difficulty:     5,
 	}
+
+	e.gameConfigs[GameTypePaintBattle] = &GameConfig{
+		Type:           GameTypePaintBattle,
+		Duration:       90 * time.Second,
+		MaxScore:       400,
+		PointsPerScore: 0.5,
+		MinValidScore:  10,
+		MaxValidScore:  380,
+		Difficulty:     3,
+		TickRate:       20,
+		Unverified:     true, // The server keeps no board, painted cells are taken on trust
+	}
+
+	e.gameConfigs[GameTypePhysicsJump] = &GameConfig{
+		Type:           GameTypePhysicsJump,
+		Duration:       60 * time.Second,
+		MaxScore:       100,
+		PointsPerScore: 2.0,
+		MinValidScore:  1,
+		MaxValidScore:  95,
+		Difficulty:     3,
+		TickRate:       30,
+		Unverified:     true, // The server runs no physics, landings are taken on trust
+	}
 }
 
 // StartGameSession creates a new game session
@@ -160,10 +188,32 @@ func (e *MiniGameEngine) StartGameSession(gameType GameType, playerUsername stri
 		return nil, fmt.Errorf("unsupported game type: %s", gameType)
 	}
 
-	sessionID := uuid.New()
+	gameState := e.newGameState(config, playerUsername)
+
+	e.sessionMutex.Lock()
+	e.activeSessions[gameState.SessionID] = gameState
+	e.sessionMutex.Unlock()
+
+	return gameState, nil
+}
+
+// NewGameState creates an untracked game state with game-specific data initialized.
+// Multiplayer rooms own these states themselves instead of registering them as
+// active single-player sessions.
+func (e *MiniGameEngine) NewGameState(gameType GameType, playerUsername string) (*GameState, error) {
+	config, exists := e.gameConfigs[gameType]
+	if !exists {
+		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+	}
+
+	return e.newGameState(config, playerUsername), nil
+}
+
+// newGameState builds a fresh in-progress game state for the given config
+func (e *MiniGameEngine) newGameState(config *GameConfig, playerUsername string) *GameState {
 	gameState := &GameState{
-		SessionID:      sessionID,
-		GameType:       gameType,
+		SessionID:      uuid.New(),
+		GameType:       config.Type,
 		PlayerUsername: playerUsername,
 		StartTime:      time.Now(),
 		CurrentScore:   0,
@@ -173,7 +223,7 @@ func (e *MiniGameEngine) StartGameSession(gameType GameType, playerUsername stri
 	}
 
 	// Initialize game-specific data
-	switch gameType {
+	switch config.Type {
 	case GameTypeClickSpeed:
 		gameState.GameData["clicks"] = 0
 		gameState.GameData["maxClicks"] = config.MaxScore
@@ -185,13 +235,14 @@ func (e *MiniGameEngine) StartGameSession(gameType GameType, playerUsername stri
 		gameState.GameData["targetNumber"] = e.generateRandomNumber(1, 100)
 		gameState.GameData["attempts"] = 0
 		gameState.GameData["maxAttempts"] = 10
+	case GameTypePaintBattle:
+		gameState.GameData["cellsPainted"] = 0
+	case GameTypePhysicsJump:
+		gameState.GameData["platform"] = 0
+		gameState.GameData["falls"] = 0
 	}
 
-	e.sessionMutex.Lock()
-	e.activeSessions[sessionID] = gameState
-	e.sessionMutex.Unlock()
-
-	return gameState, nil
+	return gameState
 }
 
 // ProcessGameAction processes a game action and updates the game state
@@ -217,7 +268,12 @@ func (e *MiniGameEngine) ProcessGameAction(sessionID uuid.UUID, action GameActio
 		return e.endGameSession(sessionID, "timeout")
 	}
 
-	// Process action based on game type
+	return e.ApplyAction(gameState, action)
+}
+
+// ApplyAction applies the game-specific rules for an action to a game state.
+// It does not check timeouts or session registration; callers own the state.
+func (e *MiniGameEngine) ApplyAction(gameState *GameState, action GameAction) (*GameState, error) {
 	switch gameState.GameType {
 	case GameTypeClickSpeed:
 		return e.processClickSpeedAction(gameState, action)
@@ -225,6 +281,10 @@ func (e *MiniGameEngine) ProcessGameAction(sessionID uuid.UUID, action GameActio
 		return e.processMemoryMatchAction(gameState, action)
 	case GameTypeNumberGuess:
 		return e.processNumberGuessAction(gameState, action)
+	case GameTypePaintBattle:
+		return e.processPaintBattleAction(gameState, action)
+	case GameTypePhysicsJump:
+		return e.processPhysicsJumpAction(gameState, action)
 	default:
 		return nil, fmt.Errorf("unsupported game type: %s", gameState.GameType)
 	}
@@ -275,6 +335,14 @@ func (e *MiniGameEngine) CalculateReward(result *GameResult) (*GameResult, error
 		return nil, fmt.Errorf("unsupported game type: %s", result.GameType)
 	}
 
+	// Scores the server cannot check are never paid out
+	if config.Unverified {
+		result.IsValid = false
+		result.Reason = fmt.Sprintf("%s scores are not verified by the server", result.GameType)
+		result.PointsEarned = 0
+		return result, nil
+	}
+
 	// Validate score range
 	if result.FinalScore < config.MinValidScore || result.FinalScore > config.MaxValidScore {
 		result.IsValid = false
@@ -396,6 +464,53 @@ func (e *MiniGameEngine) processNumberGuessAction(gameState *GameState, action G
 	return gameState, nil
 }
 
+// maxCellsPerPaint caps how many cells a single paint stroke can claim
+const maxCellsPerPaint = 5
+
+func (e *MiniGameEngine) processPaintBattleAction(gameState *GameState, action GameAction) (*GameState, error) {
+	if action.Type != "paint" {
+		return gameState, fmt.Errorf("invalid action type for paint battle game: %s", action.Type)
+	}
+
+	cells, ok := action.Data["cells"].(float64)
+	if !ok || cells < 1 || cells > maxCellsPerPaint {
+		return gameState, fmt.Errorf("invalid paint data")
+	}
+
+	painted, _ := gameState.GameData["cellsPainted"].(int)
+	painted += int(cells)
+	gameState.GameData["cellsPainted"] = painted
+	gameState.CurrentScore = painted
+
+	return gameState, nil
+}
+
+func (e *MiniGameEngine) processPhysicsJumpAction(gameState *GameState, action GameAction) (*GameState, error) {
+	platform, _ := gameState.GameData["platform"].(int)
+
+	switch action.Type {
+	case "land":
+		// Platforms must be reached one at a time
+		target, ok := action.Data["platform"].(float64)
+		if !ok || int(target) != platform+1 {
+			return gameState, fmt.Errorf("invalid landing platform")
+		}
+		platform++
+		gameState.GameData["platform"] = platform
+		if platform > gameState.CurrentScore {
+			gameState.CurrentScore = platform
+		}
+	case "fall":
+		falls, _ := gameState.GameData["falls"].(int)
+		gameState.GameData["falls"] = falls + 1
+		gameState.GameData["platform"] = 0
+	default:
+		return gameState, fmt.Errorf("invalid action type for physics jump game: %s", action.Type)
+	}
+
+	return gameState, nil
+}
+
 func (e *MiniGameEngine) generateRandomNumber(min, max int) int {
 	// Simple random number generation - in production, use crypto/rand for better security
 	return min + int(time.Now().UnixNano())%(max-min+1)
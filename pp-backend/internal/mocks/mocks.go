@@ -558,3 +558,61 @@ func (m *MockTransactionRepository) CountPaymentsByStatus(status string) (int, e
 	args := m.Called(status)
 	return args.Int(0), args.Error(1)
 }
+
+type MockPlayerRatingRepository struct {
+	mock.Mock
+}
+
+func (m *MockPlayerRatingRepository) GetRating(username, gameType string) (*repository.PlayerRating, error) {
+	args := m.Called(username, gameType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.PlayerRating), args.Error(1)
+}
+
+func (m *MockPlayerRatingRepository) ListRatings(username string) ([]*repository.PlayerRating, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.PlayerRating), args.Error(1)
+}
+
+func (m *MockPlayerRatingRepository) SaveRatingChange(rating *repository.PlayerRating, change *repository.PlayerRatingChange) error {
+	args := m.Called(rating, change)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRatingRepository) ListRatingHistory(username string, limit int) ([]*repository.PlayerRatingChange, error) {
+	args := m.Called(username, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.PlayerRatingChange), args.Error(1)
+}
+
+type MockMatchRepository struct {
+	mock.Mock
+}
+
+func (m *MockMatchRepository) CreateMatch(match *repository.Match) error {
+	args := m.Called(match)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) ListUserMatches(username string, limit, offset int) ([]*repository.Match, error) {
+	args := m.Called(username, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetMatchStats() (*repository.MatchStats, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.MatchStats), args.Error(1)
+}
@@ -0,0 +1,104 @@
+// backend/internal/repository/match_replay_repo.go
+
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var ErrMatchReplayNotFound = errors.New("match replay not found")
+
+// MatchReplay is the recorded event stream of a closed multiplayer room.
+type MatchReplay struct {
+	RoomID       uuid.UUID
+	GameType     string
+	HostUsername string
+	IsPrivate    bool
+	Participants []string
+	Replay       json.RawMessage
+	StartedAt    sql.NullTime
+	EndedAt      sql.NullTime
+	ClosedAt     time.Time
+	CreatedAt    time.Time
+}
+
+// MatchReplayRepository provides persistence for room replays.
+type MatchReplayRepository interface {
+	CreateReplay(replay *MatchReplay) error
+	GetReplay(roomID uuid.UUID) (*MatchReplay, error)
+}
+
+type matchReplayRepository struct {
+	db DBTX
+}
+
+// NewMatchReplayRepository creates a new repository backed by Postgres.
+func NewMatchReplayRepository(db DBTX) MatchReplayRepository {
+	return &matchReplayRepository{db: db}
+}
+
+// CreateReplay stores the replay of a closed room. A room is recorded once; later writes are ignored.
+func (r *matchReplayRepository) CreateReplay(replay *MatchReplay) error {
+	query := `
+		INSERT INTO match_replays (room_id, game_type, host_username, is_private, participants, replay, started_at, ended_at, closed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (room_id) DO NOTHING
+	`
+
+	_, err := r.db.Exec(query,
+		replay.RoomID,
+		replay.GameType,
+		replay.HostUsername,
+		replay.IsPrivate,
+		pq.Array(replay.Participants),
+		[]byte(replay.Replay),
+		replay.StartedAt,
+		replay.EndedAt,
+		replay.ClosedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create match replay: %w", err)
+	}
+
+	return nil
+}
+
+// GetReplay returns the stored replay of a room.
+func (r *matchReplayRepository) GetReplay(roomID uuid.UUID) (*MatchReplay, error) {
+	query := `
+		SELECT room_id, game_type, host_username, is_private, participants, replay, started_at, ended_at, closed_at, created_at
+		FROM match_replays
+		WHERE room_id = $1
+	`
+
+	var replay MatchReplay
+	var data []byte
+	err := r.db.QueryRow(query, roomID).Scan(
+		&replay.RoomID,
+		&replay.GameType,
+		&replay.HostUsername,
+		&replay.IsPrivate,
+		pq.Array(&replay.Participants),
+		&data,
+		&replay.StartedAt,
+		&replay.EndedAt,
+		&replay.ClosedAt,
+		&replay.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMatchReplayNotFound
+		}
+		return nil, fmt.Errorf("failed to get match replay: %w", err)
+	}
+	replay.Replay = data
+
+	return &replay, nil
+}
@@ -0,0 +1,234 @@
+// backend/internal/repository/match_repo.go
+
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Match is one finished multiplayer room game.
+type Match struct {
+	ID           uuid.UUID
+	RoomID       uuid.UUID
+	SessionID    uuid.UUID
+	GameType     string
+	EndReason    string
+	PlayerCount  int
+	StartedAt    time.Time
+	EndedAt      time.Time
+	DurationMs   int64
+	Participants []*MatchParticipant
+	CreatedAt    time.Time
+}
+
+// MatchParticipant is one player's outcome of a match.
+type MatchParticipant struct {
+	Username     string
+	Placement    int
+	Score        int
+	PointsEarned int
+	IsValid      bool
+}
+
+// MatchStats aggregates every recorded match.
+type MatchStats struct {
+	TotalMatches    int64
+	TotalPlayers    int64 // Distinct players over all matches
+	AverageDuration time.Duration
+	GameTypes       map[string]int // Matches per game type
+}
+
+// MatchRepository provides persistence for the match history.
+type MatchRepository interface {
+	CreateMatch(match *Match) error
+	ListUserMatches(username string, limit, offset int) ([]*Match, error)
+	GetMatchStats() (*MatchStats, error)
+}
+
+type matchRepository struct {
+	db DBTX
+}
+
+// NewMatchRepository creates a new repository backed by Postgres.
+func NewMatchRepository(db DBTX) MatchRepository {
+	return &matchRepository{db: db}
+}
+
+// CreateMatch stores a match together with all of its participants.
+func (r *matchRepository) CreateMatch(match *Match) error {
+	query := `
+		WITH inserted AS (
+			INSERT INTO matches (room_id, session_id, game_type, end_reason, player_count, started_at, ended_at, duration_ms)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id, created_at
+		), participants AS (
+			INSERT INTO match_participants (match_id, username, placement, score, points_earned, is_valid)
+			SELECT inserted.id, p.username, p.placement, p.score, p.points_earned, p.is_valid
+			FROM inserted, unnest($9::text[], $10::int[], $11::int[], $12::int[], $13::boolean[])
+				AS p(username, placement, score, points_earned, is_valid)
+		)
+		SELECT id, created_at FROM inserted
+	`
+
+	count := len(match.Participants)
+	usernames := make([]string, count)
+	placements := make([]int64, count)
+	scores := make([]int64, count)
+	points := make([]int64, count)
+	valid := make([]bool, count)
+	for i, participant := range match.Participants {
+		usernames[i] = participant.Username
+		placements[i] = int64(participant.Placement)
+		scores[i] = int64(participant.Score)
+		points[i] = int64(participant.PointsEarned)
+		valid[i] = participant.IsValid
+	}
+
+	err := r.db.QueryRow(query,
+		match.RoomID,
+		match.SessionID,
+		match.GameType,
+		match.EndReason,
+		match.PlayerCount,
+		match.StartedAt,
+		match.EndedAt,
+		match.DurationMs,
+		pq.Array(usernames),
+		pq.Array(placements),
+		pq.Array(scores),
+		pq.Array(points),
+		pq.Array(valid),
+	).Scan(&match.ID, &match.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create match: %w", err)
+	}
+
+	return nil
+}
+
+// ListUserMatches returns the matches a player took part in, newest first, with all their participants.
+func (r *matchRepository) ListUserMatches(username string, limit, offset int) ([]*Match, error) {
+	query := `
+		SELECT m.id, m.room_id, m.session_id, m.game_type, m.end_reason, m.player_count,
+			m.started_at, m.ended_at, m.duration_ms, m.created_at
+		FROM matches m
+		JOIN match_participants mp ON mp.match_id = m.id
+		WHERE mp.username = $1
+		ORDER BY m.ended_at DESC, m.id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, username, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matches: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []*Match
+	byID := make(map[uuid.UUID]*Match)
+	ids := make([]string, 0)
+	for rows.Next() {
+		var match Match
+		if err := rows.Scan(
+			&match.ID,
+			&match.RoomID,
+			&match.SessionID,
+			&match.GameType,
+			&match.EndReason,
+			&match.PlayerCount,
+			&match.StartedAt,
+			&match.EndedAt,
+			&match.DurationMs,
+			&match.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan match: %w", err)
+		}
+		matches = append(matches, &match)
+		byID[match.ID] = &match
+		ids = append(ids, match.ID.String())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return matches, nil
+	}
+
+	if err := r.loadParticipants(ids, byID); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// loadParticipants attaches the participants of the given matches, best placement first.
+func (r *matchRepository) loadParticipants(ids []string, byID map[uuid.UUID]*Match) error {
+	query := `
+		SELECT match_id, username, placement, score, points_earned, is_valid
+		FROM match_participants
+		WHERE match_id = ANY($1::uuid[])
+		ORDER BY match_id, placement, username
+	`
+
+	rows, err := r.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to list match participants: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var matchID uuid.UUID
+		var participant MatchParticipant
+		if err := rows.Scan(
+			&matchID,
+			&participant.Username,
+			&participant.Placement,
+			&participant.Score,
+			&participant.PointsEarned,
+			&participant.IsValid,
+		); err != nil {
+			return fmt.Errorf("failed to scan match participant: %w", err)
+		}
+		if match, exists := byID[matchID]; exists {
+			match.Participants = append(match.Participants, &participant)
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetMatchStats aggregates the whole match history.
+func (r *matchRepository) GetMatchStats() (*MatchStats, error) {
+	stats := &MatchStats{GameTypes: make(map[string]int)}
+
+	var averageMs float64
+	err := r.db.QueryRow(`
+		SELECT COUNT(*), COALESCE(AVG(duration_ms), 0),
+			(SELECT COUNT(DISTINCT username) FROM match_participants)
+		FROM matches
+	`).Scan(&stats.TotalMatches, &averageMs, &stats.TotalPlayers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match stats: %w", err)
+	}
+	stats.AverageDuration = time.Duration(averageMs * float64(time.Millisecond))
+
+	rows, err := r.db.Query(`SELECT game_type, COUNT(*) FROM matches GROUP BY game_type`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count matches per game type: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var gameType string
+		var count int
+		if err := rows.Scan(&gameType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan game type count: %w", err)
+		}
+		stats.GameTypes[gameType] = count
+	}
+
+	return stats, rows.Err()
+}
@@ -0,0 +1,196 @@
+// backend/internal/repository/player_rating_repo.go
+
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrPlayerRatingNotFound = errors.New("player rating not found")
+
+// PlayerRating is a player's Glicko-2 rating for one game type.
+type PlayerRating struct {
+	Username    string
+	GameType    string
+	Rating      float64
+	Deviation   float64
+	Volatility  float64
+	GamesPlayed int
+	UpdatedAt   time.Time
+}
+
+// PlayerRatingChange records how one room result moved a player's rating.
+type PlayerRatingChange struct {
+	ID             int64
+	Username       string
+	GameType       string
+	RoomID         uuid.UUID
+	Placement      int
+	Opponents      int
+	RatingBefore   float64
+	RatingAfter    float64
+	DeviationAfter float64
+	CreatedAt      time.Time
+}
+
+// PlayerRatingRepository provides persistence for skill ratings.
+type PlayerRatingRepository interface {
+	GetRating(username, gameType string) (*PlayerRating, error)
+	ListRatings(username string) ([]*PlayerRating, error)
+	SaveRatingChange(rating *PlayerRating, change *PlayerRatingChange) error
+	ListRatingHistory(username string, limit int) ([]*PlayerRatingChange, error)
+}
+
+type playerRatingRepository struct {
+	db DBTX
+}
+
+// NewPlayerRatingRepository creates a new repository backed by Postgres.
+func NewPlayerRatingRepository(db DBTX) PlayerRatingRepository {
+	return &playerRatingRepository{db: db}
+}
+
+// GetRating returns a player's rating for a game type.
+func (r *playerRatingRepository) GetRating(username, gameType string) (*PlayerRating, error) {
+	query := `
+		SELECT username, game_type, rating, deviation, volatility, games_played, updated_at
+		FROM player_ratings
+		WHERE username = $1 AND game_type = $2
+	`
+
+	var rating PlayerRating
+	err := r.db.QueryRow(query, username, gameType).Scan(
+		&rating.Username,
+		&rating.GameType,
+		&rating.Rating,
+		&rating.Deviation,
+		&rating.Volatility,
+		&rating.GamesPlayed,
+		&rating.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlayerRatingNotFound
+		}
+		return nil, fmt.Errorf("failed to get player rating: %w", err)
+	}
+
+	return &rating, nil
+}
+
+// ListRatings returns a player's ratings for every game type they played.
+func (r *playerRatingRepository) ListRatings(username string) ([]*PlayerRating, error) {
+	query := `
+		SELECT username, game_type, rating, deviation, volatility, games_played, updated_at
+		FROM player_ratings
+		WHERE username = $1
+		ORDER BY game_type
+	`
+
+	rows, err := r.db.Query(query, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list player ratings: %w", err)
+	}
+	defer rows.Close()
+
+	var ratings []*PlayerRating
+	for rows.Next() {
+		var rating PlayerRating
+		if err := rows.Scan(
+			&rating.Username,
+			&rating.GameType,
+			&rating.Rating,
+			&rating.Deviation,
+			&rating.Volatility,
+			&rating.GamesPlayed,
+			&rating.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan player rating: %w", err)
+		}
+		ratings = append(ratings, &rating)
+	}
+
+	return ratings, rows.Err()
+}
+
+// SaveRatingChange stores a player's new rating together with the history entry that explains it.
+func (r *playerRatingRepository) SaveRatingChange(rating *PlayerRating, change *PlayerRatingChange) error {
+	query := `
+		WITH upserted AS (
+			INSERT INTO player_ratings (username, game_type, rating, deviation, volatility, games_played, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW())
+			ON CONFLICT (username, game_type) DO UPDATE
+			SET rating = EXCLUDED.rating,
+				deviation = EXCLUDED.deviation,
+				volatility = EXCLUDED.volatility,
+				games_played = EXCLUDED.games_played,
+				updated_at = EXCLUDED.updated_at
+			RETURNING username
+		)
+		INSERT INTO player_rating_history (username, game_type, room_id, placement, opponents, rating_before, rating_after, deviation_after)
+		SELECT username, $2, $7, $8, $9, $10, $3, $4 FROM upserted
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query,
+		rating.Username,
+		rating.GameType,
+		rating.Rating,
+		rating.Deviation,
+		rating.Volatility,
+		rating.GamesPlayed,
+		change.RoomID,
+		change.Placement,
+		change.Opponents,
+		change.RatingBefore,
+	).Scan(&change.ID, &change.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save player rating: %w", err)
+	}
+
+	return nil
+}
+
+// ListRatingHistory returns a player's most recent rating changes, newest first.
+func (r *playerRatingRepository) ListRatingHistory(username string, limit int) ([]*PlayerRatingChange, error) {
+	query := `
+		SELECT id, username, game_type, room_id, placement, opponents, rating_before, rating_after, deviation_after, created_at
+		FROM player_rating_history
+		WHERE username = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, username, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rating history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []*PlayerRatingChange
+	for rows.Next() {
+		var change PlayerRatingChange
+		if err := rows.Scan(
+			&change.ID,
+			&change.Username,
+			&change.GameType,
+			&change.RoomID,
+			&change.Placement,
+			&change.Opponents,
+			&change.RatingBefore,
+			&change.RatingAfter,
+			&change.DeviationAfter,
+			&change.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan rating history: %w", err)
+		}
+		history = append(history, &change)
+	}
+
+	return history, rows.Err()
+}
@@ -60,11 +60,11 @@ func NewPostgresUserRepository(db DBTX) UserRepository {
 }
 
 func (r *postgresUserRepository) Find(username string) (*User, error) {
-	query := "SELECT username, password_hash, role, nickname, profile_picture_url, status_message, last_online_at, is_active, deleted_at, kakao_id FROM users WHERE username = $1"
+	query := "SELECT username, password_hash, role, nickname, profile_picture_url, status_message, last_online_at, is_active, deleted_at, banned_at, kakao_id FROM users WHERE username = $1"
 	row := r.db.QueryRow(query, username)
 
 	var user User
-	if err := row.Scan(&user.Username, &user.PasswordHash, &user.Role, &user.Nickname, &user.ProfilePictureURL, &user.StatusMessage, &user.LastOnlineAt, &user.IsActive, &user.DeletedAt, &user.KakaoID); err != nil {
+	if err := row.Scan(&user.Username, &user.PasswordHash, &user.Role, &user.Nickname, &user.ProfilePictureURL, &user.StatusMessage, &user.LastOnlineAt, &user.IsActive, &user.DeletedAt, &user.BannedAt, &user.KakaoID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrUserNotFound
 		}
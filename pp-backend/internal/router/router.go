@@ -10,7 +10,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/pitturu-ppaturu/backend/internal/auth"
 	"github.com/pitturu-ppaturu/backend/internal/container"
+	"github.com/pitturu-ppaturu/backend/internal/gameserver"
 	"github.com/pitturu-ppaturu/backend/internal/middleware"
+	"github.com/pitturu-ppaturu/backend/internal/minigame"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -120,6 +122,8 @@ func Setup(r *gin.Engine, c *container.Container) {
 			protected.PUT("/game-sessions/:session_id/end", c.GameHandler.EndGameSession)
 			protected.GET("/games/:game_id/scores", c.GameHandler.ListGameScoresByGameID)
 			protected.GET("/users/:username/scores", c.GameHandler.ListGameScoresByPlayerUsername)
+			protected.GET("/users/:username/ratings", c.RatingHandler.GetUserRatings)
+			protected.GET("/users/:username/matches", c.MatchHandler.GetUserMatches)
 
 			// Payment routes
 			protected.POST("/items", c.PaymentHandler.CreateItem)
@@ -164,9 +168,14 @@ func Setup(r *gin.Engine, c *container.Container) {
 						return
 					}
 					rooms := c.GameServer.GetRoomManager().ListPublicRooms()
-					roomList := make([]map[string]interface{}, len(rooms))
-					for i, room := range rooms {
-						roomList[i] = room.GetRoomStats()
+					spectatableOnly := ctx.Query("spectatable") == "true"
+					roomList := make([]map[string]interface{}, 0, len(rooms))
+					for _, room := range rooms {
+						stats := room.GetRoomStats()
+						if spectatableOnly && stats["allowSpectators"] != true {
+							continue
+						}
+						roomList = append(roomList, stats)
 					}
 					ctx.JSON(200, gin.H{
 						"rooms": roomList,
@@ -182,11 +191,29 @@ func Setup(r *gin.Engine, c *container.Container) {
 						})
 						return
 					}
-					// TODO: Implement room creation via HTTP API
-					ctx.JSON(501, gin.H{
-						"error": "Room creation via HTTP not implemented yet",
-						"note":  "Use WebSocket connection to create rooms",
-					})
+					username, _ := ctx.Get("user")
+					host, _ := username.(string)
+					if host == "" {
+						ctx.JSON(401, gin.H{"error": "unauthorized", "code": "UNAUTHORIZED"})
+						return
+					}
+
+					var req struct {
+						GameType string                 `json:"gameType" binding:"required"`
+						Settings map[string]interface{} `json:"settings"`
+					}
+					if err := ctx.ShouldBindJSON(&req); err != nil {
+						ctx.JSON(400, gin.H{"error": err.Error(), "code": "INVALID_REQUEST"})
+						return
+					}
+
+					room, err := c.GameServer.GetRoomManager().CreateRoom(host, minigame.GameType(req.GameType), req.Settings)
+					if err != nil {
+						status, code := gameserver.RoomErrorCode(err)
+						ctx.JSON(status, gin.H{"error": err.Error(), "code": code})
+						return
+					}
+					ctx.JSON(201, room.GetRoomStats())
 				})
 
 				// Game server status and stats
@@ -0,0 +1,52 @@
+// backend/internal/service/match_service.go
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+)
+
+const (
+	defaultMatchPageSize = 20
+	maxMatchPageSize     = 100
+)
+
+type MatchService interface {
+	ListUserMatches(username string, limit, offset int) ([]*repository.Match, error)
+}
+
+type matchService struct {
+	matchRepo repository.MatchRepository
+	userRepo  repository.UserRepository
+}
+
+func NewMatchService(matchRepo repository.MatchRepository, userRepo repository.UserRepository) MatchService {
+	return &matchService{
+		matchRepo: matchRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// ListUserMatches returns one page of a player's match history, newest first.
+func (s *matchService) ListUserMatches(username string, limit, offset int) ([]*repository.Match, error) {
+	if _, err := s.userRepo.Find(username); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxMatchPageSize {
+		limit = defaultMatchPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	matches, err := s.matchRepo.ListUserMatches(username, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matches: %w", err)
+	}
+	if matches == nil {
+		matches = []*repository.Match{}
+	}
+	return matches, nil
+}
@@ -0,0 +1,50 @@
+// backend/internal/service/match_service_test.go
+
+package service_test
+
+import (
+	"testing"
+
+	"github.com/pitturu-ppaturu/backend/internal/mocks"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchService_ListUserMatches(t *testing.T) {
+	mockMatchRepo := new(mocks.MockMatchRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	svc := service.NewMatchService(mockMatchRepo, mockUserRepo)
+
+	mockUserRepo.On("Find", "alice").Return(&repository.User{}, nil).Twice()
+	mockMatchRepo.On("ListUserMatches", "alice", 20, 0).Return(nil, nil).Once()
+	mockMatchRepo.On("ListUserMatches", "alice", 5, 10).Return([]*repository.Match{{GameType: "puzzle"}}, nil).Once()
+
+	// Out of range paging falls back to the defaults
+	matches, err := svc.ListUserMatches("alice", 1000, -3)
+	require.NoError(t, err)
+	assert.NotNil(t, matches)
+	assert.Empty(t, matches)
+
+	matches, err = svc.ListUserMatches("alice", 5, 10)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "puzzle", matches[0].GameType)
+
+	mockMatchRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestMatchService_ListUserMatches_UnknownUser(t *testing.T) {
+	mockMatchRepo := new(mocks.MockMatchRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	svc := service.NewMatchService(mockMatchRepo, mockUserRepo)
+
+	mockUserRepo.On("Find", "ghost").Return(&repository.User{}, repository.ErrUserNotFound).Once()
+
+	_, err := svc.ListUserMatches("ghost", 20, 0)
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+	mockMatchRepo.AssertNotCalled(t, "ListUserMatches")
+}
@@ -0,0 +1,240 @@
+// backend/internal/service/rating_service.go
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+)
+
+// Glicko-2 system constants.
+const (
+	DefaultRating     = 1500.0
+	DefaultDeviation  = 350.0
+	DefaultVolatility = 0.06
+
+	glickoScale       = 173.7178
+	glickoTau         = 0.5
+	glickoEpsilon     = 0.000001
+	minDeviation      = 30.0
+	ratingHistorySize = 20
+)
+
+// RatingPlacement is one player's final placement in a room, 1 being the winner.
+// Players with the same placement tied.
+type RatingPlacement struct {
+	Username  string
+	Placement int
+}
+
+// RatingProfile is a player's ratings for every game type plus their recent rating changes.
+type RatingProfile struct {
+	Username string
+	Ratings  []*repository.PlayerRating
+	History  []*repository.PlayerRatingChange
+}
+
+type RatingService interface {
+	GetRating(username, gameType string) (*repository.PlayerRating, error)
+	RecordPlacements(roomID uuid.UUID, gameType string, placements []RatingPlacement) ([]*repository.PlayerRatingChange, error)
+	GetProfile(username string, historyLimit int) (*RatingProfile, error)
+}
+
+type ratingService struct {
+	ratingRepo repository.PlayerRatingRepository
+	userRepo   repository.UserRepository
+}
+
+func NewRatingService(ratingRepo repository.PlayerRatingRepository, userRepo repository.UserRepository) RatingService {
+	return &ratingService{
+		ratingRepo: ratingRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// GetRating returns a player's rating for a game type, or the starting rating if they have not played it yet.
+func (s *ratingService) GetRating(username, gameType string) (*repository.PlayerRating, error) {
+	rating, err := s.ratingRepo.GetRating(username, gameType)
+	if err != nil {
+		if errors.Is(err, repository.ErrPlayerRatingNotFound) {
+			return &repository.PlayerRating{
+				Username:   username,
+				GameType:   gameType,
+				Rating:     DefaultRating,
+				Deviation:  DefaultDeviation,
+				Volatility: DefaultVolatility,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get rating: %w", err)
+	}
+	return rating, nil
+}
+
+// RecordPlacements rates every player against every other player in the room.
+// Each pair counts as one game in a single rating period: a better placement is a win, an equal one a draw.
+func (s *ratingService) RecordPlacements(roomID uuid.UUID, gameType string, placements []RatingPlacement) ([]*repository.PlayerRatingChange, error) {
+	if len(placements) < 2 {
+		return nil, nil
+	}
+
+	current := make([]*repository.PlayerRating, len(placements))
+	for i, p := range placements {
+		rating, err := s.GetRating(p.Username, gameType)
+		if err != nil {
+			return nil, err
+		}
+		current[i] = rating
+	}
+
+	changes := make([]*repository.PlayerRatingChange, 0, len(placements))
+	for i, p := range placements {
+		var opponents []glickoResult
+		for j, other := range placements {
+			if i == j {
+				continue
+			}
+			score := 0.5
+			if p.Placement < other.Placement {
+				score = 1
+			} else if p.Placement > other.Placement {
+				score = 0
+			}
+			opponents = append(opponents, glickoResult{
+				rating:    current[j].Rating,
+				deviation: current[j].Deviation,
+				score:     score,
+			})
+		}
+
+		before := current[i]
+		rating, deviation, volatility := glickoUpdate(before.Rating, before.Deviation, before.Volatility, opponents)
+
+		updated := &repository.PlayerRating{
+			Username:    p.Username,
+			GameType:    gameType,
+			Rating:      rating,
+			Deviation:   deviation,
+			Volatility:  volatility,
+			GamesPlayed: before.GamesPlayed + 1,
+		}
+		change := &repository.PlayerRatingChange{
+			Username:       p.Username,
+			GameType:       gameType,
+			RoomID:         roomID,
+			Placement:      p.Placement,
+			Opponents:      len(opponents),
+			RatingBefore:   before.Rating,
+			RatingAfter:    rating,
+			DeviationAfter: deviation,
+		}
+		if err := s.ratingRepo.SaveRatingChange(updated, change); err != nil {
+			return changes, fmt.Errorf("failed to save rating for %s: %w", p.Username, err)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// GetProfile returns a player's ratings and their most recent rating changes.
+func (s *ratingService) GetProfile(username string, historyLimit int) (*RatingProfile, error) {
+	if _, err := s.userRepo.Find(username); err != nil {
+		return nil, err
+	}
+	if historyLimit <= 0 || historyLimit > 100 {
+		historyLimit = ratingHistorySize
+	}
+
+	ratings, err := s.ratingRepo.ListRatings(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ratings: %w", err)
+	}
+	history, err := s.ratingRepo.ListRatingHistory(username, historyLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating history: %w", err)
+	}
+
+	return &RatingProfile{
+		Username: username,
+		Ratings:  ratings,
+		History:  history,
+	}, nil
+}
+
+type glickoResult struct {
+	rating    float64
+	deviation float64
+	score     float64
+}
+
+// glickoUpdate applies one Glicko-2 rating period and returns the new rating, deviation and volatility.
+func glickoUpdate(rating, deviation, volatility float64, results []glickoResult) (float64, float64, float64) {
+	mu := (rating - DefaultRating) / glickoScale
+	phi := deviation / glickoScale
+
+	var vInv, deltaSum float64
+	for _, r := range results {
+		muJ := (r.rating - DefaultRating) / glickoScale
+		g := glickoG(r.deviation / glickoScale)
+		e := 1 / (1 + math.Exp(-g*(mu-muJ)))
+		vInv += g * g * e * (1 - e)
+		deltaSum += g * (r.score - e)
+	}
+	v := 1 / vInv
+	delta := v * deltaSum
+
+	sigma := glickoVolatility(phi, v, delta, volatility)
+
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phiNew := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	muNew := mu + phiNew*phiNew*deltaSum
+
+	newDeviation := math.Max(phiNew*glickoScale, minDeviation)
+	newDeviation = math.Min(newDeviation, DefaultDeviation)
+	return muNew*glickoScale + DefaultRating, newDeviation, sigma
+}
+
+func glickoG(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// glickoVolatility finds the new volatility with the Illinois algorithm from the Glicko-2 paper.
+func glickoVolatility(phi, v, delta, sigma float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		num := ex * (delta*delta - phi*phi - v - ex)
+		den := 2 * (phi*phi + v + ex) * (phi*phi + v + ex)
+		return num/den - (x-a)/(glickoTau*glickoTau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*glickoTau) < 0 {
+			k++
+		}
+		B = a - k*glickoTau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > glickoEpsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
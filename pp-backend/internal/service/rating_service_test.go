@@ -0,0 +1,76 @@
+// backend/internal/service/rating_service_test.go
+
+package service_test
+
+import (
+	"testing"
+
+	"github.com/pitturu-ppaturu/backend/internal/mocks"
+	"github.com/pitturu-ppaturu/backend/internal/repository"
+	"github.com/pitturu-ppaturu/backend/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingService_GetRating_Default(t *testing.T) {
+	mockRatingRepo := new(mocks.MockPlayerRatingRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	svc := service.NewRatingService(mockRatingRepo, mockUserRepo)
+
+	mockRatingRepo.On("GetRating", "alice", "click_speed").Return(nil, repository.ErrPlayerRatingNotFound).Once()
+	rating, err := svc.GetRating("alice", "click_speed")
+	require.NoError(t, err)
+	assert.Equal(t, service.DefaultRating, rating.Rating)
+	assert.Equal(t, service.DefaultDeviation, rating.Deviation)
+	assert.Equal(t, 0, rating.GamesPlayed)
+	mockRatingRepo.AssertExpectations(t)
+}
+
+func TestRatingService_RecordPlacements(t *testing.T) {
+	mockRatingRepo := new(mocks.MockPlayerRatingRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	svc := service.NewRatingService(mockRatingRepo, mockUserRepo)
+
+	roomID := uuid.New()
+	mockRatingRepo.On("GetRating", "alice", "puzzle").Return(nil, repository.ErrPlayerRatingNotFound).Once()
+	mockRatingRepo.On("GetRating", "bob", "puzzle").Return(&repository.PlayerRating{
+		Username: "bob", GameType: "puzzle", Rating: 1500, Deviation: 200, Volatility: 0.06, GamesPlayed: 4,
+	}, nil).Once()
+	mockRatingRepo.On("GetRating", "carol", "puzzle").Return(nil, repository.ErrPlayerRatingNotFound).Once()
+	mockRatingRepo.On("SaveRatingChange", mock.AnythingOfType("*repository.PlayerRating"), mock.AnythingOfType("*repository.PlayerRatingChange")).Return(nil).Times(3)
+
+	changes, err := svc.RecordPlacements(roomID, "puzzle", []service.RatingPlacement{
+		{Username: "alice", Placement: 1},
+		{Username: "bob", Placement: 2},
+		{Username: "carol", Placement: 3},
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	assert.Greater(t, changes[0].RatingAfter, changes[0].RatingBefore)
+	assert.Less(t, changes[2].RatingAfter, changes[2].RatingBefore)
+	for _, change := range changes {
+		assert.Equal(t, roomID, change.RoomID)
+		assert.Equal(t, 2, change.Opponents)
+		assert.Less(t, change.DeviationAfter, service.DefaultDeviation)
+	}
+
+	saved := mockRatingRepo.Calls[len(mockRatingRepo.Calls)-2].Arguments.Get(0).(*repository.PlayerRating)
+	assert.Equal(t, "bob", saved.Username)
+	assert.Equal(t, 5, saved.GamesPlayed)
+	mockRatingRepo.AssertExpectations(t)
+}
+
+func TestRatingService_RecordPlacements_SinglePlayerIsIgnored(t *testing.T) {
+	mockRatingRepo := new(mocks.MockPlayerRatingRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	svc := service.NewRatingService(mockRatingRepo, mockUserRepo)
+
+	changes, err := svc.RecordPlacements(uuid.New(), "puzzle", []service.RatingPlacement{{Username: "alice", Placement: 1}})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	mockRatingRepo.AssertNotCalled(t, "SaveRatingChange", mock.Anything, mock.Anything)
+}